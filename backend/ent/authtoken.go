@@ -0,0 +1,224 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/user"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// AuthToken is the model entity for the AuthToken schema.
+type AuthToken struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind authtoken.Kind `json:"kind,omitempty"`
+	// TokenHash holds the value of the "token_hash" field.
+	TokenHash string `json:"-"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// UsedAt holds the value of the "used_at" field.
+	UsedAt *time.Time `json:"used_at,omitempty"`
+	// Attempts holds the value of the "attempts" field.
+	Attempts int `json:"attempts,omitempty"`
+	// IP holds the value of the "ip" field.
+	IP string `json:"ip,omitempty"`
+	// Email holds the value of the "email" field.
+	Email string `json:"email,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the AuthTokenQuery when eager-loading is set.
+	Edges            AuthTokenEdges `json:"edges"`
+	user_auth_tokens *int
+	selectValues     sql.SelectValues
+}
+
+// AuthTokenEdges holds the relations/edges for other nodes in the graph.
+type AuthTokenEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e AuthTokenEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*AuthToken) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case authtoken.FieldID, authtoken.FieldAttempts:
+			values[i] = new(sql.NullInt64)
+		case authtoken.FieldKind, authtoken.FieldTokenHash, authtoken.FieldIP, authtoken.FieldEmail:
+			values[i] = new(sql.NullString)
+		case authtoken.FieldExpiresAt, authtoken.FieldUsedAt, authtoken.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case authtoken.ForeignKeys[0]: // user_auth_tokens
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the AuthToken fields.
+func (at *AuthToken) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case authtoken.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			at.ID = int(value.Int64)
+		case authtoken.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				at.Kind = authtoken.Kind(value.String)
+			}
+		case authtoken.FieldTokenHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[i])
+			} else if value.Valid {
+				at.TokenHash = value.String
+			}
+		case authtoken.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				at.ExpiresAt = value.Time
+			}
+		case authtoken.FieldUsedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field used_at", values[i])
+			} else if value.Valid {
+				at.UsedAt = new(time.Time)
+				*at.UsedAt = value.Time
+			}
+		case authtoken.FieldAttempts:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field attempts", values[i])
+			} else if value.Valid {
+				at.Attempts = int(value.Int64)
+			}
+		case authtoken.FieldIP:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ip", values[i])
+			} else if value.Valid {
+				at.IP = value.String
+			}
+		case authtoken.FieldEmail:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field email", values[i])
+			} else if value.Valid {
+				at.Email = value.String
+			}
+		case authtoken.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				at.CreatedAt = value.Time
+			}
+		case authtoken.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_auth_tokens", value)
+			} else if value.Valid {
+				at.user_auth_tokens = new(int)
+				*at.user_auth_tokens = int(value.Int64)
+			}
+		default:
+			at.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the AuthToken.
+// This includes values selected through modifiers, order, etc.
+func (at *AuthToken) Value(name string) (ent.Value, error) {
+	return at.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the AuthToken entity.
+func (at *AuthToken) QueryUser() *UserQuery {
+	return NewAuthTokenClient(at.config).QueryUser(at)
+}
+
+// Update returns a builder for updating this AuthToken.
+// Note that you need to call AuthToken.Unwrap() before calling this method if this AuthToken
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (at *AuthToken) Update() *AuthTokenUpdateOne {
+	return NewAuthTokenClient(at.config).UpdateOne(at)
+}
+
+// Unwrap unwraps the AuthToken entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (at *AuthToken) Unwrap() *AuthToken {
+	_tx, ok := at.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: AuthToken is not a transactional entity")
+	}
+	at.config.driver = _tx.drv
+	return at
+}
+
+// String implements the fmt.Stringer.
+func (at *AuthToken) String() string {
+	var builder strings.Builder
+	builder.WriteString("AuthToken(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", at.ID))
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", at.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("token_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(at.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := at.UsedAt; v != nil {
+		builder.WriteString("used_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("attempts=")
+	builder.WriteString(fmt.Sprintf("%v", at.Attempts))
+	builder.WriteString(", ")
+	builder.WriteString("ip=")
+	builder.WriteString(at.IP)
+	builder.WriteString(", ")
+	builder.WriteString("email=")
+	builder.WriteString(at.Email)
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(at.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// AuthTokens is a parsable slice of AuthToken.
+type AuthTokens []*AuthToken
@@ -0,0 +1,357 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// AuthTokenCreate is the builder for creating a AuthToken entity.
+type AuthTokenCreate struct {
+	config
+	mutation *AuthTokenMutation
+	hooks    []Hook
+}
+
+// SetKind sets the "kind" field.
+func (atc *AuthTokenCreate) SetKind(a authtoken.Kind) *AuthTokenCreate {
+	atc.mutation.SetKind(a)
+	return atc
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (atc *AuthTokenCreate) SetTokenHash(s string) *AuthTokenCreate {
+	atc.mutation.SetTokenHash(s)
+	return atc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (atc *AuthTokenCreate) SetExpiresAt(t time.Time) *AuthTokenCreate {
+	atc.mutation.SetExpiresAt(t)
+	return atc
+}
+
+// SetUsedAt sets the "used_at" field.
+func (atc *AuthTokenCreate) SetUsedAt(t time.Time) *AuthTokenCreate {
+	atc.mutation.SetUsedAt(t)
+	return atc
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (atc *AuthTokenCreate) SetNillableUsedAt(t *time.Time) *AuthTokenCreate {
+	if t != nil {
+		atc.SetUsedAt(*t)
+	}
+	return atc
+}
+
+// SetAttempts sets the "attempts" field.
+func (atc *AuthTokenCreate) SetAttempts(i int) *AuthTokenCreate {
+	atc.mutation.SetAttempts(i)
+	return atc
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (atc *AuthTokenCreate) SetNillableAttempts(i *int) *AuthTokenCreate {
+	if i != nil {
+		atc.SetAttempts(*i)
+	}
+	return atc
+}
+
+// SetIP sets the "ip" field.
+func (atc *AuthTokenCreate) SetIP(s string) *AuthTokenCreate {
+	atc.mutation.SetIP(s)
+	return atc
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (atc *AuthTokenCreate) SetNillableIP(s *string) *AuthTokenCreate {
+	if s != nil {
+		atc.SetIP(*s)
+	}
+	return atc
+}
+
+// SetEmail sets the "email" field.
+func (atc *AuthTokenCreate) SetEmail(s string) *AuthTokenCreate {
+	atc.mutation.SetEmail(s)
+	return atc
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (atc *AuthTokenCreate) SetNillableEmail(s *string) *AuthTokenCreate {
+	if s != nil {
+		atc.SetEmail(*s)
+	}
+	return atc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (atc *AuthTokenCreate) SetCreatedAt(t time.Time) *AuthTokenCreate {
+	atc.mutation.SetCreatedAt(t)
+	return atc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (atc *AuthTokenCreate) SetNillableCreatedAt(t *time.Time) *AuthTokenCreate {
+	if t != nil {
+		atc.SetCreatedAt(*t)
+	}
+	return atc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (atc *AuthTokenCreate) SetUserID(id int) *AuthTokenCreate {
+	atc.mutation.SetUserID(id)
+	return atc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (atc *AuthTokenCreate) SetUser(u *User) *AuthTokenCreate {
+	return atc.SetUserID(u.ID)
+}
+
+// Mutation returns the AuthTokenMutation object of the builder.
+func (atc *AuthTokenCreate) Mutation() *AuthTokenMutation {
+	return atc.mutation
+}
+
+// Save creates the AuthToken in the database.
+func (atc *AuthTokenCreate) Save(ctx context.Context) (*AuthToken, error) {
+	atc.defaults()
+	return withHooks(ctx, atc.sqlSave, atc.mutation, atc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (atc *AuthTokenCreate) SaveX(ctx context.Context) *AuthToken {
+	v, err := atc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (atc *AuthTokenCreate) Exec(ctx context.Context) error {
+	_, err := atc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atc *AuthTokenCreate) ExecX(ctx context.Context) {
+	if err := atc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (atc *AuthTokenCreate) defaults() {
+	if _, ok := atc.mutation.Attempts(); !ok {
+		v := authtoken.DefaultAttempts
+		atc.mutation.SetAttempts(v)
+	}
+	if _, ok := atc.mutation.CreatedAt(); !ok {
+		v := authtoken.DefaultCreatedAt()
+		atc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (atc *AuthTokenCreate) check() error {
+	if _, ok := atc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "AuthToken.kind"`)}
+	}
+	if v, ok := atc.mutation.Kind(); ok {
+		if err := authtoken.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "AuthToken.kind": %w`, err)}
+		}
+	}
+	if _, ok := atc.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "AuthToken.token_hash"`)}
+	}
+	if _, ok := atc.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "AuthToken.expires_at"`)}
+	}
+	if _, ok := atc.mutation.Attempts(); !ok {
+		return &ValidationError{Name: "attempts", err: errors.New(`ent: missing required field "AuthToken.attempts"`)}
+	}
+	if _, ok := atc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "AuthToken.created_at"`)}
+	}
+	if len(atc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "AuthToken.user"`)}
+	}
+	return nil
+}
+
+func (atc *AuthTokenCreate) sqlSave(ctx context.Context) (*AuthToken, error) {
+	if err := atc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := atc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, atc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	atc.mutation.id = &_node.ID
+	atc.mutation.done = true
+	return _node, nil
+}
+
+func (atc *AuthTokenCreate) createSpec() (*AuthToken, *sqlgraph.CreateSpec) {
+	var (
+		_node = &AuthToken{config: atc.config}
+		_spec = sqlgraph.NewCreateSpec(authtoken.Table, sqlgraph.NewFieldSpec(authtoken.FieldID, field.TypeInt))
+	)
+	if value, ok := atc.mutation.Kind(); ok {
+		_spec.SetField(authtoken.FieldKind, field.TypeEnum, value)
+		_node.Kind = value
+	}
+	if value, ok := atc.mutation.TokenHash(); ok {
+		_spec.SetField(authtoken.FieldTokenHash, field.TypeString, value)
+		_node.TokenHash = value
+	}
+	if value, ok := atc.mutation.ExpiresAt(); ok {
+		_spec.SetField(authtoken.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := atc.mutation.UsedAt(); ok {
+		_spec.SetField(authtoken.FieldUsedAt, field.TypeTime, value)
+		_node.UsedAt = &value
+	}
+	if value, ok := atc.mutation.Attempts(); ok {
+		_spec.SetField(authtoken.FieldAttempts, field.TypeInt, value)
+		_node.Attempts = value
+	}
+	if value, ok := atc.mutation.IP(); ok {
+		_spec.SetField(authtoken.FieldIP, field.TypeString, value)
+		_node.IP = value
+	}
+	if value, ok := atc.mutation.Email(); ok {
+		_spec.SetField(authtoken.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := atc.mutation.CreatedAt(); ok {
+		_spec.SetField(authtoken.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := atc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   authtoken.UserTable,
+			Columns: []string{authtoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_auth_tokens = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// AuthTokenCreateBulk is the builder for creating many AuthToken entities in bulk.
+type AuthTokenCreateBulk struct {
+	config
+	err      error
+	builders []*AuthTokenCreate
+}
+
+// Save creates the AuthToken entities in the database.
+func (atcb *AuthTokenCreateBulk) Save(ctx context.Context) ([]*AuthToken, error) {
+	if atcb.err != nil {
+		return nil, atcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(atcb.builders))
+	nodes := make([]*AuthToken, len(atcb.builders))
+	mutators := make([]Mutator, len(atcb.builders))
+	for i := range atcb.builders {
+		func(i int, root context.Context) {
+			builder := atcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*AuthTokenMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, atcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, atcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, atcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atcb *AuthTokenCreateBulk) SaveX(ctx context.Context) []*AuthToken {
+	v, err := atcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (atcb *AuthTokenCreateBulk) Exec(ctx context.Context) error {
+	_, err := atcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atcb *AuthTokenCreateBulk) ExecX(ctx context.Context) {
+	if err := atcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,455 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/predicate"
+	"poll_app/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// AuthTokenUpdate is the builder for updating AuthToken entities.
+type AuthTokenUpdate struct {
+	config
+	hooks    []Hook
+	mutation *AuthTokenMutation
+}
+
+// Where appends a list predicates to the AuthTokenUpdate builder.
+func (atu *AuthTokenUpdate) Where(ps ...predicate.AuthToken) *AuthTokenUpdate {
+	atu.mutation.Where(ps...)
+	return atu
+}
+
+// SetUsedAt sets the "used_at" field.
+func (atu *AuthTokenUpdate) SetUsedAt(t time.Time) *AuthTokenUpdate {
+	atu.mutation.SetUsedAt(t)
+	return atu
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (atu *AuthTokenUpdate) SetNillableUsedAt(t *time.Time) *AuthTokenUpdate {
+	if t != nil {
+		atu.SetUsedAt(*t)
+	}
+	return atu
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (atu *AuthTokenUpdate) ClearUsedAt() *AuthTokenUpdate {
+	atu.mutation.ClearUsedAt()
+	return atu
+}
+
+// SetAttempts sets the "attempts" field.
+func (atu *AuthTokenUpdate) SetAttempts(i int) *AuthTokenUpdate {
+	atu.mutation.ResetAttempts()
+	atu.mutation.SetAttempts(i)
+	return atu
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (atu *AuthTokenUpdate) SetNillableAttempts(i *int) *AuthTokenUpdate {
+	if i != nil {
+		atu.SetAttempts(*i)
+	}
+	return atu
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (atu *AuthTokenUpdate) AddAttempts(i int) *AuthTokenUpdate {
+	atu.mutation.AddAttempts(i)
+	return atu
+}
+
+// SetIP sets the "ip" field.
+func (atu *AuthTokenUpdate) SetIP(s string) *AuthTokenUpdate {
+	atu.mutation.SetIP(s)
+	return atu
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (atu *AuthTokenUpdate) SetNillableIP(s *string) *AuthTokenUpdate {
+	if s != nil {
+		atu.SetIP(*s)
+	}
+	return atu
+}
+
+// ClearIP clears the value of the "ip" field.
+func (atu *AuthTokenUpdate) ClearIP() *AuthTokenUpdate {
+	atu.mutation.ClearIP()
+	return atu
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (atu *AuthTokenUpdate) SetUserID(id int) *AuthTokenUpdate {
+	atu.mutation.SetUserID(id)
+	return atu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (atu *AuthTokenUpdate) SetUser(u *User) *AuthTokenUpdate {
+	return atu.SetUserID(u.ID)
+}
+
+// Mutation returns the AuthTokenMutation object of the builder.
+func (atu *AuthTokenUpdate) Mutation() *AuthTokenMutation {
+	return atu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (atu *AuthTokenUpdate) ClearUser() *AuthTokenUpdate {
+	atu.mutation.ClearUser()
+	return atu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (atu *AuthTokenUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, atu.sqlSave, atu.mutation, atu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atu *AuthTokenUpdate) SaveX(ctx context.Context) int {
+	affected, err := atu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (atu *AuthTokenUpdate) Exec(ctx context.Context) error {
+	_, err := atu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atu *AuthTokenUpdate) ExecX(ctx context.Context) {
+	if err := atu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (atu *AuthTokenUpdate) check() error {
+	if atu.mutation.UserCleared() && len(atu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "AuthToken.user"`)
+	}
+	return nil
+}
+
+func (atu *AuthTokenUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := atu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(authtoken.Table, authtoken.Columns, sqlgraph.NewFieldSpec(authtoken.FieldID, field.TypeInt))
+	if ps := atu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := atu.mutation.UsedAt(); ok {
+		_spec.SetField(authtoken.FieldUsedAt, field.TypeTime, value)
+	}
+	if atu.mutation.UsedAtCleared() {
+		_spec.ClearField(authtoken.FieldUsedAt, field.TypeTime)
+	}
+	if value, ok := atu.mutation.Attempts(); ok {
+		_spec.SetField(authtoken.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := atu.mutation.AddedAttempts(); ok {
+		_spec.AddField(authtoken.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := atu.mutation.IP(); ok {
+		_spec.SetField(authtoken.FieldIP, field.TypeString, value)
+	}
+	if atu.mutation.IPCleared() {
+		_spec.ClearField(authtoken.FieldIP, field.TypeString)
+	}
+	if atu.mutation.EmailCleared() {
+		_spec.ClearField(authtoken.FieldEmail, field.TypeString)
+	}
+	if atu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   authtoken.UserTable,
+			Columns: []string{authtoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := atu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   authtoken.UserTable,
+			Columns: []string{authtoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, atu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{authtoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	atu.mutation.done = true
+	return n, nil
+}
+
+// AuthTokenUpdateOne is the builder for updating a single AuthToken entity.
+type AuthTokenUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *AuthTokenMutation
+}
+
+// SetUsedAt sets the "used_at" field.
+func (atuo *AuthTokenUpdateOne) SetUsedAt(t time.Time) *AuthTokenUpdateOne {
+	atuo.mutation.SetUsedAt(t)
+	return atuo
+}
+
+// SetNillableUsedAt sets the "used_at" field if the given value is not nil.
+func (atuo *AuthTokenUpdateOne) SetNillableUsedAt(t *time.Time) *AuthTokenUpdateOne {
+	if t != nil {
+		atuo.SetUsedAt(*t)
+	}
+	return atuo
+}
+
+// ClearUsedAt clears the value of the "used_at" field.
+func (atuo *AuthTokenUpdateOne) ClearUsedAt() *AuthTokenUpdateOne {
+	atuo.mutation.ClearUsedAt()
+	return atuo
+}
+
+// SetAttempts sets the "attempts" field.
+func (atuo *AuthTokenUpdateOne) SetAttempts(i int) *AuthTokenUpdateOne {
+	atuo.mutation.ResetAttempts()
+	atuo.mutation.SetAttempts(i)
+	return atuo
+}
+
+// SetNillableAttempts sets the "attempts" field if the given value is not nil.
+func (atuo *AuthTokenUpdateOne) SetNillableAttempts(i *int) *AuthTokenUpdateOne {
+	if i != nil {
+		atuo.SetAttempts(*i)
+	}
+	return atuo
+}
+
+// AddAttempts adds i to the "attempts" field.
+func (atuo *AuthTokenUpdateOne) AddAttempts(i int) *AuthTokenUpdateOne {
+	atuo.mutation.AddAttempts(i)
+	return atuo
+}
+
+// SetIP sets the "ip" field.
+func (atuo *AuthTokenUpdateOne) SetIP(s string) *AuthTokenUpdateOne {
+	atuo.mutation.SetIP(s)
+	return atuo
+}
+
+// SetNillableIP sets the "ip" field if the given value is not nil.
+func (atuo *AuthTokenUpdateOne) SetNillableIP(s *string) *AuthTokenUpdateOne {
+	if s != nil {
+		atuo.SetIP(*s)
+	}
+	return atuo
+}
+
+// ClearIP clears the value of the "ip" field.
+func (atuo *AuthTokenUpdateOne) ClearIP() *AuthTokenUpdateOne {
+	atuo.mutation.ClearIP()
+	return atuo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (atuo *AuthTokenUpdateOne) SetUserID(id int) *AuthTokenUpdateOne {
+	atuo.mutation.SetUserID(id)
+	return atuo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (atuo *AuthTokenUpdateOne) SetUser(u *User) *AuthTokenUpdateOne {
+	return atuo.SetUserID(u.ID)
+}
+
+// Mutation returns the AuthTokenMutation object of the builder.
+func (atuo *AuthTokenUpdateOne) Mutation() *AuthTokenMutation {
+	return atuo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (atuo *AuthTokenUpdateOne) ClearUser() *AuthTokenUpdateOne {
+	atuo.mutation.ClearUser()
+	return atuo
+}
+
+// Where appends a list predicates to the AuthTokenUpdate builder.
+func (atuo *AuthTokenUpdateOne) Where(ps ...predicate.AuthToken) *AuthTokenUpdateOne {
+	atuo.mutation.Where(ps...)
+	return atuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (atuo *AuthTokenUpdateOne) Select(field string, fields ...string) *AuthTokenUpdateOne {
+	atuo.fields = append([]string{field}, fields...)
+	return atuo
+}
+
+// Save executes the query and returns the updated AuthToken entity.
+func (atuo *AuthTokenUpdateOne) Save(ctx context.Context) (*AuthToken, error) {
+	return withHooks(ctx, atuo.sqlSave, atuo.mutation, atuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (atuo *AuthTokenUpdateOne) SaveX(ctx context.Context) *AuthToken {
+	node, err := atuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (atuo *AuthTokenUpdateOne) Exec(ctx context.Context) error {
+	_, err := atuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (atuo *AuthTokenUpdateOne) ExecX(ctx context.Context) {
+	if err := atuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (atuo *AuthTokenUpdateOne) check() error {
+	if atuo.mutation.UserCleared() && len(atuo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "AuthToken.user"`)
+	}
+	return nil
+}
+
+func (atuo *AuthTokenUpdateOne) sqlSave(ctx context.Context) (_node *AuthToken, err error) {
+	if err := atuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(authtoken.Table, authtoken.Columns, sqlgraph.NewFieldSpec(authtoken.FieldID, field.TypeInt))
+	id, ok := atuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "AuthToken.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := atuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, authtoken.FieldID)
+		for _, f := range fields {
+			if !authtoken.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != authtoken.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := atuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := atuo.mutation.UsedAt(); ok {
+		_spec.SetField(authtoken.FieldUsedAt, field.TypeTime, value)
+	}
+	if atuo.mutation.UsedAtCleared() {
+		_spec.ClearField(authtoken.FieldUsedAt, field.TypeTime)
+	}
+	if value, ok := atuo.mutation.Attempts(); ok {
+		_spec.SetField(authtoken.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := atuo.mutation.AddedAttempts(); ok {
+		_spec.AddField(authtoken.FieldAttempts, field.TypeInt, value)
+	}
+	if value, ok := atuo.mutation.IP(); ok {
+		_spec.SetField(authtoken.FieldIP, field.TypeString, value)
+	}
+	if atuo.mutation.IPCleared() {
+		_spec.ClearField(authtoken.FieldIP, field.TypeString)
+	}
+	if atuo.mutation.EmailCleared() {
+		_spec.ClearField(authtoken.FieldEmail, field.TypeString)
+	}
+	if atuo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   authtoken.UserTable,
+			Columns: []string{authtoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := atuo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   authtoken.UserTable,
+			Columns: []string{authtoken.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &AuthToken{config: atuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, atuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{authtoken.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	atuo.mutation.done = true
+	return _node, nil
+}
@@ -6,9 +6,19 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"poll_app/ent/auditlog"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/externalidentity"
+	"poll_app/ent/invite"
 	"poll_app/ent/notification"
+	"poll_app/ent/passkey"
+	"poll_app/ent/passkeyceremony"
 	"poll_app/ent/poll"
 	"poll_app/ent/polloption"
+	"poll_app/ent/recoverycode"
+	"poll_app/ent/refreshtoken"
+	"poll_app/ent/session"
+	"poll_app/ent/team"
 	"poll_app/ent/user"
 	"poll_app/ent/vote"
 	"reflect"
@@ -77,11 +87,21 @@ var (
 func checkColumn(table, column string) error {
 	initCheck.Do(func() {
 		columnCheck = sql.NewColumnCheck(map[string]func(string) bool{
-			notification.Table: notification.ValidColumn,
-			poll.Table:         poll.ValidColumn,
-			polloption.Table:   polloption.ValidColumn,
-			user.Table:         user.ValidColumn,
-			vote.Table:         vote.ValidColumn,
+			auditlog.Table:         auditlog.ValidColumn,
+			authtoken.Table:        authtoken.ValidColumn,
+			externalidentity.Table: externalidentity.ValidColumn,
+			invite.Table:           invite.ValidColumn,
+			notification.Table:     notification.ValidColumn,
+			passkey.Table:          passkey.ValidColumn,
+			passkeyceremony.Table:  passkeyceremony.ValidColumn,
+			poll.Table:             poll.ValidColumn,
+			polloption.Table:       polloption.ValidColumn,
+			recoverycode.Table:     recoverycode.ValidColumn,
+			refreshtoken.Table:     refreshtoken.ValidColumn,
+			session.Table:          session.ValidColumn,
+			team.Table:             team.ValidColumn,
+			user.Table:             user.ValidColumn,
+			vote.Table:             vote.ValidColumn,
 		})
 	})
 	return columnCheck(table, column)
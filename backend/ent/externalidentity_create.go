@@ -0,0 +1,320 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/externalidentity"
+	"poll_app/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// ExternalIdentityCreate is the builder for creating a ExternalIdentity entity.
+type ExternalIdentityCreate struct {
+	config
+	mutation *ExternalIdentityMutation
+	hooks    []Hook
+}
+
+// SetIssuer sets the "issuer" field.
+func (eic *ExternalIdentityCreate) SetIssuer(s string) *ExternalIdentityCreate {
+	eic.mutation.SetIssuer(s)
+	return eic
+}
+
+// SetSubject sets the "subject" field.
+func (eic *ExternalIdentityCreate) SetSubject(s string) *ExternalIdentityCreate {
+	eic.mutation.SetSubject(s)
+	return eic
+}
+
+// SetEmail sets the "email" field.
+func (eic *ExternalIdentityCreate) SetEmail(s string) *ExternalIdentityCreate {
+	eic.mutation.SetEmail(s)
+	return eic
+}
+
+// SetNillableEmail sets the "email" field if the given value is not nil.
+func (eic *ExternalIdentityCreate) SetNillableEmail(s *string) *ExternalIdentityCreate {
+	if s != nil {
+		eic.SetEmail(*s)
+	}
+	return eic
+}
+
+// SetLastLoginAt sets the "last_login_at" field.
+func (eic *ExternalIdentityCreate) SetLastLoginAt(t time.Time) *ExternalIdentityCreate {
+	eic.mutation.SetLastLoginAt(t)
+	return eic
+}
+
+// SetNillableLastLoginAt sets the "last_login_at" field if the given value is not nil.
+func (eic *ExternalIdentityCreate) SetNillableLastLoginAt(t *time.Time) *ExternalIdentityCreate {
+	if t != nil {
+		eic.SetLastLoginAt(*t)
+	}
+	return eic
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (eic *ExternalIdentityCreate) SetCreatedAt(t time.Time) *ExternalIdentityCreate {
+	eic.mutation.SetCreatedAt(t)
+	return eic
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (eic *ExternalIdentityCreate) SetNillableCreatedAt(t *time.Time) *ExternalIdentityCreate {
+	if t != nil {
+		eic.SetCreatedAt(*t)
+	}
+	return eic
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (eic *ExternalIdentityCreate) SetUserID(id int) *ExternalIdentityCreate {
+	eic.mutation.SetUserID(id)
+	return eic
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (eic *ExternalIdentityCreate) SetUser(u *User) *ExternalIdentityCreate {
+	return eic.SetUserID(u.ID)
+}
+
+// Mutation returns the ExternalIdentityMutation object of the builder.
+func (eic *ExternalIdentityCreate) Mutation() *ExternalIdentityMutation {
+	return eic.mutation
+}
+
+// Save creates the ExternalIdentity in the database.
+func (eic *ExternalIdentityCreate) Save(ctx context.Context) (*ExternalIdentity, error) {
+	eic.defaults()
+	return withHooks(ctx, eic.sqlSave, eic.mutation, eic.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (eic *ExternalIdentityCreate) SaveX(ctx context.Context) *ExternalIdentity {
+	v, err := eic.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (eic *ExternalIdentityCreate) Exec(ctx context.Context) error {
+	_, err := eic.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eic *ExternalIdentityCreate) ExecX(ctx context.Context) {
+	if err := eic.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (eic *ExternalIdentityCreate) defaults() {
+	if _, ok := eic.mutation.Email(); !ok {
+		v := externalidentity.DefaultEmail
+		eic.mutation.SetEmail(v)
+	}
+	if _, ok := eic.mutation.LastLoginAt(); !ok {
+		v := externalidentity.DefaultLastLoginAt()
+		eic.mutation.SetLastLoginAt(v)
+	}
+	if _, ok := eic.mutation.CreatedAt(); !ok {
+		v := externalidentity.DefaultCreatedAt()
+		eic.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (eic *ExternalIdentityCreate) check() error {
+	if _, ok := eic.mutation.Issuer(); !ok {
+		return &ValidationError{Name: "issuer", err: errors.New(`ent: missing required field "ExternalIdentity.issuer"`)}
+	}
+	if v, ok := eic.mutation.Issuer(); ok {
+		if err := externalidentity.IssuerValidator(v); err != nil {
+			return &ValidationError{Name: "issuer", err: fmt.Errorf(`ent: validator failed for field "ExternalIdentity.issuer": %w`, err)}
+		}
+	}
+	if _, ok := eic.mutation.Subject(); !ok {
+		return &ValidationError{Name: "subject", err: errors.New(`ent: missing required field "ExternalIdentity.subject"`)}
+	}
+	if v, ok := eic.mutation.Subject(); ok {
+		if err := externalidentity.SubjectValidator(v); err != nil {
+			return &ValidationError{Name: "subject", err: fmt.Errorf(`ent: validator failed for field "ExternalIdentity.subject": %w`, err)}
+		}
+	}
+	if _, ok := eic.mutation.Email(); !ok {
+		return &ValidationError{Name: "email", err: errors.New(`ent: missing required field "ExternalIdentity.email"`)}
+	}
+	if _, ok := eic.mutation.LastLoginAt(); !ok {
+		return &ValidationError{Name: "last_login_at", err: errors.New(`ent: missing required field "ExternalIdentity.last_login_at"`)}
+	}
+	if _, ok := eic.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "ExternalIdentity.created_at"`)}
+	}
+	if len(eic.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "ExternalIdentity.user"`)}
+	}
+	return nil
+}
+
+func (eic *ExternalIdentityCreate) sqlSave(ctx context.Context) (*ExternalIdentity, error) {
+	if err := eic.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := eic.createSpec()
+	if err := sqlgraph.CreateNode(ctx, eic.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	eic.mutation.id = &_node.ID
+	eic.mutation.done = true
+	return _node, nil
+}
+
+func (eic *ExternalIdentityCreate) createSpec() (*ExternalIdentity, *sqlgraph.CreateSpec) {
+	var (
+		_node = &ExternalIdentity{config: eic.config}
+		_spec = sqlgraph.NewCreateSpec(externalidentity.Table, sqlgraph.NewFieldSpec(externalidentity.FieldID, field.TypeInt))
+	)
+	if value, ok := eic.mutation.Issuer(); ok {
+		_spec.SetField(externalidentity.FieldIssuer, field.TypeString, value)
+		_node.Issuer = value
+	}
+	if value, ok := eic.mutation.Subject(); ok {
+		_spec.SetField(externalidentity.FieldSubject, field.TypeString, value)
+		_node.Subject = value
+	}
+	if value, ok := eic.mutation.Email(); ok {
+		_spec.SetField(externalidentity.FieldEmail, field.TypeString, value)
+		_node.Email = value
+	}
+	if value, ok := eic.mutation.LastLoginAt(); ok {
+		_spec.SetField(externalidentity.FieldLastLoginAt, field.TypeTime, value)
+		_node.LastLoginAt = value
+	}
+	if value, ok := eic.mutation.CreatedAt(); ok {
+		_spec.SetField(externalidentity.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := eic.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   externalidentity.UserTable,
+			Columns: []string{externalidentity.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_external_identities = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// ExternalIdentityCreateBulk is the builder for creating many ExternalIdentity entities in bulk.
+type ExternalIdentityCreateBulk struct {
+	config
+	err      error
+	builders []*ExternalIdentityCreate
+}
+
+// Save creates the ExternalIdentity entities in the database.
+func (eicb *ExternalIdentityCreateBulk) Save(ctx context.Context) ([]*ExternalIdentity, error) {
+	if eicb.err != nil {
+		return nil, eicb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(eicb.builders))
+	nodes := make([]*ExternalIdentity, len(eicb.builders))
+	mutators := make([]Mutator, len(eicb.builders))
+	for i := range eicb.builders {
+		func(i int, root context.Context) {
+			builder := eicb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*ExternalIdentityMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, eicb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, eicb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, eicb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (eicb *ExternalIdentityCreateBulk) SaveX(ctx context.Context) []*ExternalIdentity {
+	v, err := eicb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (eicb *ExternalIdentityCreateBulk) Exec(ctx context.Context) error {
+	_, err := eicb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (eicb *ExternalIdentityCreateBulk) ExecX(ctx context.Context) {
+	if err := eicb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
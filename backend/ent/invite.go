@@ -0,0 +1,237 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"fmt"
+	"poll_app/ent/invite"
+	"poll_app/ent/poll"
+	"poll_app/ent/user"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Invite is the model entity for the Invite schema.
+type Invite struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// TokenHash holds the value of the "token_hash" field.
+	TokenHash string `json:"-"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	// MaxUses holds the value of the "max_uses" field.
+	MaxUses *int `json:"max_uses,omitempty"`
+	// Uses holds the value of the "uses" field.
+	Uses int `json:"uses,omitempty"`
+	// RevokedAt holds the value of the "revoked_at" field.
+	RevokedAt *time.Time `json:"revoked_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the InviteQuery when eager-loading is set.
+	Edges                InviteEdges `json:"edges"`
+	poll_invites         *int
+	user_created_invites *int
+	selectValues         sql.SelectValues
+}
+
+// InviteEdges holds the relations/edges for other nodes in the graph.
+type InviteEdges struct {
+	// Poll holds the value of the poll edge.
+	Poll *Poll `json:"poll,omitempty"`
+	// Creator holds the value of the creator edge.
+	Creator *User `json:"creator,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [2]bool
+}
+
+// PollOrErr returns the Poll value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e InviteEdges) PollOrErr() (*Poll, error) {
+	if e.Poll != nil {
+		return e.Poll, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: poll.Label}
+	}
+	return nil, &NotLoadedError{edge: "poll"}
+}
+
+// CreatorOrErr returns the Creator value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e InviteEdges) CreatorOrErr() (*User, error) {
+	if e.Creator != nil {
+		return e.Creator, nil
+	} else if e.loadedTypes[1] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "creator"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Invite) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case invite.FieldID, invite.FieldMaxUses, invite.FieldUses:
+			values[i] = new(sql.NullInt64)
+		case invite.FieldTokenHash:
+			values[i] = new(sql.NullString)
+		case invite.FieldExpiresAt, invite.FieldRevokedAt, invite.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case invite.ForeignKeys[0]: // poll_invites
+			values[i] = new(sql.NullInt64)
+		case invite.ForeignKeys[1]: // user_created_invites
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Invite fields.
+func (i *Invite) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for j := range columns {
+		switch columns[j] {
+		case invite.FieldID:
+			value, ok := values[j].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			i.ID = int(value.Int64)
+		case invite.FieldTokenHash:
+			if value, ok := values[j].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[j])
+			} else if value.Valid {
+				i.TokenHash = value.String
+			}
+		case invite.FieldExpiresAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[j])
+			} else if value.Valid {
+				i.ExpiresAt = new(time.Time)
+				*i.ExpiresAt = value.Time
+			}
+		case invite.FieldMaxUses:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_uses", values[j])
+			} else if value.Valid {
+				i.MaxUses = new(int)
+				*i.MaxUses = int(value.Int64)
+			}
+		case invite.FieldUses:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field uses", values[j])
+			} else if value.Valid {
+				i.Uses = int(value.Int64)
+			}
+		case invite.FieldRevokedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field revoked_at", values[j])
+			} else if value.Valid {
+				i.RevokedAt = new(time.Time)
+				*i.RevokedAt = value.Time
+			}
+		case invite.FieldCreatedAt:
+			if value, ok := values[j].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[j])
+			} else if value.Valid {
+				i.CreatedAt = value.Time
+			}
+		case invite.ForeignKeys[0]:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field poll_invites", value)
+			} else if value.Valid {
+				i.poll_invites = new(int)
+				*i.poll_invites = int(value.Int64)
+			}
+		case invite.ForeignKeys[1]:
+			if value, ok := values[j].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_created_invites", value)
+			} else if value.Valid {
+				i.user_created_invites = new(int)
+				*i.user_created_invites = int(value.Int64)
+			}
+		default:
+			i.selectValues.Set(columns[j], values[j])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Invite.
+// This includes values selected through modifiers, order, etc.
+func (i *Invite) Value(name string) (ent.Value, error) {
+	return i.selectValues.Get(name)
+}
+
+// QueryPoll queries the "poll" edge of the Invite entity.
+func (i *Invite) QueryPoll() *PollQuery {
+	return NewInviteClient(i.config).QueryPoll(i)
+}
+
+// QueryCreator queries the "creator" edge of the Invite entity.
+func (i *Invite) QueryCreator() *UserQuery {
+	return NewInviteClient(i.config).QueryCreator(i)
+}
+
+// Update returns a builder for updating this Invite.
+// Note that you need to call Invite.Unwrap() before calling this method if this Invite
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (i *Invite) Update() *InviteUpdateOne {
+	return NewInviteClient(i.config).UpdateOne(i)
+}
+
+// Unwrap unwraps the Invite entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (i *Invite) Unwrap() *Invite {
+	_tx, ok := i.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Invite is not a transactional entity")
+	}
+	i.config.driver = _tx.drv
+	return i
+}
+
+// String implements the fmt.Stringer.
+func (i *Invite) String() string {
+	var builder strings.Builder
+	builder.WriteString("Invite(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", i.ID))
+	builder.WriteString("token_hash=<sensitive>")
+	builder.WriteString(", ")
+	if v := i.ExpiresAt; v != nil {
+		builder.WriteString("expires_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := i.MaxUses; v != nil {
+		builder.WriteString("max_uses=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("uses=")
+	builder.WriteString(fmt.Sprintf("%v", i.Uses))
+	builder.WriteString(", ")
+	if v := i.RevokedAt; v != nil {
+		builder.WriteString("revoked_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(i.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Invites is a parsable slice of Invite.
+type Invites []*Invite
@@ -0,0 +1,674 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/invite"
+	"poll_app/ent/poll"
+	"poll_app/ent/predicate"
+	"poll_app/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// InviteUpdate is the builder for updating Invite entities.
+type InviteUpdate struct {
+	config
+	hooks    []Hook
+	mutation *InviteMutation
+}
+
+// Where appends a list predicates to the InviteUpdate builder.
+func (iu *InviteUpdate) Where(ps ...predicate.Invite) *InviteUpdate {
+	iu.mutation.Where(ps...)
+	return iu
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (iu *InviteUpdate) SetExpiresAt(t time.Time) *InviteUpdate {
+	iu.mutation.SetExpiresAt(t)
+	return iu
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (iu *InviteUpdate) SetNillableExpiresAt(t *time.Time) *InviteUpdate {
+	if t != nil {
+		iu.SetExpiresAt(*t)
+	}
+	return iu
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (iu *InviteUpdate) ClearExpiresAt() *InviteUpdate {
+	iu.mutation.ClearExpiresAt()
+	return iu
+}
+
+// SetMaxUses sets the "max_uses" field.
+func (iu *InviteUpdate) SetMaxUses(i int) *InviteUpdate {
+	iu.mutation.ResetMaxUses()
+	iu.mutation.SetMaxUses(i)
+	return iu
+}
+
+// SetNillableMaxUses sets the "max_uses" field if the given value is not nil.
+func (iu *InviteUpdate) SetNillableMaxUses(i *int) *InviteUpdate {
+	if i != nil {
+		iu.SetMaxUses(*i)
+	}
+	return iu
+}
+
+// AddMaxUses adds i to the "max_uses" field.
+func (iu *InviteUpdate) AddMaxUses(i int) *InviteUpdate {
+	iu.mutation.AddMaxUses(i)
+	return iu
+}
+
+// ClearMaxUses clears the value of the "max_uses" field.
+func (iu *InviteUpdate) ClearMaxUses() *InviteUpdate {
+	iu.mutation.ClearMaxUses()
+	return iu
+}
+
+// SetUses sets the "uses" field.
+func (iu *InviteUpdate) SetUses(i int) *InviteUpdate {
+	iu.mutation.ResetUses()
+	iu.mutation.SetUses(i)
+	return iu
+}
+
+// SetNillableUses sets the "uses" field if the given value is not nil.
+func (iu *InviteUpdate) SetNillableUses(i *int) *InviteUpdate {
+	if i != nil {
+		iu.SetUses(*i)
+	}
+	return iu
+}
+
+// AddUses adds i to the "uses" field.
+func (iu *InviteUpdate) AddUses(i int) *InviteUpdate {
+	iu.mutation.AddUses(i)
+	return iu
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (iu *InviteUpdate) SetRevokedAt(t time.Time) *InviteUpdate {
+	iu.mutation.SetRevokedAt(t)
+	return iu
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (iu *InviteUpdate) SetNillableRevokedAt(t *time.Time) *InviteUpdate {
+	if t != nil {
+		iu.SetRevokedAt(*t)
+	}
+	return iu
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (iu *InviteUpdate) ClearRevokedAt() *InviteUpdate {
+	iu.mutation.ClearRevokedAt()
+	return iu
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (iu *InviteUpdate) SetCreatedAt(t time.Time) *InviteUpdate {
+	iu.mutation.SetCreatedAt(t)
+	return iu
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (iu *InviteUpdate) SetNillableCreatedAt(t *time.Time) *InviteUpdate {
+	if t != nil {
+		iu.SetCreatedAt(*t)
+	}
+	return iu
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (iu *InviteUpdate) SetPollID(id int) *InviteUpdate {
+	iu.mutation.SetPollID(id)
+	return iu
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (iu *InviteUpdate) SetPoll(p *Poll) *InviteUpdate {
+	return iu.SetPollID(p.ID)
+}
+
+// SetCreatorID sets the "creator" edge to the User entity by ID.
+func (iu *InviteUpdate) SetCreatorID(id int) *InviteUpdate {
+	iu.mutation.SetCreatorID(id)
+	return iu
+}
+
+// SetCreator sets the "creator" edge to the User entity.
+func (iu *InviteUpdate) SetCreator(u *User) *InviteUpdate {
+	return iu.SetCreatorID(u.ID)
+}
+
+// Mutation returns the InviteMutation object of the builder.
+func (iu *InviteUpdate) Mutation() *InviteMutation {
+	return iu.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (iu *InviteUpdate) ClearPoll() *InviteUpdate {
+	iu.mutation.ClearPoll()
+	return iu
+}
+
+// ClearCreator clears the "creator" edge to the User entity.
+func (iu *InviteUpdate) ClearCreator() *InviteUpdate {
+	iu.mutation.ClearCreator()
+	return iu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (iu *InviteUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, iu.sqlSave, iu.mutation, iu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iu *InviteUpdate) SaveX(ctx context.Context) int {
+	affected, err := iu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (iu *InviteUpdate) Exec(ctx context.Context) error {
+	_, err := iu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iu *InviteUpdate) ExecX(ctx context.Context) {
+	if err := iu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iu *InviteUpdate) check() error {
+	if v, ok := iu.mutation.MaxUses(); ok {
+		if err := invite.MaxUsesValidator(v); err != nil {
+			return &ValidationError{Name: "max_uses", err: fmt.Errorf(`ent: validator failed for field "Invite.max_uses": %w`, err)}
+		}
+	}
+	if v, ok := iu.mutation.Uses(); ok {
+		if err := invite.UsesValidator(v); err != nil {
+			return &ValidationError{Name: "uses", err: fmt.Errorf(`ent: validator failed for field "Invite.uses": %w`, err)}
+		}
+	}
+	if iu.mutation.PollCleared() && len(iu.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Invite.poll"`)
+	}
+	if iu.mutation.CreatorCleared() && len(iu.mutation.CreatorIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Invite.creator"`)
+	}
+	return nil
+}
+
+func (iu *InviteUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := iu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(invite.Table, invite.Columns, sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt))
+	if ps := iu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iu.mutation.ExpiresAt(); ok {
+		_spec.SetField(invite.FieldExpiresAt, field.TypeTime, value)
+	}
+	if iu.mutation.ExpiresAtCleared() {
+		_spec.ClearField(invite.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := iu.mutation.MaxUses(); ok {
+		_spec.SetField(invite.FieldMaxUses, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.AddedMaxUses(); ok {
+		_spec.AddField(invite.FieldMaxUses, field.TypeInt, value)
+	}
+	if iu.mutation.MaxUsesCleared() {
+		_spec.ClearField(invite.FieldMaxUses, field.TypeInt)
+	}
+	if value, ok := iu.mutation.Uses(); ok {
+		_spec.SetField(invite.FieldUses, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.AddedUses(); ok {
+		_spec.AddField(invite.FieldUses, field.TypeInt, value)
+	}
+	if value, ok := iu.mutation.RevokedAt(); ok {
+		_spec.SetField(invite.FieldRevokedAt, field.TypeTime, value)
+	}
+	if iu.mutation.RevokedAtCleared() {
+		_spec.ClearField(invite.FieldRevokedAt, field.TypeTime)
+	}
+	if value, ok := iu.mutation.CreatedAt(); ok {
+		_spec.SetField(invite.FieldCreatedAt, field.TypeTime, value)
+	}
+	if iu.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.PollTable,
+			Columns: []string{invite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := iu.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.PollTable,
+			Columns: []string{invite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if iu.mutation.CreatorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.CreatorTable,
+			Columns: []string{invite.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := iu.mutation.CreatorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.CreatorTable,
+			Columns: []string{invite.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, iu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invite.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	iu.mutation.done = true
+	return n, nil
+}
+
+// InviteUpdateOne is the builder for updating a single Invite entity.
+type InviteUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *InviteMutation
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (iuo *InviteUpdateOne) SetExpiresAt(t time.Time) *InviteUpdateOne {
+	iuo.mutation.SetExpiresAt(t)
+	return iuo
+}
+
+// SetNillableExpiresAt sets the "expires_at" field if the given value is not nil.
+func (iuo *InviteUpdateOne) SetNillableExpiresAt(t *time.Time) *InviteUpdateOne {
+	if t != nil {
+		iuo.SetExpiresAt(*t)
+	}
+	return iuo
+}
+
+// ClearExpiresAt clears the value of the "expires_at" field.
+func (iuo *InviteUpdateOne) ClearExpiresAt() *InviteUpdateOne {
+	iuo.mutation.ClearExpiresAt()
+	return iuo
+}
+
+// SetMaxUses sets the "max_uses" field.
+func (iuo *InviteUpdateOne) SetMaxUses(i int) *InviteUpdateOne {
+	iuo.mutation.ResetMaxUses()
+	iuo.mutation.SetMaxUses(i)
+	return iuo
+}
+
+// SetNillableMaxUses sets the "max_uses" field if the given value is not nil.
+func (iuo *InviteUpdateOne) SetNillableMaxUses(i *int) *InviteUpdateOne {
+	if i != nil {
+		iuo.SetMaxUses(*i)
+	}
+	return iuo
+}
+
+// AddMaxUses adds i to the "max_uses" field.
+func (iuo *InviteUpdateOne) AddMaxUses(i int) *InviteUpdateOne {
+	iuo.mutation.AddMaxUses(i)
+	return iuo
+}
+
+// ClearMaxUses clears the value of the "max_uses" field.
+func (iuo *InviteUpdateOne) ClearMaxUses() *InviteUpdateOne {
+	iuo.mutation.ClearMaxUses()
+	return iuo
+}
+
+// SetUses sets the "uses" field.
+func (iuo *InviteUpdateOne) SetUses(i int) *InviteUpdateOne {
+	iuo.mutation.ResetUses()
+	iuo.mutation.SetUses(i)
+	return iuo
+}
+
+// SetNillableUses sets the "uses" field if the given value is not nil.
+func (iuo *InviteUpdateOne) SetNillableUses(i *int) *InviteUpdateOne {
+	if i != nil {
+		iuo.SetUses(*i)
+	}
+	return iuo
+}
+
+// AddUses adds i to the "uses" field.
+func (iuo *InviteUpdateOne) AddUses(i int) *InviteUpdateOne {
+	iuo.mutation.AddUses(i)
+	return iuo
+}
+
+// SetRevokedAt sets the "revoked_at" field.
+func (iuo *InviteUpdateOne) SetRevokedAt(t time.Time) *InviteUpdateOne {
+	iuo.mutation.SetRevokedAt(t)
+	return iuo
+}
+
+// SetNillableRevokedAt sets the "revoked_at" field if the given value is not nil.
+func (iuo *InviteUpdateOne) SetNillableRevokedAt(t *time.Time) *InviteUpdateOne {
+	if t != nil {
+		iuo.SetRevokedAt(*t)
+	}
+	return iuo
+}
+
+// ClearRevokedAt clears the value of the "revoked_at" field.
+func (iuo *InviteUpdateOne) ClearRevokedAt() *InviteUpdateOne {
+	iuo.mutation.ClearRevokedAt()
+	return iuo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (iuo *InviteUpdateOne) SetCreatedAt(t time.Time) *InviteUpdateOne {
+	iuo.mutation.SetCreatedAt(t)
+	return iuo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (iuo *InviteUpdateOne) SetNillableCreatedAt(t *time.Time) *InviteUpdateOne {
+	if t != nil {
+		iuo.SetCreatedAt(*t)
+	}
+	return iuo
+}
+
+// SetPollID sets the "poll" edge to the Poll entity by ID.
+func (iuo *InviteUpdateOne) SetPollID(id int) *InviteUpdateOne {
+	iuo.mutation.SetPollID(id)
+	return iuo
+}
+
+// SetPoll sets the "poll" edge to the Poll entity.
+func (iuo *InviteUpdateOne) SetPoll(p *Poll) *InviteUpdateOne {
+	return iuo.SetPollID(p.ID)
+}
+
+// SetCreatorID sets the "creator" edge to the User entity by ID.
+func (iuo *InviteUpdateOne) SetCreatorID(id int) *InviteUpdateOne {
+	iuo.mutation.SetCreatorID(id)
+	return iuo
+}
+
+// SetCreator sets the "creator" edge to the User entity.
+func (iuo *InviteUpdateOne) SetCreator(u *User) *InviteUpdateOne {
+	return iuo.SetCreatorID(u.ID)
+}
+
+// Mutation returns the InviteMutation object of the builder.
+func (iuo *InviteUpdateOne) Mutation() *InviteMutation {
+	return iuo.mutation
+}
+
+// ClearPoll clears the "poll" edge to the Poll entity.
+func (iuo *InviteUpdateOne) ClearPoll() *InviteUpdateOne {
+	iuo.mutation.ClearPoll()
+	return iuo
+}
+
+// ClearCreator clears the "creator" edge to the User entity.
+func (iuo *InviteUpdateOne) ClearCreator() *InviteUpdateOne {
+	iuo.mutation.ClearCreator()
+	return iuo
+}
+
+// Where appends a list predicates to the InviteUpdate builder.
+func (iuo *InviteUpdateOne) Where(ps ...predicate.Invite) *InviteUpdateOne {
+	iuo.mutation.Where(ps...)
+	return iuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (iuo *InviteUpdateOne) Select(field string, fields ...string) *InviteUpdateOne {
+	iuo.fields = append([]string{field}, fields...)
+	return iuo
+}
+
+// Save executes the query and returns the updated Invite entity.
+func (iuo *InviteUpdateOne) Save(ctx context.Context) (*Invite, error) {
+	return withHooks(ctx, iuo.sqlSave, iuo.mutation, iuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (iuo *InviteUpdateOne) SaveX(ctx context.Context) *Invite {
+	node, err := iuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (iuo *InviteUpdateOne) Exec(ctx context.Context) error {
+	_, err := iuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (iuo *InviteUpdateOne) ExecX(ctx context.Context) {
+	if err := iuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (iuo *InviteUpdateOne) check() error {
+	if v, ok := iuo.mutation.MaxUses(); ok {
+		if err := invite.MaxUsesValidator(v); err != nil {
+			return &ValidationError{Name: "max_uses", err: fmt.Errorf(`ent: validator failed for field "Invite.max_uses": %w`, err)}
+		}
+	}
+	if v, ok := iuo.mutation.Uses(); ok {
+		if err := invite.UsesValidator(v); err != nil {
+			return &ValidationError{Name: "uses", err: fmt.Errorf(`ent: validator failed for field "Invite.uses": %w`, err)}
+		}
+	}
+	if iuo.mutation.PollCleared() && len(iuo.mutation.PollIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Invite.poll"`)
+	}
+	if iuo.mutation.CreatorCleared() && len(iuo.mutation.CreatorIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Invite.creator"`)
+	}
+	return nil
+}
+
+func (iuo *InviteUpdateOne) sqlSave(ctx context.Context) (_node *Invite, err error) {
+	if err := iuo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(invite.Table, invite.Columns, sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt))
+	id, ok := iuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Invite.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := iuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, invite.FieldID)
+		for _, f := range fields {
+			if !invite.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != invite.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := iuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if value, ok := iuo.mutation.ExpiresAt(); ok {
+		_spec.SetField(invite.FieldExpiresAt, field.TypeTime, value)
+	}
+	if iuo.mutation.ExpiresAtCleared() {
+		_spec.ClearField(invite.FieldExpiresAt, field.TypeTime)
+	}
+	if value, ok := iuo.mutation.MaxUses(); ok {
+		_spec.SetField(invite.FieldMaxUses, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.AddedMaxUses(); ok {
+		_spec.AddField(invite.FieldMaxUses, field.TypeInt, value)
+	}
+	if iuo.mutation.MaxUsesCleared() {
+		_spec.ClearField(invite.FieldMaxUses, field.TypeInt)
+	}
+	if value, ok := iuo.mutation.Uses(); ok {
+		_spec.SetField(invite.FieldUses, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.AddedUses(); ok {
+		_spec.AddField(invite.FieldUses, field.TypeInt, value)
+	}
+	if value, ok := iuo.mutation.RevokedAt(); ok {
+		_spec.SetField(invite.FieldRevokedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.RevokedAtCleared() {
+		_spec.ClearField(invite.FieldRevokedAt, field.TypeTime)
+	}
+	if value, ok := iuo.mutation.CreatedAt(); ok {
+		_spec.SetField(invite.FieldCreatedAt, field.TypeTime, value)
+	}
+	if iuo.mutation.PollCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.PollTable,
+			Columns: []string{invite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := iuo.mutation.PollIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.PollTable,
+			Columns: []string{invite.PollColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(poll.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if iuo.mutation.CreatorCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.CreatorTable,
+			Columns: []string{invite.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := iuo.mutation.CreatorIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   invite.CreatorTable,
+			Columns: []string{invite.CreatorColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Invite{config: iuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, iuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{invite.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	iuo.mutation.done = true
+	return _node, nil
+}
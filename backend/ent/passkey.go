@@ -0,0 +1,265 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"poll_app/ent/passkey"
+	"poll_app/ent/user"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+)
+
+// Passkey is the model entity for the Passkey schema.
+type Passkey struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// CredentialID holds the value of the "credential_id" field.
+	CredentialID []byte `json:"credential_id,omitempty"`
+	// PublicKey holds the value of the "public_key" field.
+	PublicKey []byte `json:"public_key,omitempty"`
+	// AttestationType holds the value of the "attestation_type" field.
+	AttestationType string `json:"attestation_type,omitempty"`
+	// Aaguid holds the value of the "aaguid" field.
+	Aaguid []byte `json:"aaguid,omitempty"`
+	// Transports holds the value of the "transports" field.
+	Transports []string `json:"transports,omitempty"`
+	// SignCount holds the value of the "sign_count" field.
+	SignCount uint32 `json:"sign_count,omitempty"`
+	// BackupEligible holds the value of the "backup_eligible" field.
+	BackupEligible bool `json:"backup_eligible,omitempty"`
+	// BackupState holds the value of the "backup_state" field.
+	BackupState bool `json:"backup_state,omitempty"`
+	// Name holds the value of the "name" field.
+	Name string `json:"name,omitempty"`
+	// LastUsedAt holds the value of the "last_used_at" field.
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PasskeyQuery when eager-loading is set.
+	Edges         PasskeyEdges `json:"edges"`
+	user_passkeys *int
+	selectValues  sql.SelectValues
+}
+
+// PasskeyEdges holds the relations/edges for other nodes in the graph.
+type PasskeyEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PasskeyEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*Passkey) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case passkey.FieldCredentialID, passkey.FieldPublicKey, passkey.FieldAaguid, passkey.FieldTransports:
+			values[i] = new([]byte)
+		case passkey.FieldBackupEligible, passkey.FieldBackupState:
+			values[i] = new(sql.NullBool)
+		case passkey.FieldID, passkey.FieldSignCount:
+			values[i] = new(sql.NullInt64)
+		case passkey.FieldAttestationType, passkey.FieldName:
+			values[i] = new(sql.NullString)
+		case passkey.FieldLastUsedAt, passkey.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case passkey.ForeignKeys[0]: // user_passkeys
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the Passkey fields.
+func (pa *Passkey) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case passkey.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pa.ID = int(value.Int64)
+		case passkey.FieldCredentialID:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field credential_id", values[i])
+			} else if value != nil {
+				pa.CredentialID = *value
+			}
+		case passkey.FieldPublicKey:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field public_key", values[i])
+			} else if value != nil {
+				pa.PublicKey = *value
+			}
+		case passkey.FieldAttestationType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field attestation_type", values[i])
+			} else if value.Valid {
+				pa.AttestationType = value.String
+			}
+		case passkey.FieldAaguid:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field aaguid", values[i])
+			} else if value != nil {
+				pa.Aaguid = *value
+			}
+		case passkey.FieldTransports:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field transports", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pa.Transports); err != nil {
+					return fmt.Errorf("unmarshal field transports: %w", err)
+				}
+			}
+		case passkey.FieldSignCount:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field sign_count", values[i])
+			} else if value.Valid {
+				pa.SignCount = uint32(value.Int64)
+			}
+		case passkey.FieldBackupEligible:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field backup_eligible", values[i])
+			} else if value.Valid {
+				pa.BackupEligible = value.Bool
+			}
+		case passkey.FieldBackupState:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field backup_state", values[i])
+			} else if value.Valid {
+				pa.BackupState = value.Bool
+			}
+		case passkey.FieldName:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field name", values[i])
+			} else if value.Valid {
+				pa.Name = value.String
+			}
+		case passkey.FieldLastUsedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field last_used_at", values[i])
+			} else if value.Valid {
+				pa.LastUsedAt = new(time.Time)
+				*pa.LastUsedAt = value.Time
+			}
+		case passkey.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				pa.CreatedAt = value.Time
+			}
+		case passkey.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_passkeys", value)
+			} else if value.Valid {
+				pa.user_passkeys = new(int)
+				*pa.user_passkeys = int(value.Int64)
+			}
+		default:
+			pa.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the Passkey.
+// This includes values selected through modifiers, order, etc.
+func (pa *Passkey) Value(name string) (ent.Value, error) {
+	return pa.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the Passkey entity.
+func (pa *Passkey) QueryUser() *UserQuery {
+	return NewPasskeyClient(pa.config).QueryUser(pa)
+}
+
+// Update returns a builder for updating this Passkey.
+// Note that you need to call Passkey.Unwrap() before calling this method if this Passkey
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pa *Passkey) Update() *PasskeyUpdateOne {
+	return NewPasskeyClient(pa.config).UpdateOne(pa)
+}
+
+// Unwrap unwraps the Passkey entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pa *Passkey) Unwrap() *Passkey {
+	_tx, ok := pa.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: Passkey is not a transactional entity")
+	}
+	pa.config.driver = _tx.drv
+	return pa
+}
+
+// String implements the fmt.Stringer.
+func (pa *Passkey) String() string {
+	var builder strings.Builder
+	builder.WriteString("Passkey(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pa.ID))
+	builder.WriteString("credential_id=")
+	builder.WriteString(fmt.Sprintf("%v", pa.CredentialID))
+	builder.WriteString(", ")
+	builder.WriteString("public_key=")
+	builder.WriteString(fmt.Sprintf("%v", pa.PublicKey))
+	builder.WriteString(", ")
+	builder.WriteString("attestation_type=")
+	builder.WriteString(pa.AttestationType)
+	builder.WriteString(", ")
+	builder.WriteString("aaguid=")
+	builder.WriteString(fmt.Sprintf("%v", pa.Aaguid))
+	builder.WriteString(", ")
+	builder.WriteString("transports=")
+	builder.WriteString(fmt.Sprintf("%v", pa.Transports))
+	builder.WriteString(", ")
+	builder.WriteString("sign_count=")
+	builder.WriteString(fmt.Sprintf("%v", pa.SignCount))
+	builder.WriteString(", ")
+	builder.WriteString("backup_eligible=")
+	builder.WriteString(fmt.Sprintf("%v", pa.BackupEligible))
+	builder.WriteString(", ")
+	builder.WriteString("backup_state=")
+	builder.WriteString(fmt.Sprintf("%v", pa.BackupState))
+	builder.WriteString(", ")
+	builder.WriteString("name=")
+	builder.WriteString(pa.Name)
+	builder.WriteString(", ")
+	if v := pa.LastUsedAt; v != nil {
+		builder.WriteString("last_used_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(pa.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// Passkeys is a parsable slice of Passkey.
+type Passkeys []*Passkey
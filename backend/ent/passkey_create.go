@@ -0,0 +1,423 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/passkey"
+	"poll_app/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PasskeyCreate is the builder for creating a Passkey entity.
+type PasskeyCreate struct {
+	config
+	mutation *PasskeyMutation
+	hooks    []Hook
+}
+
+// SetCredentialID sets the "credential_id" field.
+func (pc *PasskeyCreate) SetCredentialID(b []byte) *PasskeyCreate {
+	pc.mutation.SetCredentialID(b)
+	return pc
+}
+
+// SetPublicKey sets the "public_key" field.
+func (pc *PasskeyCreate) SetPublicKey(b []byte) *PasskeyCreate {
+	pc.mutation.SetPublicKey(b)
+	return pc
+}
+
+// SetAttestationType sets the "attestation_type" field.
+func (pc *PasskeyCreate) SetAttestationType(s string) *PasskeyCreate {
+	pc.mutation.SetAttestationType(s)
+	return pc
+}
+
+// SetNillableAttestationType sets the "attestation_type" field if the given value is not nil.
+func (pc *PasskeyCreate) SetNillableAttestationType(s *string) *PasskeyCreate {
+	if s != nil {
+		pc.SetAttestationType(*s)
+	}
+	return pc
+}
+
+// SetAaguid sets the "aaguid" field.
+func (pc *PasskeyCreate) SetAaguid(b []byte) *PasskeyCreate {
+	pc.mutation.SetAaguid(b)
+	return pc
+}
+
+// SetTransports sets the "transports" field.
+func (pc *PasskeyCreate) SetTransports(s []string) *PasskeyCreate {
+	pc.mutation.SetTransports(s)
+	return pc
+}
+
+// SetSignCount sets the "sign_count" field.
+func (pc *PasskeyCreate) SetSignCount(u uint32) *PasskeyCreate {
+	pc.mutation.SetSignCount(u)
+	return pc
+}
+
+// SetNillableSignCount sets the "sign_count" field if the given value is not nil.
+func (pc *PasskeyCreate) SetNillableSignCount(u *uint32) *PasskeyCreate {
+	if u != nil {
+		pc.SetSignCount(*u)
+	}
+	return pc
+}
+
+// SetBackupEligible sets the "backup_eligible" field.
+func (pc *PasskeyCreate) SetBackupEligible(b bool) *PasskeyCreate {
+	pc.mutation.SetBackupEligible(b)
+	return pc
+}
+
+// SetNillableBackupEligible sets the "backup_eligible" field if the given value is not nil.
+func (pc *PasskeyCreate) SetNillableBackupEligible(b *bool) *PasskeyCreate {
+	if b != nil {
+		pc.SetBackupEligible(*b)
+	}
+	return pc
+}
+
+// SetBackupState sets the "backup_state" field.
+func (pc *PasskeyCreate) SetBackupState(b bool) *PasskeyCreate {
+	pc.mutation.SetBackupState(b)
+	return pc
+}
+
+// SetNillableBackupState sets the "backup_state" field if the given value is not nil.
+func (pc *PasskeyCreate) SetNillableBackupState(b *bool) *PasskeyCreate {
+	if b != nil {
+		pc.SetBackupState(*b)
+	}
+	return pc
+}
+
+// SetName sets the "name" field.
+func (pc *PasskeyCreate) SetName(s string) *PasskeyCreate {
+	pc.mutation.SetName(s)
+	return pc
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (pc *PasskeyCreate) SetNillableName(s *string) *PasskeyCreate {
+	if s != nil {
+		pc.SetName(*s)
+	}
+	return pc
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (pc *PasskeyCreate) SetLastUsedAt(t time.Time) *PasskeyCreate {
+	pc.mutation.SetLastUsedAt(t)
+	return pc
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (pc *PasskeyCreate) SetNillableLastUsedAt(t *time.Time) *PasskeyCreate {
+	if t != nil {
+		pc.SetLastUsedAt(*t)
+	}
+	return pc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (pc *PasskeyCreate) SetCreatedAt(t time.Time) *PasskeyCreate {
+	pc.mutation.SetCreatedAt(t)
+	return pc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (pc *PasskeyCreate) SetNillableCreatedAt(t *time.Time) *PasskeyCreate {
+	if t != nil {
+		pc.SetCreatedAt(*t)
+	}
+	return pc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (pc *PasskeyCreate) SetUserID(id int) *PasskeyCreate {
+	pc.mutation.SetUserID(id)
+	return pc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (pc *PasskeyCreate) SetUser(u *User) *PasskeyCreate {
+	return pc.SetUserID(u.ID)
+}
+
+// Mutation returns the PasskeyMutation object of the builder.
+func (pc *PasskeyCreate) Mutation() *PasskeyMutation {
+	return pc.mutation
+}
+
+// Save creates the Passkey in the database.
+func (pc *PasskeyCreate) Save(ctx context.Context) (*Passkey, error) {
+	pc.defaults()
+	return withHooks(ctx, pc.sqlSave, pc.mutation, pc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pc *PasskeyCreate) SaveX(ctx context.Context) *Passkey {
+	v, err := pc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pc *PasskeyCreate) Exec(ctx context.Context) error {
+	_, err := pc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pc *PasskeyCreate) ExecX(ctx context.Context) {
+	if err := pc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pc *PasskeyCreate) defaults() {
+	if _, ok := pc.mutation.AttestationType(); !ok {
+		v := passkey.DefaultAttestationType
+		pc.mutation.SetAttestationType(v)
+	}
+	if _, ok := pc.mutation.SignCount(); !ok {
+		v := passkey.DefaultSignCount
+		pc.mutation.SetSignCount(v)
+	}
+	if _, ok := pc.mutation.BackupEligible(); !ok {
+		v := passkey.DefaultBackupEligible
+		pc.mutation.SetBackupEligible(v)
+	}
+	if _, ok := pc.mutation.BackupState(); !ok {
+		v := passkey.DefaultBackupState
+		pc.mutation.SetBackupState(v)
+	}
+	if _, ok := pc.mutation.Name(); !ok {
+		v := passkey.DefaultName
+		pc.mutation.SetName(v)
+	}
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		v := passkey.DefaultCreatedAt()
+		pc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pc *PasskeyCreate) check() error {
+	if _, ok := pc.mutation.CredentialID(); !ok {
+		return &ValidationError{Name: "credential_id", err: errors.New(`ent: missing required field "Passkey.credential_id"`)}
+	}
+	if _, ok := pc.mutation.PublicKey(); !ok {
+		return &ValidationError{Name: "public_key", err: errors.New(`ent: missing required field "Passkey.public_key"`)}
+	}
+	if _, ok := pc.mutation.AttestationType(); !ok {
+		return &ValidationError{Name: "attestation_type", err: errors.New(`ent: missing required field "Passkey.attestation_type"`)}
+	}
+	if _, ok := pc.mutation.SignCount(); !ok {
+		return &ValidationError{Name: "sign_count", err: errors.New(`ent: missing required field "Passkey.sign_count"`)}
+	}
+	if _, ok := pc.mutation.BackupEligible(); !ok {
+		return &ValidationError{Name: "backup_eligible", err: errors.New(`ent: missing required field "Passkey.backup_eligible"`)}
+	}
+	if _, ok := pc.mutation.BackupState(); !ok {
+		return &ValidationError{Name: "backup_state", err: errors.New(`ent: missing required field "Passkey.backup_state"`)}
+	}
+	if _, ok := pc.mutation.Name(); !ok {
+		return &ValidationError{Name: "name", err: errors.New(`ent: missing required field "Passkey.name"`)}
+	}
+	if _, ok := pc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Passkey.created_at"`)}
+	}
+	if len(pc.mutation.UserIDs()) == 0 {
+		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Passkey.user"`)}
+	}
+	return nil
+}
+
+func (pc *PasskeyCreate) sqlSave(ctx context.Context) (*Passkey, error) {
+	if err := pc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := pc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	pc.mutation.id = &_node.ID
+	pc.mutation.done = true
+	return _node, nil
+}
+
+func (pc *PasskeyCreate) createSpec() (*Passkey, *sqlgraph.CreateSpec) {
+	var (
+		_node = &Passkey{config: pc.config}
+		_spec = sqlgraph.NewCreateSpec(passkey.Table, sqlgraph.NewFieldSpec(passkey.FieldID, field.TypeInt))
+	)
+	if value, ok := pc.mutation.CredentialID(); ok {
+		_spec.SetField(passkey.FieldCredentialID, field.TypeBytes, value)
+		_node.CredentialID = value
+	}
+	if value, ok := pc.mutation.PublicKey(); ok {
+		_spec.SetField(passkey.FieldPublicKey, field.TypeBytes, value)
+		_node.PublicKey = value
+	}
+	if value, ok := pc.mutation.AttestationType(); ok {
+		_spec.SetField(passkey.FieldAttestationType, field.TypeString, value)
+		_node.AttestationType = value
+	}
+	if value, ok := pc.mutation.Aaguid(); ok {
+		_spec.SetField(passkey.FieldAaguid, field.TypeBytes, value)
+		_node.Aaguid = value
+	}
+	if value, ok := pc.mutation.Transports(); ok {
+		_spec.SetField(passkey.FieldTransports, field.TypeJSON, value)
+		_node.Transports = value
+	}
+	if value, ok := pc.mutation.SignCount(); ok {
+		_spec.SetField(passkey.FieldSignCount, field.TypeUint32, value)
+		_node.SignCount = value
+	}
+	if value, ok := pc.mutation.BackupEligible(); ok {
+		_spec.SetField(passkey.FieldBackupEligible, field.TypeBool, value)
+		_node.BackupEligible = value
+	}
+	if value, ok := pc.mutation.BackupState(); ok {
+		_spec.SetField(passkey.FieldBackupState, field.TypeBool, value)
+		_node.BackupState = value
+	}
+	if value, ok := pc.mutation.Name(); ok {
+		_spec.SetField(passkey.FieldName, field.TypeString, value)
+		_node.Name = value
+	}
+	if value, ok := pc.mutation.LastUsedAt(); ok {
+		_spec.SetField(passkey.FieldLastUsedAt, field.TypeTime, value)
+		_node.LastUsedAt = &value
+	}
+	if value, ok := pc.mutation.CreatedAt(); ok {
+		_spec.SetField(passkey.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := pc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passkey.UserTable,
+			Columns: []string{passkey.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_passkeys = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PasskeyCreateBulk is the builder for creating many Passkey entities in bulk.
+type PasskeyCreateBulk struct {
+	config
+	err      error
+	builders []*PasskeyCreate
+}
+
+// Save creates the Passkey entities in the database.
+func (pcb *PasskeyCreateBulk) Save(ctx context.Context) ([]*Passkey, error) {
+	if pcb.err != nil {
+		return nil, pcb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(pcb.builders))
+	nodes := make([]*Passkey, len(pcb.builders))
+	mutators := make([]Mutator, len(pcb.builders))
+	for i := range pcb.builders {
+		func(i int, root context.Context) {
+			builder := pcb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PasskeyMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pcb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pcb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pcb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pcb *PasskeyCreateBulk) SaveX(ctx context.Context) []*Passkey {
+	v, err := pcb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pcb *PasskeyCreateBulk) Exec(ctx context.Context) error {
+	_, err := pcb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcb *PasskeyCreateBulk) ExecX(ctx context.Context) {
+	if err := pcb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,477 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/passkey"
+	"poll_app/ent/predicate"
+	"poll_app/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PasskeyUpdate is the builder for updating Passkey entities.
+type PasskeyUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PasskeyMutation
+}
+
+// Where appends a list predicates to the PasskeyUpdate builder.
+func (pu *PasskeyUpdate) Where(ps ...predicate.Passkey) *PasskeyUpdate {
+	pu.mutation.Where(ps...)
+	return pu
+}
+
+// SetSignCount sets the "sign_count" field.
+func (pu *PasskeyUpdate) SetSignCount(u uint32) *PasskeyUpdate {
+	pu.mutation.ResetSignCount()
+	pu.mutation.SetSignCount(u)
+	return pu
+}
+
+// SetNillableSignCount sets the "sign_count" field if the given value is not nil.
+func (pu *PasskeyUpdate) SetNillableSignCount(u *uint32) *PasskeyUpdate {
+	if u != nil {
+		pu.SetSignCount(*u)
+	}
+	return pu
+}
+
+// AddSignCount adds u to the "sign_count" field.
+func (pu *PasskeyUpdate) AddSignCount(u int32) *PasskeyUpdate {
+	pu.mutation.AddSignCount(u)
+	return pu
+}
+
+// SetBackupState sets the "backup_state" field.
+func (pu *PasskeyUpdate) SetBackupState(b bool) *PasskeyUpdate {
+	pu.mutation.SetBackupState(b)
+	return pu
+}
+
+// SetNillableBackupState sets the "backup_state" field if the given value is not nil.
+func (pu *PasskeyUpdate) SetNillableBackupState(b *bool) *PasskeyUpdate {
+	if b != nil {
+		pu.SetBackupState(*b)
+	}
+	return pu
+}
+
+// SetName sets the "name" field.
+func (pu *PasskeyUpdate) SetName(s string) *PasskeyUpdate {
+	pu.mutation.SetName(s)
+	return pu
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (pu *PasskeyUpdate) SetNillableName(s *string) *PasskeyUpdate {
+	if s != nil {
+		pu.SetName(*s)
+	}
+	return pu
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (pu *PasskeyUpdate) SetLastUsedAt(t time.Time) *PasskeyUpdate {
+	pu.mutation.SetLastUsedAt(t)
+	return pu
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (pu *PasskeyUpdate) SetNillableLastUsedAt(t *time.Time) *PasskeyUpdate {
+	if t != nil {
+		pu.SetLastUsedAt(*t)
+	}
+	return pu
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (pu *PasskeyUpdate) ClearLastUsedAt() *PasskeyUpdate {
+	pu.mutation.ClearLastUsedAt()
+	return pu
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (pu *PasskeyUpdate) SetUserID(id int) *PasskeyUpdate {
+	pu.mutation.SetUserID(id)
+	return pu
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (pu *PasskeyUpdate) SetUser(u *User) *PasskeyUpdate {
+	return pu.SetUserID(u.ID)
+}
+
+// Mutation returns the PasskeyMutation object of the builder.
+func (pu *PasskeyUpdate) Mutation() *PasskeyMutation {
+	return pu.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (pu *PasskeyUpdate) ClearUser() *PasskeyUpdate {
+	pu.mutation.ClearUser()
+	return pu
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pu *PasskeyUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, pu.sqlSave, pu.mutation, pu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pu *PasskeyUpdate) SaveX(ctx context.Context) int {
+	affected, err := pu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pu *PasskeyUpdate) Exec(ctx context.Context) error {
+	_, err := pu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pu *PasskeyUpdate) ExecX(ctx context.Context) {
+	if err := pu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pu *PasskeyUpdate) check() error {
+	if pu.mutation.UserCleared() && len(pu.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Passkey.user"`)
+	}
+	return nil
+}
+
+func (pu *PasskeyUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	if err := pu.check(); err != nil {
+		return n, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(passkey.Table, passkey.Columns, sqlgraph.NewFieldSpec(passkey.FieldID, field.TypeInt))
+	if ps := pu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if pu.mutation.AaguidCleared() {
+		_spec.ClearField(passkey.FieldAaguid, field.TypeBytes)
+	}
+	if pu.mutation.TransportsCleared() {
+		_spec.ClearField(passkey.FieldTransports, field.TypeJSON)
+	}
+	if value, ok := pu.mutation.SignCount(); ok {
+		_spec.SetField(passkey.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := pu.mutation.AddedSignCount(); ok {
+		_spec.AddField(passkey.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := pu.mutation.BackupState(); ok {
+		_spec.SetField(passkey.FieldBackupState, field.TypeBool, value)
+	}
+	if value, ok := pu.mutation.Name(); ok {
+		_spec.SetField(passkey.FieldName, field.TypeString, value)
+	}
+	if value, ok := pu.mutation.LastUsedAt(); ok {
+		_spec.SetField(passkey.FieldLastUsedAt, field.TypeTime, value)
+	}
+	if pu.mutation.LastUsedAtCleared() {
+		_spec.ClearField(passkey.FieldLastUsedAt, field.TypeTime)
+	}
+	if pu.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passkey.UserTable,
+			Columns: []string{passkey.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passkey.UserTable,
+			Columns: []string{passkey.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passkey.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pu.mutation.done = true
+	return n, nil
+}
+
+// PasskeyUpdateOne is the builder for updating a single Passkey entity.
+type PasskeyUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PasskeyMutation
+}
+
+// SetSignCount sets the "sign_count" field.
+func (puo *PasskeyUpdateOne) SetSignCount(u uint32) *PasskeyUpdateOne {
+	puo.mutation.ResetSignCount()
+	puo.mutation.SetSignCount(u)
+	return puo
+}
+
+// SetNillableSignCount sets the "sign_count" field if the given value is not nil.
+func (puo *PasskeyUpdateOne) SetNillableSignCount(u *uint32) *PasskeyUpdateOne {
+	if u != nil {
+		puo.SetSignCount(*u)
+	}
+	return puo
+}
+
+// AddSignCount adds u to the "sign_count" field.
+func (puo *PasskeyUpdateOne) AddSignCount(u int32) *PasskeyUpdateOne {
+	puo.mutation.AddSignCount(u)
+	return puo
+}
+
+// SetBackupState sets the "backup_state" field.
+func (puo *PasskeyUpdateOne) SetBackupState(b bool) *PasskeyUpdateOne {
+	puo.mutation.SetBackupState(b)
+	return puo
+}
+
+// SetNillableBackupState sets the "backup_state" field if the given value is not nil.
+func (puo *PasskeyUpdateOne) SetNillableBackupState(b *bool) *PasskeyUpdateOne {
+	if b != nil {
+		puo.SetBackupState(*b)
+	}
+	return puo
+}
+
+// SetName sets the "name" field.
+func (puo *PasskeyUpdateOne) SetName(s string) *PasskeyUpdateOne {
+	puo.mutation.SetName(s)
+	return puo
+}
+
+// SetNillableName sets the "name" field if the given value is not nil.
+func (puo *PasskeyUpdateOne) SetNillableName(s *string) *PasskeyUpdateOne {
+	if s != nil {
+		puo.SetName(*s)
+	}
+	return puo
+}
+
+// SetLastUsedAt sets the "last_used_at" field.
+func (puo *PasskeyUpdateOne) SetLastUsedAt(t time.Time) *PasskeyUpdateOne {
+	puo.mutation.SetLastUsedAt(t)
+	return puo
+}
+
+// SetNillableLastUsedAt sets the "last_used_at" field if the given value is not nil.
+func (puo *PasskeyUpdateOne) SetNillableLastUsedAt(t *time.Time) *PasskeyUpdateOne {
+	if t != nil {
+		puo.SetLastUsedAt(*t)
+	}
+	return puo
+}
+
+// ClearLastUsedAt clears the value of the "last_used_at" field.
+func (puo *PasskeyUpdateOne) ClearLastUsedAt() *PasskeyUpdateOne {
+	puo.mutation.ClearLastUsedAt()
+	return puo
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (puo *PasskeyUpdateOne) SetUserID(id int) *PasskeyUpdateOne {
+	puo.mutation.SetUserID(id)
+	return puo
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (puo *PasskeyUpdateOne) SetUser(u *User) *PasskeyUpdateOne {
+	return puo.SetUserID(u.ID)
+}
+
+// Mutation returns the PasskeyMutation object of the builder.
+func (puo *PasskeyUpdateOne) Mutation() *PasskeyMutation {
+	return puo.mutation
+}
+
+// ClearUser clears the "user" edge to the User entity.
+func (puo *PasskeyUpdateOne) ClearUser() *PasskeyUpdateOne {
+	puo.mutation.ClearUser()
+	return puo
+}
+
+// Where appends a list predicates to the PasskeyUpdate builder.
+func (puo *PasskeyUpdateOne) Where(ps ...predicate.Passkey) *PasskeyUpdateOne {
+	puo.mutation.Where(ps...)
+	return puo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (puo *PasskeyUpdateOne) Select(field string, fields ...string) *PasskeyUpdateOne {
+	puo.fields = append([]string{field}, fields...)
+	return puo
+}
+
+// Save executes the query and returns the updated Passkey entity.
+func (puo *PasskeyUpdateOne) Save(ctx context.Context) (*Passkey, error) {
+	return withHooks(ctx, puo.sqlSave, puo.mutation, puo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (puo *PasskeyUpdateOne) SaveX(ctx context.Context) *Passkey {
+	node, err := puo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (puo *PasskeyUpdateOne) Exec(ctx context.Context) error {
+	_, err := puo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (puo *PasskeyUpdateOne) ExecX(ctx context.Context) {
+	if err := puo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (puo *PasskeyUpdateOne) check() error {
+	if puo.mutation.UserCleared() && len(puo.mutation.UserIDs()) > 0 {
+		return errors.New(`ent: clearing a required unique edge "Passkey.user"`)
+	}
+	return nil
+}
+
+func (puo *PasskeyUpdateOne) sqlSave(ctx context.Context) (_node *Passkey, err error) {
+	if err := puo.check(); err != nil {
+		return _node, err
+	}
+	_spec := sqlgraph.NewUpdateSpec(passkey.Table, passkey.Columns, sqlgraph.NewFieldSpec(passkey.FieldID, field.TypeInt))
+	id, ok := puo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "Passkey.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := puo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, passkey.FieldID)
+		for _, f := range fields {
+			if !passkey.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != passkey.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := puo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if puo.mutation.AaguidCleared() {
+		_spec.ClearField(passkey.FieldAaguid, field.TypeBytes)
+	}
+	if puo.mutation.TransportsCleared() {
+		_spec.ClearField(passkey.FieldTransports, field.TypeJSON)
+	}
+	if value, ok := puo.mutation.SignCount(); ok {
+		_spec.SetField(passkey.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := puo.mutation.AddedSignCount(); ok {
+		_spec.AddField(passkey.FieldSignCount, field.TypeUint32, value)
+	}
+	if value, ok := puo.mutation.BackupState(); ok {
+		_spec.SetField(passkey.FieldBackupState, field.TypeBool, value)
+	}
+	if value, ok := puo.mutation.Name(); ok {
+		_spec.SetField(passkey.FieldName, field.TypeString, value)
+	}
+	if value, ok := puo.mutation.LastUsedAt(); ok {
+		_spec.SetField(passkey.FieldLastUsedAt, field.TypeTime, value)
+	}
+	if puo.mutation.LastUsedAtCleared() {
+		_spec.ClearField(passkey.FieldLastUsedAt, field.TypeTime)
+	}
+	if puo.mutation.UserCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passkey.UserTable,
+			Columns: []string{passkey.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passkey.UserTable,
+			Columns: []string{passkey.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	_node = &Passkey{config: puo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, puo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passkey.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	puo.mutation.done = true
+	return _node, nil
+}
@@ -0,0 +1,194 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"encoding/json"
+	"fmt"
+	"poll_app/ent/passkeyceremony"
+	"poll_app/ent/user"
+	"strings"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/dialect/sql"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// PasskeyCeremony is the model entity for the PasskeyCeremony schema.
+type PasskeyCeremony struct {
+	config `json:"-"`
+	// ID of the ent.
+	ID int `json:"id,omitempty"`
+	// Kind holds the value of the "kind" field.
+	Kind passkeyceremony.Kind `json:"kind,omitempty"`
+	// TokenHash holds the value of the "token_hash" field.
+	TokenHash string `json:"-"`
+	// Session holds the value of the "session" field.
+	Session webauthn.SessionData `json:"session,omitempty"`
+	// ExpiresAt holds the value of the "expires_at" field.
+	ExpiresAt time.Time `json:"expires_at,omitempty"`
+	// CreatedAt holds the value of the "created_at" field.
+	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Edges holds the relations/edges for other nodes in the graph.
+	// The values are being populated by the PasskeyCeremonyQuery when eager-loading is set.
+	Edges                   PasskeyCeremonyEdges `json:"edges"`
+	user_passkey_ceremonies *int
+	selectValues            sql.SelectValues
+}
+
+// PasskeyCeremonyEdges holds the relations/edges for other nodes in the graph.
+type PasskeyCeremonyEdges struct {
+	// User holds the value of the user edge.
+	User *User `json:"user,omitempty"`
+	// loadedTypes holds the information for reporting if a
+	// type was loaded (or requested) in eager-loading or not.
+	loadedTypes [1]bool
+}
+
+// UserOrErr returns the User value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PasskeyCeremonyEdges) UserOrErr() (*User, error) {
+	if e.User != nil {
+		return e.User, nil
+	} else if e.loadedTypes[0] {
+		return nil, &NotFoundError{label: user.Label}
+	}
+	return nil, &NotLoadedError{edge: "user"}
+}
+
+// scanValues returns the types for scanning values from sql.Rows.
+func (*PasskeyCeremony) scanValues(columns []string) ([]any, error) {
+	values := make([]any, len(columns))
+	for i := range columns {
+		switch columns[i] {
+		case passkeyceremony.FieldSession:
+			values[i] = new([]byte)
+		case passkeyceremony.FieldID:
+			values[i] = new(sql.NullInt64)
+		case passkeyceremony.FieldKind, passkeyceremony.FieldTokenHash:
+			values[i] = new(sql.NullString)
+		case passkeyceremony.FieldExpiresAt, passkeyceremony.FieldCreatedAt:
+			values[i] = new(sql.NullTime)
+		case passkeyceremony.ForeignKeys[0]: // user_passkey_ceremonies
+			values[i] = new(sql.NullInt64)
+		default:
+			values[i] = new(sql.UnknownType)
+		}
+	}
+	return values, nil
+}
+
+// assignValues assigns the values that were returned from sql.Rows (after scanning)
+// to the PasskeyCeremony fields.
+func (pc *PasskeyCeremony) assignValues(columns []string, values []any) error {
+	if m, n := len(values), len(columns); m < n {
+		return fmt.Errorf("mismatch number of scan values: %d != %d", m, n)
+	}
+	for i := range columns {
+		switch columns[i] {
+		case passkeyceremony.FieldID:
+			value, ok := values[i].(*sql.NullInt64)
+			if !ok {
+				return fmt.Errorf("unexpected type %T for field id", value)
+			}
+			pc.ID = int(value.Int64)
+		case passkeyceremony.FieldKind:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field kind", values[i])
+			} else if value.Valid {
+				pc.Kind = passkeyceremony.Kind(value.String)
+			}
+		case passkeyceremony.FieldTokenHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field token_hash", values[i])
+			} else if value.Valid {
+				pc.TokenHash = value.String
+			}
+		case passkeyceremony.FieldSession:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field session", values[i])
+			} else if value != nil && len(*value) > 0 {
+				if err := json.Unmarshal(*value, &pc.Session); err != nil {
+					return fmt.Errorf("unmarshal field session: %w", err)
+				}
+			}
+		case passkeyceremony.FieldExpiresAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field expires_at", values[i])
+			} else if value.Valid {
+				pc.ExpiresAt = value.Time
+			}
+		case passkeyceremony.FieldCreatedAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field created_at", values[i])
+			} else if value.Valid {
+				pc.CreatedAt = value.Time
+			}
+		case passkeyceremony.ForeignKeys[0]:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for edge-field user_passkey_ceremonies", value)
+			} else if value.Valid {
+				pc.user_passkey_ceremonies = new(int)
+				*pc.user_passkey_ceremonies = int(value.Int64)
+			}
+		default:
+			pc.selectValues.Set(columns[i], values[i])
+		}
+	}
+	return nil
+}
+
+// Value returns the ent.Value that was dynamically selected and assigned to the PasskeyCeremony.
+// This includes values selected through modifiers, order, etc.
+func (pc *PasskeyCeremony) Value(name string) (ent.Value, error) {
+	return pc.selectValues.Get(name)
+}
+
+// QueryUser queries the "user" edge of the PasskeyCeremony entity.
+func (pc *PasskeyCeremony) QueryUser() *UserQuery {
+	return NewPasskeyCeremonyClient(pc.config).QueryUser(pc)
+}
+
+// Update returns a builder for updating this PasskeyCeremony.
+// Note that you need to call PasskeyCeremony.Unwrap() before calling this method if this PasskeyCeremony
+// was returned from a transaction, and the transaction was committed or rolled back.
+func (pc *PasskeyCeremony) Update() *PasskeyCeremonyUpdateOne {
+	return NewPasskeyCeremonyClient(pc.config).UpdateOne(pc)
+}
+
+// Unwrap unwraps the PasskeyCeremony entity that was returned from a transaction after it was closed,
+// so that all future queries will be executed through the driver which created the transaction.
+func (pc *PasskeyCeremony) Unwrap() *PasskeyCeremony {
+	_tx, ok := pc.config.driver.(*txDriver)
+	if !ok {
+		panic("ent: PasskeyCeremony is not a transactional entity")
+	}
+	pc.config.driver = _tx.drv
+	return pc
+}
+
+// String implements the fmt.Stringer.
+func (pc *PasskeyCeremony) String() string {
+	var builder strings.Builder
+	builder.WriteString("PasskeyCeremony(")
+	builder.WriteString(fmt.Sprintf("id=%v, ", pc.ID))
+	builder.WriteString("kind=")
+	builder.WriteString(fmt.Sprintf("%v", pc.Kind))
+	builder.WriteString(", ")
+	builder.WriteString("token_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("session=")
+	builder.WriteString(fmt.Sprintf("%v", pc.Session))
+	builder.WriteString(", ")
+	builder.WriteString("expires_at=")
+	builder.WriteString(pc.ExpiresAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("created_at=")
+	builder.WriteString(pc.CreatedAt.Format(time.ANSIC))
+	builder.WriteByte(')')
+	return builder.String()
+}
+
+// PasskeyCeremonies is a parsable slice of PasskeyCeremony.
+type PasskeyCeremonies []*PasskeyCeremony
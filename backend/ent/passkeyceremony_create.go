@@ -0,0 +1,297 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/passkeyceremony"
+	"poll_app/ent/user"
+	"time"
+
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// PasskeyCeremonyCreate is the builder for creating a PasskeyCeremony entity.
+type PasskeyCeremonyCreate struct {
+	config
+	mutation *PasskeyCeremonyMutation
+	hooks    []Hook
+}
+
+// SetKind sets the "kind" field.
+func (pcc *PasskeyCeremonyCreate) SetKind(pa passkeyceremony.Kind) *PasskeyCeremonyCreate {
+	pcc.mutation.SetKind(pa)
+	return pcc
+}
+
+// SetTokenHash sets the "token_hash" field.
+func (pcc *PasskeyCeremonyCreate) SetTokenHash(s string) *PasskeyCeremonyCreate {
+	pcc.mutation.SetTokenHash(s)
+	return pcc
+}
+
+// SetSession sets the "session" field.
+func (pcc *PasskeyCeremonyCreate) SetSession(wd webauthn.SessionData) *PasskeyCeremonyCreate {
+	pcc.mutation.SetSession(wd)
+	return pcc
+}
+
+// SetExpiresAt sets the "expires_at" field.
+func (pcc *PasskeyCeremonyCreate) SetExpiresAt(t time.Time) *PasskeyCeremonyCreate {
+	pcc.mutation.SetExpiresAt(t)
+	return pcc
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (pcc *PasskeyCeremonyCreate) SetCreatedAt(t time.Time) *PasskeyCeremonyCreate {
+	pcc.mutation.SetCreatedAt(t)
+	return pcc
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (pcc *PasskeyCeremonyCreate) SetNillableCreatedAt(t *time.Time) *PasskeyCeremonyCreate {
+	if t != nil {
+		pcc.SetCreatedAt(*t)
+	}
+	return pcc
+}
+
+// SetUserID sets the "user" edge to the User entity by ID.
+func (pcc *PasskeyCeremonyCreate) SetUserID(id int) *PasskeyCeremonyCreate {
+	pcc.mutation.SetUserID(id)
+	return pcc
+}
+
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (pcc *PasskeyCeremonyCreate) SetNillableUserID(id *int) *PasskeyCeremonyCreate {
+	if id != nil {
+		pcc = pcc.SetUserID(*id)
+	}
+	return pcc
+}
+
+// SetUser sets the "user" edge to the User entity.
+func (pcc *PasskeyCeremonyCreate) SetUser(u *User) *PasskeyCeremonyCreate {
+	return pcc.SetUserID(u.ID)
+}
+
+// Mutation returns the PasskeyCeremonyMutation object of the builder.
+func (pcc *PasskeyCeremonyCreate) Mutation() *PasskeyCeremonyMutation {
+	return pcc.mutation
+}
+
+// Save creates the PasskeyCeremony in the database.
+func (pcc *PasskeyCeremonyCreate) Save(ctx context.Context) (*PasskeyCeremony, error) {
+	pcc.defaults()
+	return withHooks(ctx, pcc.sqlSave, pcc.mutation, pcc.hooks)
+}
+
+// SaveX calls Save and panics if Save returns an error.
+func (pcc *PasskeyCeremonyCreate) SaveX(ctx context.Context) *PasskeyCeremony {
+	v, err := pcc.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pcc *PasskeyCeremonyCreate) Exec(ctx context.Context) error {
+	_, err := pcc.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcc *PasskeyCeremonyCreate) ExecX(ctx context.Context) {
+	if err := pcc.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+// defaults sets the default values of the builder before save.
+func (pcc *PasskeyCeremonyCreate) defaults() {
+	if _, ok := pcc.mutation.CreatedAt(); !ok {
+		v := passkeyceremony.DefaultCreatedAt()
+		pcc.mutation.SetCreatedAt(v)
+	}
+}
+
+// check runs all checks and user-defined validators on the builder.
+func (pcc *PasskeyCeremonyCreate) check() error {
+	if _, ok := pcc.mutation.Kind(); !ok {
+		return &ValidationError{Name: "kind", err: errors.New(`ent: missing required field "PasskeyCeremony.kind"`)}
+	}
+	if v, ok := pcc.mutation.Kind(); ok {
+		if err := passkeyceremony.KindValidator(v); err != nil {
+			return &ValidationError{Name: "kind", err: fmt.Errorf(`ent: validator failed for field "PasskeyCeremony.kind": %w`, err)}
+		}
+	}
+	if _, ok := pcc.mutation.TokenHash(); !ok {
+		return &ValidationError{Name: "token_hash", err: errors.New(`ent: missing required field "PasskeyCeremony.token_hash"`)}
+	}
+	if _, ok := pcc.mutation.Session(); !ok {
+		return &ValidationError{Name: "session", err: errors.New(`ent: missing required field "PasskeyCeremony.session"`)}
+	}
+	if _, ok := pcc.mutation.ExpiresAt(); !ok {
+		return &ValidationError{Name: "expires_at", err: errors.New(`ent: missing required field "PasskeyCeremony.expires_at"`)}
+	}
+	if _, ok := pcc.mutation.CreatedAt(); !ok {
+		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "PasskeyCeremony.created_at"`)}
+	}
+	return nil
+}
+
+func (pcc *PasskeyCeremonyCreate) sqlSave(ctx context.Context) (*PasskeyCeremony, error) {
+	if err := pcc.check(); err != nil {
+		return nil, err
+	}
+	_node, _spec := pcc.createSpec()
+	if err := sqlgraph.CreateNode(ctx, pcc.driver, _spec); err != nil {
+		if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	id := _spec.ID.Value.(int64)
+	_node.ID = int(id)
+	pcc.mutation.id = &_node.ID
+	pcc.mutation.done = true
+	return _node, nil
+}
+
+func (pcc *PasskeyCeremonyCreate) createSpec() (*PasskeyCeremony, *sqlgraph.CreateSpec) {
+	var (
+		_node = &PasskeyCeremony{config: pcc.config}
+		_spec = sqlgraph.NewCreateSpec(passkeyceremony.Table, sqlgraph.NewFieldSpec(passkeyceremony.FieldID, field.TypeInt))
+	)
+	if value, ok := pcc.mutation.Kind(); ok {
+		_spec.SetField(passkeyceremony.FieldKind, field.TypeEnum, value)
+		_node.Kind = value
+	}
+	if value, ok := pcc.mutation.TokenHash(); ok {
+		_spec.SetField(passkeyceremony.FieldTokenHash, field.TypeString, value)
+		_node.TokenHash = value
+	}
+	if value, ok := pcc.mutation.Session(); ok {
+		_spec.SetField(passkeyceremony.FieldSession, field.TypeJSON, value)
+		_node.Session = value
+	}
+	if value, ok := pcc.mutation.ExpiresAt(); ok {
+		_spec.SetField(passkeyceremony.FieldExpiresAt, field.TypeTime, value)
+		_node.ExpiresAt = value
+	}
+	if value, ok := pcc.mutation.CreatedAt(); ok {
+		_spec.SetField(passkeyceremony.FieldCreatedAt, field.TypeTime, value)
+		_node.CreatedAt = value
+	}
+	if nodes := pcc.mutation.UserIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   passkeyceremony.UserTable,
+			Columns: []string{passkeyceremony.UserColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.user_passkey_ceremonies = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	return _node, _spec
+}
+
+// PasskeyCeremonyCreateBulk is the builder for creating many PasskeyCeremony entities in bulk.
+type PasskeyCeremonyCreateBulk struct {
+	config
+	err      error
+	builders []*PasskeyCeremonyCreate
+}
+
+// Save creates the PasskeyCeremony entities in the database.
+func (pccb *PasskeyCeremonyCreateBulk) Save(ctx context.Context) ([]*PasskeyCeremony, error) {
+	if pccb.err != nil {
+		return nil, pccb.err
+	}
+	specs := make([]*sqlgraph.CreateSpec, len(pccb.builders))
+	nodes := make([]*PasskeyCeremony, len(pccb.builders))
+	mutators := make([]Mutator, len(pccb.builders))
+	for i := range pccb.builders {
+		func(i int, root context.Context) {
+			builder := pccb.builders[i]
+			builder.defaults()
+			var mut Mutator = MutateFunc(func(ctx context.Context, m Mutation) (Value, error) {
+				mutation, ok := m.(*PasskeyCeremonyMutation)
+				if !ok {
+					return nil, fmt.Errorf("unexpected mutation type %T", m)
+				}
+				if err := builder.check(); err != nil {
+					return nil, err
+				}
+				builder.mutation = mutation
+				var err error
+				nodes[i], specs[i] = builder.createSpec()
+				if i < len(mutators)-1 {
+					_, err = mutators[i+1].Mutate(root, pccb.builders[i+1].mutation)
+				} else {
+					spec := &sqlgraph.BatchCreateSpec{Nodes: specs}
+					// Invoke the actual operation on the latest mutation in the chain.
+					if err = sqlgraph.BatchCreate(ctx, pccb.driver, spec); err != nil {
+						if sqlgraph.IsConstraintError(err) {
+							err = &ConstraintError{msg: err.Error(), wrap: err}
+						}
+					}
+				}
+				if err != nil {
+					return nil, err
+				}
+				mutation.id = &nodes[i].ID
+				if specs[i].ID.Value != nil {
+					id := specs[i].ID.Value.(int64)
+					nodes[i].ID = int(id)
+				}
+				mutation.done = true
+				return nodes[i], nil
+			})
+			for i := len(builder.hooks) - 1; i >= 0; i-- {
+				mut = builder.hooks[i](mut)
+			}
+			mutators[i] = mut
+		}(i, ctx)
+	}
+	if len(mutators) > 0 {
+		if _, err := mutators[0].Mutate(ctx, pccb.builders[0].mutation); err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pccb *PasskeyCeremonyCreateBulk) SaveX(ctx context.Context) []*PasskeyCeremony {
+	v, err := pccb.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return v
+}
+
+// Exec executes the query.
+func (pccb *PasskeyCeremonyCreateBulk) Exec(ctx context.Context) error {
+	_, err := pccb.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pccb *PasskeyCeremonyCreateBulk) ExecX(ctx context.Context) {
+	if err := pccb.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,88 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"poll_app/ent/passkeyceremony"
+	"poll_app/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PasskeyCeremonyDelete is the builder for deleting a PasskeyCeremony entity.
+type PasskeyCeremonyDelete struct {
+	config
+	hooks    []Hook
+	mutation *PasskeyCeremonyMutation
+}
+
+// Where appends a list predicates to the PasskeyCeremonyDelete builder.
+func (pcd *PasskeyCeremonyDelete) Where(ps ...predicate.PasskeyCeremony) *PasskeyCeremonyDelete {
+	pcd.mutation.Where(ps...)
+	return pcd
+}
+
+// Exec executes the deletion query and returns how many vertices were deleted.
+func (pcd *PasskeyCeremonyDelete) Exec(ctx context.Context) (int, error) {
+	return withHooks(ctx, pcd.sqlExec, pcd.mutation, pcd.hooks)
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcd *PasskeyCeremonyDelete) ExecX(ctx context.Context) int {
+	n, err := pcd.Exec(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func (pcd *PasskeyCeremonyDelete) sqlExec(ctx context.Context) (int, error) {
+	_spec := sqlgraph.NewDeleteSpec(passkeyceremony.Table, sqlgraph.NewFieldSpec(passkeyceremony.FieldID, field.TypeInt))
+	if ps := pcd.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	affected, err := sqlgraph.DeleteNodes(ctx, pcd.driver, _spec)
+	if err != nil && sqlgraph.IsConstraintError(err) {
+		err = &ConstraintError{msg: err.Error(), wrap: err}
+	}
+	pcd.mutation.done = true
+	return affected, err
+}
+
+// PasskeyCeremonyDeleteOne is the builder for deleting a single PasskeyCeremony entity.
+type PasskeyCeremonyDeleteOne struct {
+	pcd *PasskeyCeremonyDelete
+}
+
+// Where appends a list predicates to the PasskeyCeremonyDelete builder.
+func (pcdo *PasskeyCeremonyDeleteOne) Where(ps ...predicate.PasskeyCeremony) *PasskeyCeremonyDeleteOne {
+	pcdo.pcd.mutation.Where(ps...)
+	return pcdo
+}
+
+// Exec executes the deletion query.
+func (pcdo *PasskeyCeremonyDeleteOne) Exec(ctx context.Context) error {
+	n, err := pcdo.pcd.Exec(ctx)
+	switch {
+	case err != nil:
+		return err
+	case n == 0:
+		return &NotFoundError{passkeyceremony.Label}
+	default:
+		return nil
+	}
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcdo *PasskeyCeremonyDeleteOne) ExecX(ctx context.Context) {
+	if err := pcdo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
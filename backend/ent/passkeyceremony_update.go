@@ -0,0 +1,175 @@
+// Code generated by ent, DO NOT EDIT.
+
+package ent
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"poll_app/ent/passkeyceremony"
+	"poll_app/ent/predicate"
+
+	"entgo.io/ent/dialect/sql"
+	"entgo.io/ent/dialect/sql/sqlgraph"
+	"entgo.io/ent/schema/field"
+)
+
+// PasskeyCeremonyUpdate is the builder for updating PasskeyCeremony entities.
+type PasskeyCeremonyUpdate struct {
+	config
+	hooks    []Hook
+	mutation *PasskeyCeremonyMutation
+}
+
+// Where appends a list predicates to the PasskeyCeremonyUpdate builder.
+func (pcu *PasskeyCeremonyUpdate) Where(ps ...predicate.PasskeyCeremony) *PasskeyCeremonyUpdate {
+	pcu.mutation.Where(ps...)
+	return pcu
+}
+
+// Mutation returns the PasskeyCeremonyMutation object of the builder.
+func (pcu *PasskeyCeremonyUpdate) Mutation() *PasskeyCeremonyMutation {
+	return pcu.mutation
+}
+
+// Save executes the query and returns the number of nodes affected by the update operation.
+func (pcu *PasskeyCeremonyUpdate) Save(ctx context.Context) (int, error) {
+	return withHooks(ctx, pcu.sqlSave, pcu.mutation, pcu.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pcu *PasskeyCeremonyUpdate) SaveX(ctx context.Context) int {
+	affected, err := pcu.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return affected
+}
+
+// Exec executes the query.
+func (pcu *PasskeyCeremonyUpdate) Exec(ctx context.Context) error {
+	_, err := pcu.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcu *PasskeyCeremonyUpdate) ExecX(ctx context.Context) {
+	if err := pcu.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pcu *PasskeyCeremonyUpdate) sqlSave(ctx context.Context) (n int, err error) {
+	_spec := sqlgraph.NewUpdateSpec(passkeyceremony.Table, passkeyceremony.Columns, sqlgraph.NewFieldSpec(passkeyceremony.FieldID, field.TypeInt))
+	if ps := pcu.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	if n, err = sqlgraph.UpdateNodes(ctx, pcu.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passkeyceremony.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return 0, err
+	}
+	pcu.mutation.done = true
+	return n, nil
+}
+
+// PasskeyCeremonyUpdateOne is the builder for updating a single PasskeyCeremony entity.
+type PasskeyCeremonyUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PasskeyCeremonyMutation
+}
+
+// Mutation returns the PasskeyCeremonyMutation object of the builder.
+func (pcuo *PasskeyCeremonyUpdateOne) Mutation() *PasskeyCeremonyMutation {
+	return pcuo.mutation
+}
+
+// Where appends a list predicates to the PasskeyCeremonyUpdate builder.
+func (pcuo *PasskeyCeremonyUpdateOne) Where(ps ...predicate.PasskeyCeremony) *PasskeyCeremonyUpdateOne {
+	pcuo.mutation.Where(ps...)
+	return pcuo
+}
+
+// Select allows selecting one or more fields (columns) of the returned entity.
+// The default is selecting all fields defined in the entity schema.
+func (pcuo *PasskeyCeremonyUpdateOne) Select(field string, fields ...string) *PasskeyCeremonyUpdateOne {
+	pcuo.fields = append([]string{field}, fields...)
+	return pcuo
+}
+
+// Save executes the query and returns the updated PasskeyCeremony entity.
+func (pcuo *PasskeyCeremonyUpdateOne) Save(ctx context.Context) (*PasskeyCeremony, error) {
+	return withHooks(ctx, pcuo.sqlSave, pcuo.mutation, pcuo.hooks)
+}
+
+// SaveX is like Save, but panics if an error occurs.
+func (pcuo *PasskeyCeremonyUpdateOne) SaveX(ctx context.Context) *PasskeyCeremony {
+	node, err := pcuo.Save(ctx)
+	if err != nil {
+		panic(err)
+	}
+	return node
+}
+
+// Exec executes the query on the entity.
+func (pcuo *PasskeyCeremonyUpdateOne) Exec(ctx context.Context) error {
+	_, err := pcuo.Save(ctx)
+	return err
+}
+
+// ExecX is like Exec, but panics if an error occurs.
+func (pcuo *PasskeyCeremonyUpdateOne) ExecX(ctx context.Context) {
+	if err := pcuo.Exec(ctx); err != nil {
+		panic(err)
+	}
+}
+
+func (pcuo *PasskeyCeremonyUpdateOne) sqlSave(ctx context.Context) (_node *PasskeyCeremony, err error) {
+	_spec := sqlgraph.NewUpdateSpec(passkeyceremony.Table, passkeyceremony.Columns, sqlgraph.NewFieldSpec(passkeyceremony.FieldID, field.TypeInt))
+	id, ok := pcuo.mutation.ID()
+	if !ok {
+		return nil, &ValidationError{Name: "id", err: errors.New(`ent: missing "PasskeyCeremony.id" for update`)}
+	}
+	_spec.Node.ID.Value = id
+	if fields := pcuo.fields; len(fields) > 0 {
+		_spec.Node.Columns = make([]string, 0, len(fields))
+		_spec.Node.Columns = append(_spec.Node.Columns, passkeyceremony.FieldID)
+		for _, f := range fields {
+			if !passkeyceremony.ValidColumn(f) {
+				return nil, &ValidationError{Name: f, err: fmt.Errorf("ent: invalid field %q for query", f)}
+			}
+			if f != passkeyceremony.FieldID {
+				_spec.Node.Columns = append(_spec.Node.Columns, f)
+			}
+		}
+	}
+	if ps := pcuo.mutation.predicates; len(ps) > 0 {
+		_spec.Predicate = func(selector *sql.Selector) {
+			for i := range ps {
+				ps[i](selector)
+			}
+		}
+	}
+	_node = &PasskeyCeremony{config: pcuo.config}
+	_spec.Assign = _node.assignValues
+	_spec.ScanValues = _node.scanValues
+	if err = sqlgraph.UpdateNode(ctx, pcuo.driver, _spec); err != nil {
+		if _, ok := err.(*sqlgraph.NotFoundError); ok {
+			err = &NotFoundError{passkeyceremony.Label}
+		} else if sqlgraph.IsConstraintError(err) {
+			err = &ConstraintError{msg: err.Error(), wrap: err}
+		}
+		return nil, err
+	}
+	pcuo.mutation.done = true
+	return _node, nil
+}
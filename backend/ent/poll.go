@@ -5,6 +5,7 @@ package ent
 import (
 	"fmt"
 	"poll_app/ent/poll"
+	"poll_app/ent/team"
 	"poll_app/ent/user"
 	"strings"
 	"time"
@@ -26,6 +27,30 @@ type Poll struct {
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// UpdatedAt holds the value of the "updated_at" field.
 	UpdatedAt time.Time `json:"updated_at,omitempty"`
+	// OpensAt holds the value of the "opens_at" field.
+	OpensAt *time.Time `json:"opens_at,omitempty"`
+	// ClosesAt holds the value of the "closes_at" field.
+	ClosesAt *time.Time `json:"closes_at,omitempty"`
+	// BallotType holds the value of the "ballot_type" field.
+	BallotType poll.BallotType `json:"ballot_type,omitempty"`
+	// MinChoices holds the value of the "min_choices" field.
+	MinChoices int `json:"min_choices,omitempty"`
+	// MaxChoices holds the value of the "max_choices" field.
+	MaxChoices int `json:"max_choices,omitempty"`
+	// ScoreMin holds the value of the "score_min" field.
+	ScoreMin int `json:"score_min,omitempty"`
+	// ScoreMax holds the value of the "score_max" field.
+	ScoreMax int `json:"score_max,omitempty"`
+	// Anonymous holds the value of the "anonymous" field.
+	Anonymous bool `json:"anonymous,omitempty"`
+	// Visibility holds the value of the "visibility" field.
+	Visibility poll.Visibility `json:"visibility,omitempty"`
+	// ResultsVisibility holds the value of the "results_visibility" field.
+	ResultsVisibility poll.ResultsVisibility `json:"results_visibility,omitempty"`
+	// TeamID holds the value of the "team_id" field.
+	TeamID *int `json:"team_id,omitempty"`
+	// LinkKey holds the value of the "link_key" field.
+	LinkKey *string `json:"-"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the PollQuery when eager-loading is set.
 	Edges        PollEdges `json:"edges"`
@@ -39,9 +64,17 @@ type PollEdges struct {
 	Creator *User `json:"creator,omitempty"`
 	// Options holds the value of the options edge.
 	Options []*PollOption `json:"options,omitempty"`
+	// Invitees holds the value of the invitees edge.
+	Invitees []*User `json:"invitees,omitempty"`
+	// Participants holds the value of the participants edge.
+	Participants []*User `json:"participants,omitempty"`
+	// Invites holds the value of the invites edge.
+	Invites []*Invite `json:"invites,omitempty"`
+	// Team holds the value of the team edge.
+	Team *Team `json:"team,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [2]bool
+	loadedTypes [6]bool
 }
 
 // CreatorOrErr returns the Creator value or an error if the edge
@@ -64,16 +97,56 @@ func (e PollEdges) OptionsOrErr() ([]*PollOption, error) {
 	return nil, &NotLoadedError{edge: "options"}
 }
 
+// InviteesOrErr returns the Invitees value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) InviteesOrErr() ([]*User, error) {
+	if e.loadedTypes[2] {
+		return e.Invitees, nil
+	}
+	return nil, &NotLoadedError{edge: "invitees"}
+}
+
+// ParticipantsOrErr returns the Participants value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) ParticipantsOrErr() ([]*User, error) {
+	if e.loadedTypes[3] {
+		return e.Participants, nil
+	}
+	return nil, &NotLoadedError{edge: "participants"}
+}
+
+// InvitesOrErr returns the Invites value or an error if the edge
+// was not loaded in eager-loading.
+func (e PollEdges) InvitesOrErr() ([]*Invite, error) {
+	if e.loadedTypes[4] {
+		return e.Invites, nil
+	}
+	return nil, &NotLoadedError{edge: "invites"}
+}
+
+// TeamOrErr returns the Team value or an error if the edge
+// was not loaded in eager-loading, or loaded but was not found.
+func (e PollEdges) TeamOrErr() (*Team, error) {
+	if e.Team != nil {
+		return e.Team, nil
+	} else if e.loadedTypes[5] {
+		return nil, &NotFoundError{label: team.Label}
+	}
+	return nil, &NotLoadedError{edge: "team"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*Poll) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case poll.FieldID:
+		case poll.FieldAnonymous:
+			values[i] = new(sql.NullBool)
+		case poll.FieldID, poll.FieldMinChoices, poll.FieldMaxChoices, poll.FieldScoreMin, poll.FieldScoreMax, poll.FieldTeamID:
 			values[i] = new(sql.NullInt64)
-		case poll.FieldTitle, poll.FieldDescription:
+		case poll.FieldTitle, poll.FieldDescription, poll.FieldBallotType, poll.FieldVisibility, poll.FieldResultsVisibility, poll.FieldLinkKey:
 			values[i] = new(sql.NullString)
-		case poll.FieldCreatedAt, poll.FieldUpdatedAt:
+		case poll.FieldCreatedAt, poll.FieldUpdatedAt, poll.FieldOpensAt, poll.FieldClosesAt:
 			values[i] = new(sql.NullTime)
 		case poll.ForeignKeys[0]: // user_polls
 			values[i] = new(sql.NullInt64)
@@ -122,6 +195,82 @@ func (po *Poll) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				po.UpdatedAt = value.Time
 			}
+		case poll.FieldOpensAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field opens_at", values[i])
+			} else if value.Valid {
+				po.OpensAt = new(time.Time)
+				*po.OpensAt = value.Time
+			}
+		case poll.FieldClosesAt:
+			if value, ok := values[i].(*sql.NullTime); !ok {
+				return fmt.Errorf("unexpected type %T for field closes_at", values[i])
+			} else if value.Valid {
+				po.ClosesAt = new(time.Time)
+				*po.ClosesAt = value.Time
+			}
+		case poll.FieldBallotType:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field ballot_type", values[i])
+			} else if value.Valid {
+				po.BallotType = poll.BallotType(value.String)
+			}
+		case poll.FieldMinChoices:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field min_choices", values[i])
+			} else if value.Valid {
+				po.MinChoices = int(value.Int64)
+			}
+		case poll.FieldMaxChoices:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field max_choices", values[i])
+			} else if value.Valid {
+				po.MaxChoices = int(value.Int64)
+			}
+		case poll.FieldScoreMin:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field score_min", values[i])
+			} else if value.Valid {
+				po.ScoreMin = int(value.Int64)
+			}
+		case poll.FieldScoreMax:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field score_max", values[i])
+			} else if value.Valid {
+				po.ScoreMax = int(value.Int64)
+			}
+		case poll.FieldAnonymous:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field anonymous", values[i])
+			} else if value.Valid {
+				po.Anonymous = value.Bool
+			}
+		case poll.FieldVisibility:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field visibility", values[i])
+			} else if value.Valid {
+				po.Visibility = poll.Visibility(value.String)
+			}
+		case poll.FieldResultsVisibility:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field results_visibility", values[i])
+			} else if value.Valid {
+				po.ResultsVisibility = poll.ResultsVisibility(value.String)
+			}
+		case poll.FieldTeamID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field team_id", values[i])
+			} else if value.Valid {
+				po.TeamID = new(int)
+				*po.TeamID = int(value.Int64)
+			}
+		case poll.FieldLinkKey:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field link_key", values[i])
+			} else if value.Valid {
+				po.LinkKey = new(string)
+				*po.LinkKey = value.String
+			}
 		case poll.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field user_polls", value)
@@ -152,6 +301,26 @@ func (po *Poll) QueryOptions() *PollOptionQuery {
 	return NewPollClient(po.config).QueryOptions(po)
 }
 
+// QueryInvitees queries the "invitees" edge of the Poll entity.
+func (po *Poll) QueryInvitees() *UserQuery {
+	return NewPollClient(po.config).QueryInvitees(po)
+}
+
+// QueryParticipants queries the "participants" edge of the Poll entity.
+func (po *Poll) QueryParticipants() *UserQuery {
+	return NewPollClient(po.config).QueryParticipants(po)
+}
+
+// QueryInvites queries the "invites" edge of the Poll entity.
+func (po *Poll) QueryInvites() *InviteQuery {
+	return NewPollClient(po.config).QueryInvites(po)
+}
+
+// QueryTeam queries the "team" edge of the Poll entity.
+func (po *Poll) QueryTeam() *TeamQuery {
+	return NewPollClient(po.config).QueryTeam(po)
+}
+
 // Update returns a builder for updating this Poll.
 // Note that you need to call Poll.Unwrap() before calling this method if this Poll
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -186,6 +355,47 @@ func (po *Poll) String() string {
 	builder.WriteString(", ")
 	builder.WriteString("updated_at=")
 	builder.WriteString(po.UpdatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	if v := po.OpensAt; v != nil {
+		builder.WriteString("opens_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	if v := po.ClosesAt; v != nil {
+		builder.WriteString("closes_at=")
+		builder.WriteString(v.Format(time.ANSIC))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("ballot_type=")
+	builder.WriteString(fmt.Sprintf("%v", po.BallotType))
+	builder.WriteString(", ")
+	builder.WriteString("min_choices=")
+	builder.WriteString(fmt.Sprintf("%v", po.MinChoices))
+	builder.WriteString(", ")
+	builder.WriteString("max_choices=")
+	builder.WriteString(fmt.Sprintf("%v", po.MaxChoices))
+	builder.WriteString(", ")
+	builder.WriteString("score_min=")
+	builder.WriteString(fmt.Sprintf("%v", po.ScoreMin))
+	builder.WriteString(", ")
+	builder.WriteString("score_max=")
+	builder.WriteString(fmt.Sprintf("%v", po.ScoreMax))
+	builder.WriteString(", ")
+	builder.WriteString("anonymous=")
+	builder.WriteString(fmt.Sprintf("%v", po.Anonymous))
+	builder.WriteString(", ")
+	builder.WriteString("visibility=")
+	builder.WriteString(fmt.Sprintf("%v", po.Visibility))
+	builder.WriteString(", ")
+	builder.WriteString("results_visibility=")
+	builder.WriteString(fmt.Sprintf("%v", po.ResultsVisibility))
+	builder.WriteString(", ")
+	if v := po.TeamID; v != nil {
+		builder.WriteString("team_id=")
+		builder.WriteString(fmt.Sprintf("%v", *v))
+	}
+	builder.WriteString(", ")
+	builder.WriteString("link_key=<sensitive>")
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -3,8 +3,10 @@
 package poll
 
 import (
+	"fmt"
 	"time"
 
+	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 )
@@ -22,10 +24,42 @@ const (
 	FieldCreatedAt = "created_at"
 	// FieldUpdatedAt holds the string denoting the updated_at field in the database.
 	FieldUpdatedAt = "updated_at"
+	// FieldOpensAt holds the string denoting the opens_at field in the database.
+	FieldOpensAt = "opens_at"
+	// FieldClosesAt holds the string denoting the closes_at field in the database.
+	FieldClosesAt = "closes_at"
+	// FieldBallotType holds the string denoting the ballot_type field in the database.
+	FieldBallotType = "ballot_type"
+	// FieldMinChoices holds the string denoting the min_choices field in the database.
+	FieldMinChoices = "min_choices"
+	// FieldMaxChoices holds the string denoting the max_choices field in the database.
+	FieldMaxChoices = "max_choices"
+	// FieldScoreMin holds the string denoting the score_min field in the database.
+	FieldScoreMin = "score_min"
+	// FieldScoreMax holds the string denoting the score_max field in the database.
+	FieldScoreMax = "score_max"
+	// FieldAnonymous holds the string denoting the anonymous field in the database.
+	FieldAnonymous = "anonymous"
+	// FieldVisibility holds the string denoting the visibility field in the database.
+	FieldVisibility = "visibility"
+	// FieldResultsVisibility holds the string denoting the results_visibility field in the database.
+	FieldResultsVisibility = "results_visibility"
+	// FieldTeamID holds the string denoting the team_id field in the database.
+	FieldTeamID = "team_id"
+	// FieldLinkKey holds the string denoting the link_key field in the database.
+	FieldLinkKey = "link_key"
 	// EdgeCreator holds the string denoting the creator edge name in mutations.
 	EdgeCreator = "creator"
 	// EdgeOptions holds the string denoting the options edge name in mutations.
 	EdgeOptions = "options"
+	// EdgeInvitees holds the string denoting the invitees edge name in mutations.
+	EdgeInvitees = "invitees"
+	// EdgeParticipants holds the string denoting the participants edge name in mutations.
+	EdgeParticipants = "participants"
+	// EdgeInvites holds the string denoting the invites edge name in mutations.
+	EdgeInvites = "invites"
+	// EdgeTeam holds the string denoting the team edge name in mutations.
+	EdgeTeam = "team"
 	// Table holds the table name of the poll in the database.
 	Table = "polls"
 	// CreatorTable is the table that holds the creator relation/edge.
@@ -42,6 +76,30 @@ const (
 	OptionsInverseTable = "poll_options"
 	// OptionsColumn is the table column denoting the options relation/edge.
 	OptionsColumn = "poll_options"
+	// InviteesTable is the table that holds the invitees relation/edge. The primary key declared below.
+	InviteesTable = "poll_invitees"
+	// InviteesInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	InviteesInverseTable = "users"
+	// ParticipantsTable is the table that holds the participants relation/edge. The primary key declared below.
+	ParticipantsTable = "poll_participants"
+	// ParticipantsInverseTable is the table name for the User entity.
+	// It exists in this package in order to avoid circular dependency with the "user" package.
+	ParticipantsInverseTable = "users"
+	// InvitesTable is the table that holds the invites relation/edge.
+	InvitesTable = "invites"
+	// InvitesInverseTable is the table name for the Invite entity.
+	// It exists in this package in order to avoid circular dependency with the "invite" package.
+	InvitesInverseTable = "invites"
+	// InvitesColumn is the table column denoting the invites relation/edge.
+	InvitesColumn = "poll_invites"
+	// TeamTable is the table that holds the team relation/edge.
+	TeamTable = "polls"
+	// TeamInverseTable is the table name for the Team entity.
+	// It exists in this package in order to avoid circular dependency with the "team" package.
+	TeamInverseTable = "teams"
+	// TeamColumn is the table column denoting the team relation/edge.
+	TeamColumn = "team_id"
 )
 
 // Columns holds all SQL columns for poll fields.
@@ -51,6 +109,18 @@ var Columns = []string{
 	FieldDescription,
 	FieldCreatedAt,
 	FieldUpdatedAt,
+	FieldOpensAt,
+	FieldClosesAt,
+	FieldBallotType,
+	FieldMinChoices,
+	FieldMaxChoices,
+	FieldScoreMin,
+	FieldScoreMax,
+	FieldAnonymous,
+	FieldVisibility,
+	FieldResultsVisibility,
+	FieldTeamID,
+	FieldLinkKey,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "polls"
@@ -59,6 +129,15 @@ var ForeignKeys = []string{
 	"user_polls",
 }
 
+var (
+	// InviteesPrimaryKey and InviteesColumn2 are the table columns denoting the
+	// primary key for the invitees relation (M2M).
+	InviteesPrimaryKey = []string{"poll_id", "user_id"}
+	// ParticipantsPrimaryKey and ParticipantsColumn2 are the table columns denoting the
+	// primary key for the participants relation (M2M).
+	ParticipantsPrimaryKey = []string{"poll_id", "user_id"}
+)
+
 // ValidColumn reports if the column name is valid (part of the table columns).
 func ValidColumn(column string) bool {
 	for i := range Columns {
@@ -74,7 +153,14 @@ func ValidColumn(column string) bool {
 	return false
 }
 
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "poll_app/ent/runtime"
 var (
+	Hooks  [1]ent.Hook
+	Policy ent.Policy
 	// TitleValidator is a validator for the "title" field. It is called by the builders before save.
 	TitleValidator func(string) error
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
@@ -83,8 +169,112 @@ var (
 	DefaultUpdatedAt func() time.Time
 	// UpdateDefaultUpdatedAt holds the default value on update for the "updated_at" field.
 	UpdateDefaultUpdatedAt func() time.Time
+	// DefaultMinChoices holds the default value on creation for the "min_choices" field.
+	DefaultMinChoices int
+	// MinChoicesValidator is a validator for the "min_choices" field. It is called by the builders before save.
+	MinChoicesValidator func(int) error
+	// DefaultMaxChoices holds the default value on creation for the "max_choices" field.
+	DefaultMaxChoices int
+	// MaxChoicesValidator is a validator for the "max_choices" field. It is called by the builders before save.
+	MaxChoicesValidator func(int) error
+	// DefaultScoreMin holds the default value on creation for the "score_min" field.
+	DefaultScoreMin int
+	// ScoreMinValidator is a validator for the "score_min" field. It is called by the builders before save.
+	ScoreMinValidator func(int) error
+	// DefaultScoreMax holds the default value on creation for the "score_max" field.
+	DefaultScoreMax int
+	// ScoreMaxValidator is a validator for the "score_max" field. It is called by the builders before save.
+	ScoreMaxValidator func(int) error
+	// DefaultAnonymous holds the default value on creation for the "anonymous" field.
+	DefaultAnonymous bool
+	// DefaultLinkKey holds the default value on creation for the "link_key" field.
+	DefaultLinkKey func() string
+)
+
+// BallotType defines the type for the "ballot_type" enum field.
+type BallotType string
+
+// BallotTypeSingle is the default value of the BallotType enum.
+const DefaultBallotType = BallotTypeSingle
+
+// BallotType values.
+const (
+	BallotTypeSingle   BallotType = "single"
+	BallotTypeMultiple BallotType = "multiple"
+	BallotTypeRanked   BallotType = "ranked"
+	BallotTypeScore    BallotType = "score"
+)
+
+func (bt BallotType) String() string {
+	return string(bt)
+}
+
+// BallotTypeValidator is a validator for the "ballot_type" field enum values. It is called by the builders before save.
+func BallotTypeValidator(bt BallotType) error {
+	switch bt {
+	case BallotTypeSingle, BallotTypeMultiple, BallotTypeRanked, BallotTypeScore:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for ballot_type field: %q", bt)
+	}
+}
+
+// Visibility defines the type for the "visibility" enum field.
+type Visibility string
+
+// VisibilityPublic is the default value of the Visibility enum.
+const DefaultVisibility = VisibilityPublic
+
+// Visibility values.
+const (
+	VisibilityPublic   Visibility = "public"
+	VisibilityUnlisted Visibility = "unlisted"
+	VisibilityPrivate  Visibility = "private"
+	VisibilityTeam     Visibility = "team"
+)
+
+func (v Visibility) String() string {
+	return string(v)
+}
+
+// VisibilityValidator is a validator for the "visibility" field enum values. It is called by the builders before save.
+func VisibilityValidator(v Visibility) error {
+	switch v {
+	case VisibilityPublic, VisibilityUnlisted, VisibilityPrivate, VisibilityTeam:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for visibility field: %q", v)
+	}
+}
+
+// ResultsVisibility defines the type for the "results_visibility" enum field.
+type ResultsVisibility string
+
+// ResultsVisibilityAlways is the default value of the ResultsVisibility enum.
+const DefaultResultsVisibility = ResultsVisibilityAlways
+
+// ResultsVisibility values.
+const (
+	ResultsVisibilityAlways      ResultsVisibility = "always"
+	ResultsVisibilityAfterVote   ResultsVisibility = "after_vote"
+	ResultsVisibilityAfterClose  ResultsVisibility = "after_close"
+	ResultsVisibilityCreatorOnly ResultsVisibility = "creator_only"
 )
 
+func (rv ResultsVisibility) String() string {
+	return string(rv)
+}
+
+// ResultsVisibilityValidator is a validator for the "results_visibility" field enum values. It is called by the builders before save.
+func ResultsVisibilityValidator(rv ResultsVisibility) error {
+	switch rv {
+	case ResultsVisibilityAlways, ResultsVisibilityAfterVote, ResultsVisibilityAfterClose, ResultsVisibilityCreatorOnly:
+		return nil
+	default:
+		return fmt.Errorf("poll: invalid enum value for results_visibility field: %q", rv)
+	}
+}
+
 // OrderOption defines the ordering options for the Poll queries.
 type OrderOption func(*sql.Selector)
 
@@ -113,6 +303,66 @@ func ByUpdatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldUpdatedAt, opts...).ToFunc()
 }
 
+// ByOpensAt orders the results by the opens_at field.
+func ByOpensAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOpensAt, opts...).ToFunc()
+}
+
+// ByClosesAt orders the results by the closes_at field.
+func ByClosesAt(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldClosesAt, opts...).ToFunc()
+}
+
+// ByBallotType orders the results by the ballot_type field.
+func ByBallotType(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldBallotType, opts...).ToFunc()
+}
+
+// ByMinChoices orders the results by the min_choices field.
+func ByMinChoices(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMinChoices, opts...).ToFunc()
+}
+
+// ByMaxChoices orders the results by the max_choices field.
+func ByMaxChoices(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldMaxChoices, opts...).ToFunc()
+}
+
+// ByScoreMin orders the results by the score_min field.
+func ByScoreMin(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScoreMin, opts...).ToFunc()
+}
+
+// ByScoreMax orders the results by the score_max field.
+func ByScoreMax(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScoreMax, opts...).ToFunc()
+}
+
+// ByAnonymous orders the results by the anonymous field.
+func ByAnonymous(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldAnonymous, opts...).ToFunc()
+}
+
+// ByVisibility orders the results by the visibility field.
+func ByVisibility(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVisibility, opts...).ToFunc()
+}
+
+// ByResultsVisibility orders the results by the results_visibility field.
+func ByResultsVisibility(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldResultsVisibility, opts...).ToFunc()
+}
+
+// ByTeamID orders the results by the team_id field.
+func ByTeamID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldTeamID, opts...).ToFunc()
+}
+
+// ByLinkKey orders the results by the link_key field.
+func ByLinkKey(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldLinkKey, opts...).ToFunc()
+}
+
 // ByCreatorField orders the results by creator field.
 func ByCreatorField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
@@ -133,6 +383,55 @@ func ByOptions(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
 		sqlgraph.OrderByNeighborTerms(s, newOptionsStep(), append([]sql.OrderTerm{term}, terms...)...)
 	}
 }
+
+// ByInviteesCount orders the results by invitees count.
+func ByInviteesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newInviteesStep(), opts...)
+	}
+}
+
+// ByInvitees orders the results by invitees terms.
+func ByInvitees(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newInviteesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByParticipantsCount orders the results by participants count.
+func ByParticipantsCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newParticipantsStep(), opts...)
+	}
+}
+
+// ByParticipants orders the results by participants terms.
+func ByParticipants(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newParticipantsStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByInvitesCount orders the results by invites count.
+func ByInvitesCount(opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborsCount(s, newInvitesStep(), opts...)
+	}
+}
+
+// ByInvites orders the results by invites terms.
+func ByInvites(term sql.OrderTerm, terms ...sql.OrderTerm) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newInvitesStep(), append([]sql.OrderTerm{term}, terms...)...)
+	}
+}
+
+// ByTeamField orders the results by team field.
+func ByTeamField(field string, opts ...sql.OrderTermOption) OrderOption {
+	return func(s *sql.Selector) {
+		sqlgraph.OrderByNeighborTerms(s, newTeamStep(), sql.OrderByField(field, opts...))
+	}
+}
 func newCreatorStep() *sqlgraph.Step {
 	return sqlgraph.NewStep(
 		sqlgraph.From(Table, FieldID),
@@ -147,3 +446,31 @@ func newOptionsStep() *sqlgraph.Step {
 		sqlgraph.Edge(sqlgraph.O2M, false, OptionsTable, OptionsColumn),
 	)
 }
+func newInviteesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(InviteesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, InviteesTable, InviteesPrimaryKey...),
+	)
+}
+func newParticipantsStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(ParticipantsInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2M, false, ParticipantsTable, ParticipantsPrimaryKey...),
+	)
+}
+func newInvitesStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(InvitesInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.O2M, false, InvitesTable, InvitesColumn),
+	)
+}
+func newTeamStep() *sqlgraph.Step {
+	return sqlgraph.NewStep(
+		sqlgraph.From(Table, FieldID),
+		sqlgraph.To(TeamInverseTable, FieldID),
+		sqlgraph.Edge(sqlgraph.M2O, true, TeamTable, TeamColumn),
+	)
+}
@@ -75,6 +75,51 @@ func UpdatedAt(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldUpdatedAt, v))
 }
 
+// OpensAt applies equality check predicate on the "opens_at" field. It's identical to OpensAtEQ.
+func OpensAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// ClosesAt applies equality check predicate on the "closes_at" field. It's identical to ClosesAtEQ.
+func ClosesAt(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// MinChoices applies equality check predicate on the "min_choices" field. It's identical to MinChoicesEQ.
+func MinChoices(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMinChoices, v))
+}
+
+// MaxChoices applies equality check predicate on the "max_choices" field. It's identical to MaxChoicesEQ.
+func MaxChoices(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxChoices, v))
+}
+
+// ScoreMin applies equality check predicate on the "score_min" field. It's identical to ScoreMinEQ.
+func ScoreMin(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMin, v))
+}
+
+// ScoreMax applies equality check predicate on the "score_max" field. It's identical to ScoreMaxEQ.
+func ScoreMax(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMax, v))
+}
+
+// Anonymous applies equality check predicate on the "anonymous" field. It's identical to AnonymousEQ.
+func Anonymous(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAnonymous, v))
+}
+
+// TeamID applies equality check predicate on the "team_id" field. It's identical to TeamIDEQ.
+func TeamID(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldTeamID, v))
+}
+
+// LinkKey applies equality check predicate on the "link_key" field. It's identical to LinkKeyEQ.
+func LinkKey(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldLinkKey, v))
+}
+
 // TitleEQ applies the EQ predicate on the "title" field.
 func TitleEQ(v string) predicate.Poll {
 	return predicate.Poll(sql.FieldEQ(FieldTitle, v))
@@ -295,6 +340,441 @@ func UpdatedAtLTE(v time.Time) predicate.Poll {
 	return predicate.Poll(sql.FieldLTE(FieldUpdatedAt, v))
 }
 
+// OpensAtEQ applies the EQ predicate on the "opens_at" field.
+func OpensAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldOpensAt, v))
+}
+
+// OpensAtNEQ applies the NEQ predicate on the "opens_at" field.
+func OpensAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldOpensAt, v))
+}
+
+// OpensAtIn applies the In predicate on the "opens_at" field.
+func OpensAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldOpensAt, vs...))
+}
+
+// OpensAtNotIn applies the NotIn predicate on the "opens_at" field.
+func OpensAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldOpensAt, vs...))
+}
+
+// OpensAtGT applies the GT predicate on the "opens_at" field.
+func OpensAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldOpensAt, v))
+}
+
+// OpensAtGTE applies the GTE predicate on the "opens_at" field.
+func OpensAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldOpensAt, v))
+}
+
+// OpensAtLT applies the LT predicate on the "opens_at" field.
+func OpensAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldOpensAt, v))
+}
+
+// OpensAtLTE applies the LTE predicate on the "opens_at" field.
+func OpensAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldOpensAt, v))
+}
+
+// OpensAtIsNil applies the IsNil predicate on the "opens_at" field.
+func OpensAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldOpensAt))
+}
+
+// OpensAtNotNil applies the NotNil predicate on the "opens_at" field.
+func OpensAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldOpensAt))
+}
+
+// ClosesAtEQ applies the EQ predicate on the "closes_at" field.
+func ClosesAtEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldClosesAt, v))
+}
+
+// ClosesAtNEQ applies the NEQ predicate on the "closes_at" field.
+func ClosesAtNEQ(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldClosesAt, v))
+}
+
+// ClosesAtIn applies the In predicate on the "closes_at" field.
+func ClosesAtIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtNotIn applies the NotIn predicate on the "closes_at" field.
+func ClosesAtNotIn(vs ...time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldClosesAt, vs...))
+}
+
+// ClosesAtGT applies the GT predicate on the "closes_at" field.
+func ClosesAtGT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldClosesAt, v))
+}
+
+// ClosesAtGTE applies the GTE predicate on the "closes_at" field.
+func ClosesAtGTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldClosesAt, v))
+}
+
+// ClosesAtLT applies the LT predicate on the "closes_at" field.
+func ClosesAtLT(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldClosesAt, v))
+}
+
+// ClosesAtLTE applies the LTE predicate on the "closes_at" field.
+func ClosesAtLTE(v time.Time) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldClosesAt, v))
+}
+
+// ClosesAtIsNil applies the IsNil predicate on the "closes_at" field.
+func ClosesAtIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldClosesAt))
+}
+
+// ClosesAtNotNil applies the NotNil predicate on the "closes_at" field.
+func ClosesAtNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldClosesAt))
+}
+
+// BallotTypeEQ applies the EQ predicate on the "ballot_type" field.
+func BallotTypeEQ(v BallotType) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldBallotType, v))
+}
+
+// BallotTypeNEQ applies the NEQ predicate on the "ballot_type" field.
+func BallotTypeNEQ(v BallotType) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldBallotType, v))
+}
+
+// BallotTypeIn applies the In predicate on the "ballot_type" field.
+func BallotTypeIn(vs ...BallotType) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldBallotType, vs...))
+}
+
+// BallotTypeNotIn applies the NotIn predicate on the "ballot_type" field.
+func BallotTypeNotIn(vs ...BallotType) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldBallotType, vs...))
+}
+
+// MinChoicesEQ applies the EQ predicate on the "min_choices" field.
+func MinChoicesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMinChoices, v))
+}
+
+// MinChoicesNEQ applies the NEQ predicate on the "min_choices" field.
+func MinChoicesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMinChoices, v))
+}
+
+// MinChoicesIn applies the In predicate on the "min_choices" field.
+func MinChoicesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMinChoices, vs...))
+}
+
+// MinChoicesNotIn applies the NotIn predicate on the "min_choices" field.
+func MinChoicesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMinChoices, vs...))
+}
+
+// MinChoicesGT applies the GT predicate on the "min_choices" field.
+func MinChoicesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldMinChoices, v))
+}
+
+// MinChoicesGTE applies the GTE predicate on the "min_choices" field.
+func MinChoicesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldMinChoices, v))
+}
+
+// MinChoicesLT applies the LT predicate on the "min_choices" field.
+func MinChoicesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldMinChoices, v))
+}
+
+// MinChoicesLTE applies the LTE predicate on the "min_choices" field.
+func MinChoicesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldMinChoices, v))
+}
+
+// MaxChoicesEQ applies the EQ predicate on the "max_choices" field.
+func MaxChoicesEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldMaxChoices, v))
+}
+
+// MaxChoicesNEQ applies the NEQ predicate on the "max_choices" field.
+func MaxChoicesNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldMaxChoices, v))
+}
+
+// MaxChoicesIn applies the In predicate on the "max_choices" field.
+func MaxChoicesIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldMaxChoices, vs...))
+}
+
+// MaxChoicesNotIn applies the NotIn predicate on the "max_choices" field.
+func MaxChoicesNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldMaxChoices, vs...))
+}
+
+// MaxChoicesGT applies the GT predicate on the "max_choices" field.
+func MaxChoicesGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldMaxChoices, v))
+}
+
+// MaxChoicesGTE applies the GTE predicate on the "max_choices" field.
+func MaxChoicesGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldMaxChoices, v))
+}
+
+// MaxChoicesLT applies the LT predicate on the "max_choices" field.
+func MaxChoicesLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldMaxChoices, v))
+}
+
+// MaxChoicesLTE applies the LTE predicate on the "max_choices" field.
+func MaxChoicesLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldMaxChoices, v))
+}
+
+// ScoreMinEQ applies the EQ predicate on the "score_min" field.
+func ScoreMinEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMin, v))
+}
+
+// ScoreMinNEQ applies the NEQ predicate on the "score_min" field.
+func ScoreMinNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldScoreMin, v))
+}
+
+// ScoreMinIn applies the In predicate on the "score_min" field.
+func ScoreMinIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldScoreMin, vs...))
+}
+
+// ScoreMinNotIn applies the NotIn predicate on the "score_min" field.
+func ScoreMinNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldScoreMin, vs...))
+}
+
+// ScoreMinGT applies the GT predicate on the "score_min" field.
+func ScoreMinGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldScoreMin, v))
+}
+
+// ScoreMinGTE applies the GTE predicate on the "score_min" field.
+func ScoreMinGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldScoreMin, v))
+}
+
+// ScoreMinLT applies the LT predicate on the "score_min" field.
+func ScoreMinLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldScoreMin, v))
+}
+
+// ScoreMinLTE applies the LTE predicate on the "score_min" field.
+func ScoreMinLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldScoreMin, v))
+}
+
+// ScoreMaxEQ applies the EQ predicate on the "score_max" field.
+func ScoreMaxEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldScoreMax, v))
+}
+
+// ScoreMaxNEQ applies the NEQ predicate on the "score_max" field.
+func ScoreMaxNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldScoreMax, v))
+}
+
+// ScoreMaxIn applies the In predicate on the "score_max" field.
+func ScoreMaxIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldScoreMax, vs...))
+}
+
+// ScoreMaxNotIn applies the NotIn predicate on the "score_max" field.
+func ScoreMaxNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldScoreMax, vs...))
+}
+
+// ScoreMaxGT applies the GT predicate on the "score_max" field.
+func ScoreMaxGT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldScoreMax, v))
+}
+
+// ScoreMaxGTE applies the GTE predicate on the "score_max" field.
+func ScoreMaxGTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldScoreMax, v))
+}
+
+// ScoreMaxLT applies the LT predicate on the "score_max" field.
+func ScoreMaxLT(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldScoreMax, v))
+}
+
+// ScoreMaxLTE applies the LTE predicate on the "score_max" field.
+func ScoreMaxLTE(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldScoreMax, v))
+}
+
+// AnonymousEQ applies the EQ predicate on the "anonymous" field.
+func AnonymousEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldAnonymous, v))
+}
+
+// AnonymousNEQ applies the NEQ predicate on the "anonymous" field.
+func AnonymousNEQ(v bool) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldAnonymous, v))
+}
+
+// VisibilityEQ applies the EQ predicate on the "visibility" field.
+func VisibilityEQ(v Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldVisibility, v))
+}
+
+// VisibilityNEQ applies the NEQ predicate on the "visibility" field.
+func VisibilityNEQ(v Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldVisibility, v))
+}
+
+// VisibilityIn applies the In predicate on the "visibility" field.
+func VisibilityIn(vs ...Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldVisibility, vs...))
+}
+
+// VisibilityNotIn applies the NotIn predicate on the "visibility" field.
+func VisibilityNotIn(vs ...Visibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldVisibility, vs...))
+}
+
+// ResultsVisibilityEQ applies the EQ predicate on the "results_visibility" field.
+func ResultsVisibilityEQ(v ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityNEQ applies the NEQ predicate on the "results_visibility" field.
+func ResultsVisibilityNEQ(v ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldResultsVisibility, v))
+}
+
+// ResultsVisibilityIn applies the In predicate on the "results_visibility" field.
+func ResultsVisibilityIn(vs ...ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldResultsVisibility, vs...))
+}
+
+// ResultsVisibilityNotIn applies the NotIn predicate on the "results_visibility" field.
+func ResultsVisibilityNotIn(vs ...ResultsVisibility) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldResultsVisibility, vs...))
+}
+
+// TeamIDEQ applies the EQ predicate on the "team_id" field.
+func TeamIDEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldTeamID, v))
+}
+
+// TeamIDNEQ applies the NEQ predicate on the "team_id" field.
+func TeamIDNEQ(v int) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldTeamID, v))
+}
+
+// TeamIDIn applies the In predicate on the "team_id" field.
+func TeamIDIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldTeamID, vs...))
+}
+
+// TeamIDNotIn applies the NotIn predicate on the "team_id" field.
+func TeamIDNotIn(vs ...int) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldTeamID, vs...))
+}
+
+// TeamIDIsNil applies the IsNil predicate on the "team_id" field.
+func TeamIDIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldTeamID))
+}
+
+// TeamIDNotNil applies the NotNil predicate on the "team_id" field.
+func TeamIDNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldTeamID))
+}
+
+// LinkKeyEQ applies the EQ predicate on the "link_key" field.
+func LinkKeyEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEQ(FieldLinkKey, v))
+}
+
+// LinkKeyNEQ applies the NEQ predicate on the "link_key" field.
+func LinkKeyNEQ(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldNEQ(FieldLinkKey, v))
+}
+
+// LinkKeyIn applies the In predicate on the "link_key" field.
+func LinkKeyIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldIn(FieldLinkKey, vs...))
+}
+
+// LinkKeyNotIn applies the NotIn predicate on the "link_key" field.
+func LinkKeyNotIn(vs ...string) predicate.Poll {
+	return predicate.Poll(sql.FieldNotIn(FieldLinkKey, vs...))
+}
+
+// LinkKeyGT applies the GT predicate on the "link_key" field.
+func LinkKeyGT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGT(FieldLinkKey, v))
+}
+
+// LinkKeyGTE applies the GTE predicate on the "link_key" field.
+func LinkKeyGTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldGTE(FieldLinkKey, v))
+}
+
+// LinkKeyLT applies the LT predicate on the "link_key" field.
+func LinkKeyLT(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLT(FieldLinkKey, v))
+}
+
+// LinkKeyLTE applies the LTE predicate on the "link_key" field.
+func LinkKeyLTE(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldLTE(FieldLinkKey, v))
+}
+
+// LinkKeyContains applies the Contains predicate on the "link_key" field.
+func LinkKeyContains(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContains(FieldLinkKey, v))
+}
+
+// LinkKeyHasPrefix applies the HasPrefix predicate on the "link_key" field.
+func LinkKeyHasPrefix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasPrefix(FieldLinkKey, v))
+}
+
+// LinkKeyHasSuffix applies the HasSuffix predicate on the "link_key" field.
+func LinkKeyHasSuffix(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldHasSuffix(FieldLinkKey, v))
+}
+
+// LinkKeyIsNil applies the IsNil predicate on the "link_key" field.
+func LinkKeyIsNil() predicate.Poll {
+	return predicate.Poll(sql.FieldIsNull(FieldLinkKey))
+}
+
+// LinkKeyNotNil applies the NotNil predicate on the "link_key" field.
+func LinkKeyNotNil() predicate.Poll {
+	return predicate.Poll(sql.FieldNotNull(FieldLinkKey))
+}
+
+// LinkKeyEqualFold applies the EqualFold predicate on the "link_key" field.
+func LinkKeyEqualFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldEqualFold(FieldLinkKey, v))
+}
+
+// LinkKeyContainsFold applies the ContainsFold predicate on the "link_key" field.
+func LinkKeyContainsFold(v string) predicate.Poll {
+	return predicate.Poll(sql.FieldContainsFold(FieldLinkKey, v))
+}
+
 // HasCreator applies the HasEdge predicate on the "creator" edge.
 func HasCreator() predicate.Poll {
 	return predicate.Poll(func(s *sql.Selector) {
@@ -341,6 +821,98 @@ func HasOptionsWith(preds ...predicate.PollOption) predicate.Poll {
 	})
 }
 
+// HasInvitees applies the HasEdge predicate on the "invitees" edge.
+func HasInvitees() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, InviteesTable, InviteesPrimaryKey...),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasInviteesWith applies the HasEdge predicate on the "invitees" edge with a given conditions (other predicates).
+func HasInviteesWith(preds ...predicate.User) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newInviteesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasParticipants applies the HasEdge predicate on the "participants" edge.
+func HasParticipants() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, ParticipantsTable, ParticipantsPrimaryKey...),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasParticipantsWith applies the HasEdge predicate on the "participants" edge with a given conditions (other predicates).
+func HasParticipantsWith(preds ...predicate.User) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newParticipantsStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasInvites applies the HasEdge predicate on the "invites" edge.
+func HasInvites() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, InvitesTable, InvitesColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasInvitesWith applies the HasEdge predicate on the "invites" edge with a given conditions (other predicates).
+func HasInvitesWith(preds ...predicate.Invite) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newInvitesStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
+// HasTeam applies the HasEdge predicate on the "team" edge.
+func HasTeam() predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := sqlgraph.NewStep(
+			sqlgraph.From(Table, FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, TeamTable, TeamColumn),
+		)
+		sqlgraph.HasNeighbors(s, step)
+	})
+}
+
+// HasTeamWith applies the HasEdge predicate on the "team" edge with a given conditions (other predicates).
+func HasTeamWith(preds ...predicate.Team) predicate.Poll {
+	return predicate.Poll(func(s *sql.Selector) {
+		step := newTeamStep()
+		sqlgraph.HasNeighborsWith(s, step, func(s *sql.Selector) {
+			for _, p := range preds {
+				p(s)
+			}
+		})
+	})
+}
+
 // And groups predicates with the AND operator between them.
 func And(predicates ...predicate.Poll) predicate.Poll {
 	return predicate.Poll(sql.AndPredicates(predicates...))
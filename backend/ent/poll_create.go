@@ -6,8 +6,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"poll_app/ent/invite"
 	"poll_app/ent/poll"
 	"poll_app/ent/polloption"
+	"poll_app/ent/team"
 	"poll_app/ent/user"
 	"time"
 
@@ -70,6 +72,174 @@ func (pc *PollCreate) SetNillableUpdatedAt(t *time.Time) *PollCreate {
 	return pc
 }
 
+// SetOpensAt sets the "opens_at" field.
+func (pc *PollCreate) SetOpensAt(t time.Time) *PollCreate {
+	pc.mutation.SetOpensAt(t)
+	return pc
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (pc *PollCreate) SetNillableOpensAt(t *time.Time) *PollCreate {
+	if t != nil {
+		pc.SetOpensAt(*t)
+	}
+	return pc
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (pc *PollCreate) SetClosesAt(t time.Time) *PollCreate {
+	pc.mutation.SetClosesAt(t)
+	return pc
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (pc *PollCreate) SetNillableClosesAt(t *time.Time) *PollCreate {
+	if t != nil {
+		pc.SetClosesAt(*t)
+	}
+	return pc
+}
+
+// SetBallotType sets the "ballot_type" field.
+func (pc *PollCreate) SetBallotType(pt poll.BallotType) *PollCreate {
+	pc.mutation.SetBallotType(pt)
+	return pc
+}
+
+// SetNillableBallotType sets the "ballot_type" field if the given value is not nil.
+func (pc *PollCreate) SetNillableBallotType(pt *poll.BallotType) *PollCreate {
+	if pt != nil {
+		pc.SetBallotType(*pt)
+	}
+	return pc
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (pc *PollCreate) SetMinChoices(i int) *PollCreate {
+	pc.mutation.SetMinChoices(i)
+	return pc
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (pc *PollCreate) SetNillableMinChoices(i *int) *PollCreate {
+	if i != nil {
+		pc.SetMinChoices(*i)
+	}
+	return pc
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (pc *PollCreate) SetMaxChoices(i int) *PollCreate {
+	pc.mutation.SetMaxChoices(i)
+	return pc
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (pc *PollCreate) SetNillableMaxChoices(i *int) *PollCreate {
+	if i != nil {
+		pc.SetMaxChoices(*i)
+	}
+	return pc
+}
+
+// SetScoreMin sets the "score_min" field.
+func (pc *PollCreate) SetScoreMin(i int) *PollCreate {
+	pc.mutation.SetScoreMin(i)
+	return pc
+}
+
+// SetNillableScoreMin sets the "score_min" field if the given value is not nil.
+func (pc *PollCreate) SetNillableScoreMin(i *int) *PollCreate {
+	if i != nil {
+		pc.SetScoreMin(*i)
+	}
+	return pc
+}
+
+// SetScoreMax sets the "score_max" field.
+func (pc *PollCreate) SetScoreMax(i int) *PollCreate {
+	pc.mutation.SetScoreMax(i)
+	return pc
+}
+
+// SetNillableScoreMax sets the "score_max" field if the given value is not nil.
+func (pc *PollCreate) SetNillableScoreMax(i *int) *PollCreate {
+	if i != nil {
+		pc.SetScoreMax(*i)
+	}
+	return pc
+}
+
+// SetAnonymous sets the "anonymous" field.
+func (pc *PollCreate) SetAnonymous(b bool) *PollCreate {
+	pc.mutation.SetAnonymous(b)
+	return pc
+}
+
+// SetNillableAnonymous sets the "anonymous" field if the given value is not nil.
+func (pc *PollCreate) SetNillableAnonymous(b *bool) *PollCreate {
+	if b != nil {
+		pc.SetAnonymous(*b)
+	}
+	return pc
+}
+
+// SetVisibility sets the "visibility" field.
+func (pc *PollCreate) SetVisibility(po poll.Visibility) *PollCreate {
+	pc.mutation.SetVisibility(po)
+	return pc
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (pc *PollCreate) SetNillableVisibility(po *poll.Visibility) *PollCreate {
+	if po != nil {
+		pc.SetVisibility(*po)
+	}
+	return pc
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (pc *PollCreate) SetResultsVisibility(pv poll.ResultsVisibility) *PollCreate {
+	pc.mutation.SetResultsVisibility(pv)
+	return pc
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (pc *PollCreate) SetNillableResultsVisibility(pv *poll.ResultsVisibility) *PollCreate {
+	if pv != nil {
+		pc.SetResultsVisibility(*pv)
+	}
+	return pc
+}
+
+// SetTeamID sets the "team_id" field.
+func (pc *PollCreate) SetTeamID(i int) *PollCreate {
+	pc.mutation.SetTeamID(i)
+	return pc
+}
+
+// SetNillableTeamID sets the "team_id" field if the given value is not nil.
+func (pc *PollCreate) SetNillableTeamID(i *int) *PollCreate {
+	if i != nil {
+		pc.SetTeamID(*i)
+	}
+	return pc
+}
+
+// SetLinkKey sets the "link_key" field.
+func (pc *PollCreate) SetLinkKey(s string) *PollCreate {
+	pc.mutation.SetLinkKey(s)
+	return pc
+}
+
+// SetNillableLinkKey sets the "link_key" field if the given value is not nil.
+func (pc *PollCreate) SetNillableLinkKey(s *string) *PollCreate {
+	if s != nil {
+		pc.SetLinkKey(*s)
+	}
+	return pc
+}
+
 // SetCreatorID sets the "creator" edge to the User entity by ID.
 func (pc *PollCreate) SetCreatorID(id int) *PollCreate {
 	pc.mutation.SetCreatorID(id)
@@ -96,6 +266,56 @@ func (pc *PollCreate) AddOptions(p ...*PollOption) *PollCreate {
 	return pc.AddOptionIDs(ids...)
 }
 
+// AddInviteeIDs adds the "invitees" edge to the User entity by IDs.
+func (pc *PollCreate) AddInviteeIDs(ids ...int) *PollCreate {
+	pc.mutation.AddInviteeIDs(ids...)
+	return pc
+}
+
+// AddInvitees adds the "invitees" edges to the User entity.
+func (pc *PollCreate) AddInvitees(u ...*User) *PollCreate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pc.AddInviteeIDs(ids...)
+}
+
+// AddParticipantIDs adds the "participants" edge to the User entity by IDs.
+func (pc *PollCreate) AddParticipantIDs(ids ...int) *PollCreate {
+	pc.mutation.AddParticipantIDs(ids...)
+	return pc
+}
+
+// AddParticipants adds the "participants" edges to the User entity.
+func (pc *PollCreate) AddParticipants(u ...*User) *PollCreate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pc.AddParticipantIDs(ids...)
+}
+
+// AddInviteIDs adds the "invites" edge to the Invite entity by IDs.
+func (pc *PollCreate) AddInviteIDs(ids ...int) *PollCreate {
+	pc.mutation.AddInviteIDs(ids...)
+	return pc
+}
+
+// AddInvites adds the "invites" edges to the Invite entity.
+func (pc *PollCreate) AddInvites(i ...*Invite) *PollCreate {
+	ids := make([]int, len(i))
+	for j := range i {
+		ids[j] = i[j].ID
+	}
+	return pc.AddInviteIDs(ids...)
+}
+
+// SetTeam sets the "team" edge to the Team entity.
+func (pc *PollCreate) SetTeam(t *Team) *PollCreate {
+	return pc.SetTeamID(t.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (pc *PollCreate) Mutation() *PollMutation {
 	return pc.mutation
@@ -103,7 +323,9 @@ func (pc *PollCreate) Mutation() *PollMutation {
 
 // Save creates the Poll in the database.
 func (pc *PollCreate) Save(ctx context.Context) (*Poll, error) {
-	pc.defaults()
+	if err := pc.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, pc.sqlSave, pc.mutation, pc.hooks)
 }
 
@@ -130,15 +352,61 @@ func (pc *PollCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (pc *PollCreate) defaults() {
+func (pc *PollCreate) defaults() error {
 	if _, ok := pc.mutation.CreatedAt(); !ok {
+		if poll.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized poll.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
 		v := poll.DefaultCreatedAt()
 		pc.mutation.SetCreatedAt(v)
 	}
 	if _, ok := pc.mutation.UpdatedAt(); !ok {
+		if poll.DefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized poll.DefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := poll.DefaultUpdatedAt()
 		pc.mutation.SetUpdatedAt(v)
 	}
+	if _, ok := pc.mutation.BallotType(); !ok {
+		v := poll.DefaultBallotType
+		pc.mutation.SetBallotType(v)
+	}
+	if _, ok := pc.mutation.MinChoices(); !ok {
+		v := poll.DefaultMinChoices
+		pc.mutation.SetMinChoices(v)
+	}
+	if _, ok := pc.mutation.MaxChoices(); !ok {
+		v := poll.DefaultMaxChoices
+		pc.mutation.SetMaxChoices(v)
+	}
+	if _, ok := pc.mutation.ScoreMin(); !ok {
+		v := poll.DefaultScoreMin
+		pc.mutation.SetScoreMin(v)
+	}
+	if _, ok := pc.mutation.ScoreMax(); !ok {
+		v := poll.DefaultScoreMax
+		pc.mutation.SetScoreMax(v)
+	}
+	if _, ok := pc.mutation.Anonymous(); !ok {
+		v := poll.DefaultAnonymous
+		pc.mutation.SetAnonymous(v)
+	}
+	if _, ok := pc.mutation.Visibility(); !ok {
+		v := poll.DefaultVisibility
+		pc.mutation.SetVisibility(v)
+	}
+	if _, ok := pc.mutation.ResultsVisibility(); !ok {
+		v := poll.DefaultResultsVisibility
+		pc.mutation.SetResultsVisibility(v)
+	}
+	if _, ok := pc.mutation.LinkKey(); !ok {
+		if poll.DefaultLinkKey == nil {
+			return fmt.Errorf("ent: uninitialized poll.DefaultLinkKey (forgotten import ent/runtime?)")
+		}
+		v := poll.DefaultLinkKey()
+		pc.mutation.SetLinkKey(v)
+	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -157,6 +425,65 @@ func (pc *PollCreate) check() error {
 	if _, ok := pc.mutation.UpdatedAt(); !ok {
 		return &ValidationError{Name: "updated_at", err: errors.New(`ent: missing required field "Poll.updated_at"`)}
 	}
+	if _, ok := pc.mutation.BallotType(); !ok {
+		return &ValidationError{Name: "ballot_type", err: errors.New(`ent: missing required field "Poll.ballot_type"`)}
+	}
+	if v, ok := pc.mutation.BallotType(); ok {
+		if err := poll.BallotTypeValidator(v); err != nil {
+			return &ValidationError{Name: "ballot_type", err: fmt.Errorf(`ent: validator failed for field "Poll.ballot_type": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.MinChoices(); !ok {
+		return &ValidationError{Name: "min_choices", err: errors.New(`ent: missing required field "Poll.min_choices"`)}
+	}
+	if v, ok := pc.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.MaxChoices(); !ok {
+		return &ValidationError{Name: "max_choices", err: errors.New(`ent: missing required field "Poll.max_choices"`)}
+	}
+	if v, ok := pc.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.ScoreMin(); !ok {
+		return &ValidationError{Name: "score_min", err: errors.New(`ent: missing required field "Poll.score_min"`)}
+	}
+	if v, ok := pc.mutation.ScoreMin(); ok {
+		if err := poll.ScoreMinValidator(v); err != nil {
+			return &ValidationError{Name: "score_min", err: fmt.Errorf(`ent: validator failed for field "Poll.score_min": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.ScoreMax(); !ok {
+		return &ValidationError{Name: "score_max", err: errors.New(`ent: missing required field "Poll.score_max"`)}
+	}
+	if v, ok := pc.mutation.ScoreMax(); ok {
+		if err := poll.ScoreMaxValidator(v); err != nil {
+			return &ValidationError{Name: "score_max", err: fmt.Errorf(`ent: validator failed for field "Poll.score_max": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.Anonymous(); !ok {
+		return &ValidationError{Name: "anonymous", err: errors.New(`ent: missing required field "Poll.anonymous"`)}
+	}
+	if _, ok := pc.mutation.Visibility(); !ok {
+		return &ValidationError{Name: "visibility", err: errors.New(`ent: missing required field "Poll.visibility"`)}
+	}
+	if v, ok := pc.mutation.Visibility(); ok {
+		if err := poll.VisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.visibility": %w`, err)}
+		}
+	}
+	if _, ok := pc.mutation.ResultsVisibility(); !ok {
+		return &ValidationError{Name: "results_visibility", err: errors.New(`ent: missing required field "Poll.results_visibility"`)}
+	}
+	if v, ok := pc.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
 	if len(pc.mutation.CreatorIDs()) == 0 {
 		return &ValidationError{Name: "creator", err: errors.New(`ent: missing required edge "Poll.creator"`)}
 	}
@@ -202,6 +529,50 @@ func (pc *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		_spec.SetField(poll.FieldUpdatedAt, field.TypeTime, value)
 		_node.UpdatedAt = value
 	}
+	if value, ok := pc.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+		_node.OpensAt = &value
+	}
+	if value, ok := pc.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+		_node.ClosesAt = &value
+	}
+	if value, ok := pc.mutation.BallotType(); ok {
+		_spec.SetField(poll.FieldBallotType, field.TypeEnum, value)
+		_node.BallotType = value
+	}
+	if value, ok := pc.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+		_node.MinChoices = value
+	}
+	if value, ok := pc.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+		_node.MaxChoices = value
+	}
+	if value, ok := pc.mutation.ScoreMin(); ok {
+		_spec.SetField(poll.FieldScoreMin, field.TypeInt, value)
+		_node.ScoreMin = value
+	}
+	if value, ok := pc.mutation.ScoreMax(); ok {
+		_spec.SetField(poll.FieldScoreMax, field.TypeInt, value)
+		_node.ScoreMax = value
+	}
+	if value, ok := pc.mutation.Anonymous(); ok {
+		_spec.SetField(poll.FieldAnonymous, field.TypeBool, value)
+		_node.Anonymous = value
+	}
+	if value, ok := pc.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeEnum, value)
+		_node.Visibility = value
+	}
+	if value, ok := pc.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+		_node.ResultsVisibility = value
+	}
+	if value, ok := pc.mutation.LinkKey(); ok {
+		_spec.SetField(poll.FieldLinkKey, field.TypeString, value)
+		_node.LinkKey = &value
+	}
 	if nodes := pc.mutation.CreatorIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -235,6 +606,71 @@ func (pc *PollCreate) createSpec() (*Poll, *sqlgraph.CreateSpec) {
 		}
 		_spec.Edges = append(_spec.Edges, edge)
 	}
+	if nodes := pc.mutation.InviteesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InviteesTable,
+			Columns: poll.InviteesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := pc.mutation.ParticipantsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.ParticipantsTable,
+			Columns: poll.ParticipantsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := pc.mutation.InvitesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges = append(_spec.Edges, edge)
+	}
+	if nodes := pc.mutation.TeamIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TeamTable,
+			Columns: []string{poll.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_node.TeamID = &nodes[0]
+		_spec.Edges = append(_spec.Edges, edge)
+	}
 	return _node, _spec
 }
 
@@ -5,11 +5,14 @@ package ent
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"math"
+	"poll_app/ent/invite"
 	"poll_app/ent/poll"
 	"poll_app/ent/polloption"
 	"poll_app/ent/predicate"
+	"poll_app/ent/team"
 	"poll_app/ent/user"
 
 	"entgo.io/ent"
@@ -21,13 +24,17 @@ import (
 // PollQuery is the builder for querying Poll entities.
 type PollQuery struct {
 	config
-	ctx         *QueryContext
-	order       []poll.OrderOption
-	inters      []Interceptor
-	predicates  []predicate.Poll
-	withCreator *UserQuery
-	withOptions *PollOptionQuery
-	withFKs     bool
+	ctx              *QueryContext
+	order            []poll.OrderOption
+	inters           []Interceptor
+	predicates       []predicate.Poll
+	withCreator      *UserQuery
+	withOptions      *PollOptionQuery
+	withInvitees     *UserQuery
+	withParticipants *UserQuery
+	withInvites      *InviteQuery
+	withTeam         *TeamQuery
+	withFKs          bool
 	// intermediate query (i.e. traversal path).
 	sql  *sql.Selector
 	path func(context.Context) (*sql.Selector, error)
@@ -108,6 +115,94 @@ func (pq *PollQuery) QueryOptions() *PollOptionQuery {
 	return query
 }
 
+// QueryInvitees chains the current query on the "invitees" edge.
+func (pq *PollQuery) QueryInvitees() *UserQuery {
+	query := (&UserClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, poll.InviteesTable, poll.InviteesPrimaryKey...),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryParticipants chains the current query on the "participants" edge.
+func (pq *PollQuery) QueryParticipants() *UserQuery {
+	query := (&UserClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(user.Table, user.FieldID),
+			sqlgraph.Edge(sqlgraph.M2M, false, poll.ParticipantsTable, poll.ParticipantsPrimaryKey...),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryInvites chains the current query on the "invites" edge.
+func (pq *PollQuery) QueryInvites() *InviteQuery {
+	query := (&InviteClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(invite.Table, invite.FieldID),
+			sqlgraph.Edge(sqlgraph.O2M, false, poll.InvitesTable, poll.InvitesColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
+// QueryTeam chains the current query on the "team" edge.
+func (pq *PollQuery) QueryTeam() *TeamQuery {
+	query := (&TeamClient{config: pq.config}).Query()
+	query.path = func(ctx context.Context) (fromU *sql.Selector, err error) {
+		if err := pq.prepareQuery(ctx); err != nil {
+			return nil, err
+		}
+		selector := pq.sqlQuery(ctx)
+		if err := selector.Err(); err != nil {
+			return nil, err
+		}
+		step := sqlgraph.NewStep(
+			sqlgraph.From(poll.Table, poll.FieldID, selector),
+			sqlgraph.To(team.Table, team.FieldID),
+			sqlgraph.Edge(sqlgraph.M2O, true, poll.TeamTable, poll.TeamColumn),
+		)
+		fromU = sqlgraph.SetNeighbors(pq.driver.Dialect(), step)
+		return fromU, nil
+	}
+	return query
+}
+
 // First returns the first Poll entity from the query.
 // Returns a *NotFoundError when no Poll was found.
 func (pq *PollQuery) First(ctx context.Context) (*Poll, error) {
@@ -295,13 +390,17 @@ func (pq *PollQuery) Clone() *PollQuery {
 		return nil
 	}
 	return &PollQuery{
-		config:      pq.config,
-		ctx:         pq.ctx.Clone(),
-		order:       append([]poll.OrderOption{}, pq.order...),
-		inters:      append([]Interceptor{}, pq.inters...),
-		predicates:  append([]predicate.Poll{}, pq.predicates...),
-		withCreator: pq.withCreator.Clone(),
-		withOptions: pq.withOptions.Clone(),
+		config:           pq.config,
+		ctx:              pq.ctx.Clone(),
+		order:            append([]poll.OrderOption{}, pq.order...),
+		inters:           append([]Interceptor{}, pq.inters...),
+		predicates:       append([]predicate.Poll{}, pq.predicates...),
+		withCreator:      pq.withCreator.Clone(),
+		withOptions:      pq.withOptions.Clone(),
+		withInvitees:     pq.withInvitees.Clone(),
+		withParticipants: pq.withParticipants.Clone(),
+		withInvites:      pq.withInvites.Clone(),
+		withTeam:         pq.withTeam.Clone(),
 		// clone intermediate query.
 		sql:  pq.sql.Clone(),
 		path: pq.path,
@@ -330,6 +429,50 @@ func (pq *PollQuery) WithOptions(opts ...func(*PollOptionQuery)) *PollQuery {
 	return pq
 }
 
+// WithInvitees tells the query-builder to eager-load the nodes that are connected to
+// the "invitees" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PollQuery) WithInvitees(opts ...func(*UserQuery)) *PollQuery {
+	query := (&UserClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withInvitees = query
+	return pq
+}
+
+// WithParticipants tells the query-builder to eager-load the nodes that are connected to
+// the "participants" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PollQuery) WithParticipants(opts ...func(*UserQuery)) *PollQuery {
+	query := (&UserClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withParticipants = query
+	return pq
+}
+
+// WithInvites tells the query-builder to eager-load the nodes that are connected to
+// the "invites" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PollQuery) WithInvites(opts ...func(*InviteQuery)) *PollQuery {
+	query := (&InviteClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withInvites = query
+	return pq
+}
+
+// WithTeam tells the query-builder to eager-load the nodes that are connected to
+// the "team" edge. The optional arguments are used to configure the query builder of the edge.
+func (pq *PollQuery) WithTeam(opts ...func(*TeamQuery)) *PollQuery {
+	query := (&TeamClient{config: pq.config}).Query()
+	for _, opt := range opts {
+		opt(query)
+	}
+	pq.withTeam = query
+	return pq
+}
+
 // GroupBy is used to group vertices by one or more fields/columns.
 // It is often used with aggregate functions, like: count, max, mean, min, sum.
 //
@@ -401,6 +544,12 @@ func (pq *PollQuery) prepareQuery(ctx context.Context) error {
 		}
 		pq.sql = prev
 	}
+	if poll.Policy == nil {
+		return errors.New("ent: uninitialized poll.Policy (forgotten import ent/runtime?)")
+	}
+	if err := poll.Policy.EvalQuery(ctx, pq); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -409,9 +558,13 @@ func (pq *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 		nodes       = []*Poll{}
 		withFKs     = pq.withFKs
 		_spec       = pq.querySpec()
-		loadedTypes = [2]bool{
+		loadedTypes = [6]bool{
 			pq.withCreator != nil,
 			pq.withOptions != nil,
+			pq.withInvitees != nil,
+			pq.withParticipants != nil,
+			pq.withInvites != nil,
+			pq.withTeam != nil,
 		}
 	)
 	if pq.withCreator != nil {
@@ -451,6 +604,33 @@ func (pq *PollQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Poll, e
 			return nil, err
 		}
 	}
+	if query := pq.withInvitees; query != nil {
+		if err := pq.loadInvitees(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Invitees = []*User{} },
+			func(n *Poll, e *User) { n.Edges.Invitees = append(n.Edges.Invitees, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := pq.withParticipants; query != nil {
+		if err := pq.loadParticipants(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Participants = []*User{} },
+			func(n *Poll, e *User) { n.Edges.Participants = append(n.Edges.Participants, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := pq.withInvites; query != nil {
+		if err := pq.loadInvites(ctx, query, nodes,
+			func(n *Poll) { n.Edges.Invites = []*Invite{} },
+			func(n *Poll, e *Invite) { n.Edges.Invites = append(n.Edges.Invites, e) }); err != nil {
+			return nil, err
+		}
+	}
+	if query := pq.withTeam; query != nil {
+		if err := pq.loadTeam(ctx, query, nodes, nil,
+			func(n *Poll, e *Team) { n.Edges.Team = e }); err != nil {
+			return nil, err
+		}
+	}
 	return nodes, nil
 }
 
@@ -517,6 +697,191 @@ func (pq *PollQuery) loadOptions(ctx context.Context, query *PollOptionQuery, no
 	}
 	return nil
 }
+func (pq *PollQuery) loadInvitees(ctx context.Context, query *UserQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *User)) error {
+	edgeIDs := make([]driver.Value, len(nodes))
+	byID := make(map[int]*Poll)
+	nids := make(map[int]map[*Poll]struct{})
+	for i, node := range nodes {
+		edgeIDs[i] = node.ID
+		byID[node.ID] = node
+		if init != nil {
+			init(node)
+		}
+	}
+	query.Where(func(s *sql.Selector) {
+		joinT := sql.Table(poll.InviteesTable)
+		s.Join(joinT).On(s.C(user.FieldID), joinT.C(poll.InviteesPrimaryKey[1]))
+		s.Where(sql.InValues(joinT.C(poll.InviteesPrimaryKey[0]), edgeIDs...))
+		columns := s.SelectedColumns()
+		s.Select(joinT.C(poll.InviteesPrimaryKey[0]))
+		s.AppendSelect(columns...)
+		s.SetDistinct(false)
+	})
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+			assign := spec.Assign
+			values := spec.ScanValues
+			spec.ScanValues = func(columns []string) ([]any, error) {
+				values, err := values(columns[1:])
+				if err != nil {
+					return nil, err
+				}
+				return append([]any{new(sql.NullInt64)}, values...), nil
+			}
+			spec.Assign = func(columns []string, values []any) error {
+				outValue := int(values[0].(*sql.NullInt64).Int64)
+				inValue := int(values[1].(*sql.NullInt64).Int64)
+				if nids[inValue] == nil {
+					nids[inValue] = map[*Poll]struct{}{byID[outValue]: {}}
+					return assign(columns[1:], values[1:])
+				}
+				nids[inValue][byID[outValue]] = struct{}{}
+				return nil
+			}
+		})
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected "invitees" node returned %v`, n.ID)
+		}
+		for kn := range nodes {
+			assign(kn, n)
+		}
+	}
+	return nil
+}
+func (pq *PollQuery) loadParticipants(ctx context.Context, query *UserQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *User)) error {
+	edgeIDs := make([]driver.Value, len(nodes))
+	byID := make(map[int]*Poll)
+	nids := make(map[int]map[*Poll]struct{})
+	for i, node := range nodes {
+		edgeIDs[i] = node.ID
+		byID[node.ID] = node
+		if init != nil {
+			init(node)
+		}
+	}
+	query.Where(func(s *sql.Selector) {
+		joinT := sql.Table(poll.ParticipantsTable)
+		s.Join(joinT).On(s.C(user.FieldID), joinT.C(poll.ParticipantsPrimaryKey[1]))
+		s.Where(sql.InValues(joinT.C(poll.ParticipantsPrimaryKey[0]), edgeIDs...))
+		columns := s.SelectedColumns()
+		s.Select(joinT.C(poll.ParticipantsPrimaryKey[0]))
+		s.AppendSelect(columns...)
+		s.SetDistinct(false)
+	})
+	if err := query.prepareQuery(ctx); err != nil {
+		return err
+	}
+	qr := QuerierFunc(func(ctx context.Context, q Query) (Value, error) {
+		return query.sqlAll(ctx, func(_ context.Context, spec *sqlgraph.QuerySpec) {
+			assign := spec.Assign
+			values := spec.ScanValues
+			spec.ScanValues = func(columns []string) ([]any, error) {
+				values, err := values(columns[1:])
+				if err != nil {
+					return nil, err
+				}
+				return append([]any{new(sql.NullInt64)}, values...), nil
+			}
+			spec.Assign = func(columns []string, values []any) error {
+				outValue := int(values[0].(*sql.NullInt64).Int64)
+				inValue := int(values[1].(*sql.NullInt64).Int64)
+				if nids[inValue] == nil {
+					nids[inValue] = map[*Poll]struct{}{byID[outValue]: {}}
+					return assign(columns[1:], values[1:])
+				}
+				nids[inValue][byID[outValue]] = struct{}{}
+				return nil
+			}
+		})
+	})
+	neighbors, err := withInterceptors[[]*User](ctx, query, qr, query.inters)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected "participants" node returned %v`, n.ID)
+		}
+		for kn := range nodes {
+			assign(kn, n)
+		}
+	}
+	return nil
+}
+func (pq *PollQuery) loadInvites(ctx context.Context, query *InviteQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Invite)) error {
+	fks := make([]driver.Value, 0, len(nodes))
+	nodeids := make(map[int]*Poll)
+	for i := range nodes {
+		fks = append(fks, nodes[i].ID)
+		nodeids[nodes[i].ID] = nodes[i]
+		if init != nil {
+			init(nodes[i])
+		}
+	}
+	query.withFKs = true
+	query.Where(predicate.Invite(func(s *sql.Selector) {
+		s.Where(sql.InValues(s.C(poll.InvitesColumn), fks...))
+	}))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		fk := n.poll_invites
+		if fk == nil {
+			return fmt.Errorf(`foreign-key "poll_invites" is nil for node %v`, n.ID)
+		}
+		node, ok := nodeids[*fk]
+		if !ok {
+			return fmt.Errorf(`unexpected referenced foreign-key "poll_invites" returned %v for node %v`, *fk, n.ID)
+		}
+		assign(node, n)
+	}
+	return nil
+}
+func (pq *PollQuery) loadTeam(ctx context.Context, query *TeamQuery, nodes []*Poll, init func(*Poll), assign func(*Poll, *Team)) error {
+	ids := make([]int, 0, len(nodes))
+	nodeids := make(map[int][]*Poll)
+	for i := range nodes {
+		if nodes[i].TeamID == nil {
+			continue
+		}
+		fk := *nodes[i].TeamID
+		if _, ok := nodeids[fk]; !ok {
+			ids = append(ids, fk)
+		}
+		nodeids[fk] = append(nodeids[fk], nodes[i])
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	query.Where(team.IDIn(ids...))
+	neighbors, err := query.All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, n := range neighbors {
+		nodes, ok := nodeids[n.ID]
+		if !ok {
+			return fmt.Errorf(`unexpected foreign-key "team_id" returned %v`, n.ID)
+		}
+		for i := range nodes {
+			assign(nodes[i], n)
+		}
+	}
+	return nil
+}
 
 func (pq *PollQuery) sqlCount(ctx context.Context) (int, error) {
 	_spec := pq.querySpec()
@@ -543,6 +908,9 @@ func (pq *PollQuery) querySpec() *sqlgraph.QuerySpec {
 				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
 			}
 		}
+		if pq.withTeam != nil {
+			_spec.Node.AddColumnOnce(poll.FieldTeamID)
+		}
 	}
 	if ps := pq.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
@@ -6,9 +6,11 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"poll_app/ent/invite"
 	"poll_app/ent/poll"
 	"poll_app/ent/polloption"
 	"poll_app/ent/predicate"
+	"poll_app/ent/team"
 	"poll_app/ent/user"
 	"time"
 
@@ -84,6 +86,212 @@ func (pu *PollUpdate) SetUpdatedAt(t time.Time) *PollUpdate {
 	return pu
 }
 
+// SetOpensAt sets the "opens_at" field.
+func (pu *PollUpdate) SetOpensAt(t time.Time) *PollUpdate {
+	pu.mutation.SetOpensAt(t)
+	return pu
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableOpensAt(t *time.Time) *PollUpdate {
+	if t != nil {
+		pu.SetOpensAt(*t)
+	}
+	return pu
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (pu *PollUpdate) ClearOpensAt() *PollUpdate {
+	pu.mutation.ClearOpensAt()
+	return pu
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (pu *PollUpdate) SetClosesAt(t time.Time) *PollUpdate {
+	pu.mutation.SetClosesAt(t)
+	return pu
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableClosesAt(t *time.Time) *PollUpdate {
+	if t != nil {
+		pu.SetClosesAt(*t)
+	}
+	return pu
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (pu *PollUpdate) ClearClosesAt() *PollUpdate {
+	pu.mutation.ClearClosesAt()
+	return pu
+}
+
+// SetBallotType sets the "ballot_type" field.
+func (pu *PollUpdate) SetBallotType(pt poll.BallotType) *PollUpdate {
+	pu.mutation.SetBallotType(pt)
+	return pu
+}
+
+// SetNillableBallotType sets the "ballot_type" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableBallotType(pt *poll.BallotType) *PollUpdate {
+	if pt != nil {
+		pu.SetBallotType(*pt)
+	}
+	return pu
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (pu *PollUpdate) SetMinChoices(i int) *PollUpdate {
+	pu.mutation.ResetMinChoices()
+	pu.mutation.SetMinChoices(i)
+	return pu
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableMinChoices(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetMinChoices(*i)
+	}
+	return pu
+}
+
+// AddMinChoices adds i to the "min_choices" field.
+func (pu *PollUpdate) AddMinChoices(i int) *PollUpdate {
+	pu.mutation.AddMinChoices(i)
+	return pu
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (pu *PollUpdate) SetMaxChoices(i int) *PollUpdate {
+	pu.mutation.ResetMaxChoices()
+	pu.mutation.SetMaxChoices(i)
+	return pu
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableMaxChoices(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetMaxChoices(*i)
+	}
+	return pu
+}
+
+// AddMaxChoices adds i to the "max_choices" field.
+func (pu *PollUpdate) AddMaxChoices(i int) *PollUpdate {
+	pu.mutation.AddMaxChoices(i)
+	return pu
+}
+
+// SetScoreMin sets the "score_min" field.
+func (pu *PollUpdate) SetScoreMin(i int) *PollUpdate {
+	pu.mutation.ResetScoreMin()
+	pu.mutation.SetScoreMin(i)
+	return pu
+}
+
+// SetNillableScoreMin sets the "score_min" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableScoreMin(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetScoreMin(*i)
+	}
+	return pu
+}
+
+// AddScoreMin adds i to the "score_min" field.
+func (pu *PollUpdate) AddScoreMin(i int) *PollUpdate {
+	pu.mutation.AddScoreMin(i)
+	return pu
+}
+
+// SetScoreMax sets the "score_max" field.
+func (pu *PollUpdate) SetScoreMax(i int) *PollUpdate {
+	pu.mutation.ResetScoreMax()
+	pu.mutation.SetScoreMax(i)
+	return pu
+}
+
+// SetNillableScoreMax sets the "score_max" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableScoreMax(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetScoreMax(*i)
+	}
+	return pu
+}
+
+// AddScoreMax adds i to the "score_max" field.
+func (pu *PollUpdate) AddScoreMax(i int) *PollUpdate {
+	pu.mutation.AddScoreMax(i)
+	return pu
+}
+
+// SetVisibility sets the "visibility" field.
+func (pu *PollUpdate) SetVisibility(po poll.Visibility) *PollUpdate {
+	pu.mutation.SetVisibility(po)
+	return pu
+}
+
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableVisibility(po *poll.Visibility) *PollUpdate {
+	if po != nil {
+		pu.SetVisibility(*po)
+	}
+	return pu
+}
+
+// SetResultsVisibility sets the "results_visibility" field.
+func (pu *PollUpdate) SetResultsVisibility(pv poll.ResultsVisibility) *PollUpdate {
+	pu.mutation.SetResultsVisibility(pv)
+	return pu
+}
+
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableResultsVisibility(pv *poll.ResultsVisibility) *PollUpdate {
+	if pv != nil {
+		pu.SetResultsVisibility(*pv)
+	}
+	return pu
+}
+
+// SetTeamID sets the "team_id" field.
+func (pu *PollUpdate) SetTeamID(i int) *PollUpdate {
+	pu.mutation.SetTeamID(i)
+	return pu
+}
+
+// SetNillableTeamID sets the "team_id" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableTeamID(i *int) *PollUpdate {
+	if i != nil {
+		pu.SetTeamID(*i)
+	}
+	return pu
+}
+
+// ClearTeamID clears the value of the "team_id" field.
+func (pu *PollUpdate) ClearTeamID() *PollUpdate {
+	pu.mutation.ClearTeamID()
+	return pu
+}
+
+// SetLinkKey sets the "link_key" field.
+func (pu *PollUpdate) SetLinkKey(s string) *PollUpdate {
+	pu.mutation.SetLinkKey(s)
+	return pu
+}
+
+// SetNillableLinkKey sets the "link_key" field if the given value is not nil.
+func (pu *PollUpdate) SetNillableLinkKey(s *string) *PollUpdate {
+	if s != nil {
+		pu.SetLinkKey(*s)
+	}
+	return pu
+}
+
+// ClearLinkKey clears the value of the "link_key" field.
+func (pu *PollUpdate) ClearLinkKey() *PollUpdate {
+	pu.mutation.ClearLinkKey()
+	return pu
+}
+
 // SetCreatorID sets the "creator" edge to the User entity by ID.
 func (pu *PollUpdate) SetCreatorID(id int) *PollUpdate {
 	pu.mutation.SetCreatorID(id)
@@ -110,6 +318,56 @@ func (pu *PollUpdate) AddOptions(p ...*PollOption) *PollUpdate {
 	return pu.AddOptionIDs(ids...)
 }
 
+// AddInviteeIDs adds the "invitees" edge to the User entity by IDs.
+func (pu *PollUpdate) AddInviteeIDs(ids ...int) *PollUpdate {
+	pu.mutation.AddInviteeIDs(ids...)
+	return pu
+}
+
+// AddInvitees adds the "invitees" edges to the User entity.
+func (pu *PollUpdate) AddInvitees(u ...*User) *PollUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pu.AddInviteeIDs(ids...)
+}
+
+// AddParticipantIDs adds the "participants" edge to the User entity by IDs.
+func (pu *PollUpdate) AddParticipantIDs(ids ...int) *PollUpdate {
+	pu.mutation.AddParticipantIDs(ids...)
+	return pu
+}
+
+// AddParticipants adds the "participants" edges to the User entity.
+func (pu *PollUpdate) AddParticipants(u ...*User) *PollUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pu.AddParticipantIDs(ids...)
+}
+
+// AddInviteIDs adds the "invites" edge to the Invite entity by IDs.
+func (pu *PollUpdate) AddInviteIDs(ids ...int) *PollUpdate {
+	pu.mutation.AddInviteIDs(ids...)
+	return pu
+}
+
+// AddInvites adds the "invites" edges to the Invite entity.
+func (pu *PollUpdate) AddInvites(i ...*Invite) *PollUpdate {
+	ids := make([]int, len(i))
+	for j := range i {
+		ids[j] = i[j].ID
+	}
+	return pu.AddInviteIDs(ids...)
+}
+
+// SetTeam sets the "team" edge to the Team entity.
+func (pu *PollUpdate) SetTeam(t *Team) *PollUpdate {
+	return pu.SetTeamID(t.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (pu *PollUpdate) Mutation() *PollMutation {
 	return pu.mutation
@@ -142,9 +400,80 @@ func (pu *PollUpdate) RemoveOptions(p ...*PollOption) *PollUpdate {
 	return pu.RemoveOptionIDs(ids...)
 }
 
+// ClearInvitees clears all "invitees" edges to the User entity.
+func (pu *PollUpdate) ClearInvitees() *PollUpdate {
+	pu.mutation.ClearInvitees()
+	return pu
+}
+
+// RemoveInviteeIDs removes the "invitees" edge to User entities by IDs.
+func (pu *PollUpdate) RemoveInviteeIDs(ids ...int) *PollUpdate {
+	pu.mutation.RemoveInviteeIDs(ids...)
+	return pu
+}
+
+// RemoveInvitees removes "invitees" edges to User entities.
+func (pu *PollUpdate) RemoveInvitees(u ...*User) *PollUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pu.RemoveInviteeIDs(ids...)
+}
+
+// ClearParticipants clears all "participants" edges to the User entity.
+func (pu *PollUpdate) ClearParticipants() *PollUpdate {
+	pu.mutation.ClearParticipants()
+	return pu
+}
+
+// RemoveParticipantIDs removes the "participants" edge to User entities by IDs.
+func (pu *PollUpdate) RemoveParticipantIDs(ids ...int) *PollUpdate {
+	pu.mutation.RemoveParticipantIDs(ids...)
+	return pu
+}
+
+// RemoveParticipants removes "participants" edges to User entities.
+func (pu *PollUpdate) RemoveParticipants(u ...*User) *PollUpdate {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return pu.RemoveParticipantIDs(ids...)
+}
+
+// ClearInvites clears all "invites" edges to the Invite entity.
+func (pu *PollUpdate) ClearInvites() *PollUpdate {
+	pu.mutation.ClearInvites()
+	return pu
+}
+
+// RemoveInviteIDs removes the "invites" edge to Invite entities by IDs.
+func (pu *PollUpdate) RemoveInviteIDs(ids ...int) *PollUpdate {
+	pu.mutation.RemoveInviteIDs(ids...)
+	return pu
+}
+
+// RemoveInvites removes "invites" edges to Invite entities.
+func (pu *PollUpdate) RemoveInvites(i ...*Invite) *PollUpdate {
+	ids := make([]int, len(i))
+	for j := range i {
+		ids[j] = i[j].ID
+	}
+	return pu.RemoveInviteIDs(ids...)
+}
+
+// ClearTeam clears the "team" edge to the Team entity.
+func (pu *PollUpdate) ClearTeam() *PollUpdate {
+	pu.mutation.ClearTeam()
+	return pu
+}
+
 // Save executes the query and returns the number of nodes affected by the update operation.
 func (pu *PollUpdate) Save(ctx context.Context) (int, error) {
-	pu.defaults()
+	if err := pu.defaults(); err != nil {
+		return 0, err
+	}
 	return withHooks(ctx, pu.sqlSave, pu.mutation, pu.hooks)
 }
 
@@ -171,11 +500,15 @@ func (pu *PollUpdate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (pu *PollUpdate) defaults() {
+func (pu *PollUpdate) defaults() error {
 	if _, ok := pu.mutation.UpdatedAt(); !ok {
+		if poll.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized poll.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := poll.UpdateDefaultUpdatedAt()
 		pu.mutation.SetUpdatedAt(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -185,6 +518,41 @@ func (pu *PollUpdate) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := pu.mutation.BallotType(); ok {
+		if err := poll.BallotTypeValidator(v); err != nil {
+			return &ValidationError{Name: "ballot_type", err: fmt.Errorf(`ent: validator failed for field "Poll.ballot_type": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.ScoreMin(); ok {
+		if err := poll.ScoreMinValidator(v); err != nil {
+			return &ValidationError{Name: "score_min", err: fmt.Errorf(`ent: validator failed for field "Poll.score_min": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.ScoreMax(); ok {
+		if err := poll.ScoreMaxValidator(v); err != nil {
+			return &ValidationError{Name: "score_max", err: fmt.Errorf(`ent: validator failed for field "Poll.score_max": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.Visibility(); ok {
+		if err := poll.VisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.visibility": %w`, err)}
+		}
+	}
+	if v, ok := pu.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
 	if pu.mutation.CreatorCleared() && len(pu.mutation.CreatorIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Poll.creator"`)
 	}
@@ -218,6 +586,57 @@ func (pu *PollUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := pu.mutation.UpdatedAt(); ok {
 		_spec.SetField(poll.FieldUpdatedAt, field.TypeTime, value)
 	}
+	if value, ok := pu.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if pu.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := pu.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if pu.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := pu.mutation.BallotType(); ok {
+		_spec.SetField(poll.FieldBallotType, field.TypeEnum, value)
+	}
+	if value, ok := pu.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedMinChoices(); ok {
+		_spec.AddField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedMaxChoices(); ok {
+		_spec.AddField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.ScoreMin(); ok {
+		_spec.SetField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedScoreMin(); ok {
+		_spec.AddField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.ScoreMax(); ok {
+		_spec.SetField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.AddedScoreMax(); ok {
+		_spec.AddField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := pu.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeEnum, value)
+	}
+	if value, ok := pu.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+	}
+	if value, ok := pu.mutation.LinkKey(); ok {
+		_spec.SetField(poll.FieldLinkKey, field.TypeString, value)
+	}
+	if pu.mutation.LinkKeyCleared() {
+		_spec.ClearField(poll.FieldLinkKey, field.TypeString)
+	}
 	if pu.mutation.CreatorCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -292,6 +711,170 @@ func (pu *PollUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if pu.mutation.InviteesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InviteesTable,
+			Columns: poll.InviteesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedInviteesIDs(); len(nodes) > 0 && !pu.mutation.InviteesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InviteesTable,
+			Columns: poll.InviteesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.InviteesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InviteesTable,
+			Columns: poll.InviteesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if pu.mutation.ParticipantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.ParticipantsTable,
+			Columns: poll.ParticipantsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedParticipantsIDs(); len(nodes) > 0 && !pu.mutation.ParticipantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.ParticipantsTable,
+			Columns: poll.ParticipantsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.ParticipantsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.ParticipantsTable,
+			Columns: poll.ParticipantsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if pu.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.RemovedInvitesIDs(); len(nodes) > 0 && !pu.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.InvitesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if pu.mutation.TeamCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TeamTable,
+			Columns: []string{poll.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := pu.mutation.TeamIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TeamTable,
+			Columns: []string{poll.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	if n, err = sqlgraph.UpdateNodes(ctx, pu.driver, _spec); err != nil {
 		if _, ok := err.(*sqlgraph.NotFoundError); ok {
 			err = &NotFoundError{poll.Label}
@@ -300,69 +883,275 @@ func (pu *PollUpdate) sqlSave(ctx context.Context) (n int, err error) {
 		}
 		return 0, err
 	}
-	pu.mutation.done = true
-	return n, nil
+	pu.mutation.done = true
+	return n, nil
+}
+
+// PollUpdateOne is the builder for updating a single Poll entity.
+type PollUpdateOne struct {
+	config
+	fields   []string
+	hooks    []Hook
+	mutation *PollMutation
+}
+
+// SetTitle sets the "title" field.
+func (puo *PollUpdateOne) SetTitle(s string) *PollUpdateOne {
+	puo.mutation.SetTitle(s)
+	return puo
+}
+
+// SetNillableTitle sets the "title" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableTitle(s *string) *PollUpdateOne {
+	if s != nil {
+		puo.SetTitle(*s)
+	}
+	return puo
+}
+
+// SetDescription sets the "description" field.
+func (puo *PollUpdateOne) SetDescription(s string) *PollUpdateOne {
+	puo.mutation.SetDescription(s)
+	return puo
+}
+
+// SetNillableDescription sets the "description" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableDescription(s *string) *PollUpdateOne {
+	if s != nil {
+		puo.SetDescription(*s)
+	}
+	return puo
+}
+
+// ClearDescription clears the value of the "description" field.
+func (puo *PollUpdateOne) ClearDescription() *PollUpdateOne {
+	puo.mutation.ClearDescription()
+	return puo
+}
+
+// SetCreatedAt sets the "created_at" field.
+func (puo *PollUpdateOne) SetCreatedAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetCreatedAt(t)
+	return puo
+}
+
+// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableCreatedAt(t *time.Time) *PollUpdateOne {
+	if t != nil {
+		puo.SetCreatedAt(*t)
+	}
+	return puo
+}
+
+// SetUpdatedAt sets the "updated_at" field.
+func (puo *PollUpdateOne) SetUpdatedAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetUpdatedAt(t)
+	return puo
+}
+
+// SetOpensAt sets the "opens_at" field.
+func (puo *PollUpdateOne) SetOpensAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetOpensAt(t)
+	return puo
+}
+
+// SetNillableOpensAt sets the "opens_at" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableOpensAt(t *time.Time) *PollUpdateOne {
+	if t != nil {
+		puo.SetOpensAt(*t)
+	}
+	return puo
+}
+
+// ClearOpensAt clears the value of the "opens_at" field.
+func (puo *PollUpdateOne) ClearOpensAt() *PollUpdateOne {
+	puo.mutation.ClearOpensAt()
+	return puo
+}
+
+// SetClosesAt sets the "closes_at" field.
+func (puo *PollUpdateOne) SetClosesAt(t time.Time) *PollUpdateOne {
+	puo.mutation.SetClosesAt(t)
+	return puo
+}
+
+// SetNillableClosesAt sets the "closes_at" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableClosesAt(t *time.Time) *PollUpdateOne {
+	if t != nil {
+		puo.SetClosesAt(*t)
+	}
+	return puo
+}
+
+// ClearClosesAt clears the value of the "closes_at" field.
+func (puo *PollUpdateOne) ClearClosesAt() *PollUpdateOne {
+	puo.mutation.ClearClosesAt()
+	return puo
+}
+
+// SetBallotType sets the "ballot_type" field.
+func (puo *PollUpdateOne) SetBallotType(pt poll.BallotType) *PollUpdateOne {
+	puo.mutation.SetBallotType(pt)
+	return puo
+}
+
+// SetNillableBallotType sets the "ballot_type" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableBallotType(pt *poll.BallotType) *PollUpdateOne {
+	if pt != nil {
+		puo.SetBallotType(*pt)
+	}
+	return puo
+}
+
+// SetMinChoices sets the "min_choices" field.
+func (puo *PollUpdateOne) SetMinChoices(i int) *PollUpdateOne {
+	puo.mutation.ResetMinChoices()
+	puo.mutation.SetMinChoices(i)
+	return puo
+}
+
+// SetNillableMinChoices sets the "min_choices" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableMinChoices(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetMinChoices(*i)
+	}
+	return puo
+}
+
+// AddMinChoices adds i to the "min_choices" field.
+func (puo *PollUpdateOne) AddMinChoices(i int) *PollUpdateOne {
+	puo.mutation.AddMinChoices(i)
+	return puo
+}
+
+// SetMaxChoices sets the "max_choices" field.
+func (puo *PollUpdateOne) SetMaxChoices(i int) *PollUpdateOne {
+	puo.mutation.ResetMaxChoices()
+	puo.mutation.SetMaxChoices(i)
+	return puo
+}
+
+// SetNillableMaxChoices sets the "max_choices" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableMaxChoices(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetMaxChoices(*i)
+	}
+	return puo
+}
+
+// AddMaxChoices adds i to the "max_choices" field.
+func (puo *PollUpdateOne) AddMaxChoices(i int) *PollUpdateOne {
+	puo.mutation.AddMaxChoices(i)
+	return puo
+}
+
+// SetScoreMin sets the "score_min" field.
+func (puo *PollUpdateOne) SetScoreMin(i int) *PollUpdateOne {
+	puo.mutation.ResetScoreMin()
+	puo.mutation.SetScoreMin(i)
+	return puo
+}
+
+// SetNillableScoreMin sets the "score_min" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableScoreMin(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetScoreMin(*i)
+	}
+	return puo
+}
+
+// AddScoreMin adds i to the "score_min" field.
+func (puo *PollUpdateOne) AddScoreMin(i int) *PollUpdateOne {
+	puo.mutation.AddScoreMin(i)
+	return puo
+}
+
+// SetScoreMax sets the "score_max" field.
+func (puo *PollUpdateOne) SetScoreMax(i int) *PollUpdateOne {
+	puo.mutation.ResetScoreMax()
+	puo.mutation.SetScoreMax(i)
+	return puo
+}
+
+// SetNillableScoreMax sets the "score_max" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableScoreMax(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetScoreMax(*i)
+	}
+	return puo
 }
 
-// PollUpdateOne is the builder for updating a single Poll entity.
-type PollUpdateOne struct {
-	config
-	fields   []string
-	hooks    []Hook
-	mutation *PollMutation
+// AddScoreMax adds i to the "score_max" field.
+func (puo *PollUpdateOne) AddScoreMax(i int) *PollUpdateOne {
+	puo.mutation.AddScoreMax(i)
+	return puo
 }
 
-// SetTitle sets the "title" field.
-func (puo *PollUpdateOne) SetTitle(s string) *PollUpdateOne {
-	puo.mutation.SetTitle(s)
+// SetVisibility sets the "visibility" field.
+func (puo *PollUpdateOne) SetVisibility(po poll.Visibility) *PollUpdateOne {
+	puo.mutation.SetVisibility(po)
 	return puo
 }
 
-// SetNillableTitle sets the "title" field if the given value is not nil.
-func (puo *PollUpdateOne) SetNillableTitle(s *string) *PollUpdateOne {
-	if s != nil {
-		puo.SetTitle(*s)
+// SetNillableVisibility sets the "visibility" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableVisibility(po *poll.Visibility) *PollUpdateOne {
+	if po != nil {
+		puo.SetVisibility(*po)
 	}
 	return puo
 }
 
-// SetDescription sets the "description" field.
-func (puo *PollUpdateOne) SetDescription(s string) *PollUpdateOne {
-	puo.mutation.SetDescription(s)
+// SetResultsVisibility sets the "results_visibility" field.
+func (puo *PollUpdateOne) SetResultsVisibility(pv poll.ResultsVisibility) *PollUpdateOne {
+	puo.mutation.SetResultsVisibility(pv)
 	return puo
 }
 
-// SetNillableDescription sets the "description" field if the given value is not nil.
-func (puo *PollUpdateOne) SetNillableDescription(s *string) *PollUpdateOne {
-	if s != nil {
-		puo.SetDescription(*s)
+// SetNillableResultsVisibility sets the "results_visibility" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableResultsVisibility(pv *poll.ResultsVisibility) *PollUpdateOne {
+	if pv != nil {
+		puo.SetResultsVisibility(*pv)
 	}
 	return puo
 }
 
-// ClearDescription clears the value of the "description" field.
-func (puo *PollUpdateOne) ClearDescription() *PollUpdateOne {
-	puo.mutation.ClearDescription()
+// SetTeamID sets the "team_id" field.
+func (puo *PollUpdateOne) SetTeamID(i int) *PollUpdateOne {
+	puo.mutation.SetTeamID(i)
 	return puo
 }
 
-// SetCreatedAt sets the "created_at" field.
-func (puo *PollUpdateOne) SetCreatedAt(t time.Time) *PollUpdateOne {
-	puo.mutation.SetCreatedAt(t)
+// SetNillableTeamID sets the "team_id" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableTeamID(i *int) *PollUpdateOne {
+	if i != nil {
+		puo.SetTeamID(*i)
+	}
 	return puo
 }
 
-// SetNillableCreatedAt sets the "created_at" field if the given value is not nil.
-func (puo *PollUpdateOne) SetNillableCreatedAt(t *time.Time) *PollUpdateOne {
-	if t != nil {
-		puo.SetCreatedAt(*t)
+// ClearTeamID clears the value of the "team_id" field.
+func (puo *PollUpdateOne) ClearTeamID() *PollUpdateOne {
+	puo.mutation.ClearTeamID()
+	return puo
+}
+
+// SetLinkKey sets the "link_key" field.
+func (puo *PollUpdateOne) SetLinkKey(s string) *PollUpdateOne {
+	puo.mutation.SetLinkKey(s)
+	return puo
+}
+
+// SetNillableLinkKey sets the "link_key" field if the given value is not nil.
+func (puo *PollUpdateOne) SetNillableLinkKey(s *string) *PollUpdateOne {
+	if s != nil {
+		puo.SetLinkKey(*s)
 	}
 	return puo
 }
 
-// SetUpdatedAt sets the "updated_at" field.
-func (puo *PollUpdateOne) SetUpdatedAt(t time.Time) *PollUpdateOne {
-	puo.mutation.SetUpdatedAt(t)
+// ClearLinkKey clears the value of the "link_key" field.
+func (puo *PollUpdateOne) ClearLinkKey() *PollUpdateOne {
+	puo.mutation.ClearLinkKey()
 	return puo
 }
 
@@ -392,6 +1181,56 @@ func (puo *PollUpdateOne) AddOptions(p ...*PollOption) *PollUpdateOne {
 	return puo.AddOptionIDs(ids...)
 }
 
+// AddInviteeIDs adds the "invitees" edge to the User entity by IDs.
+func (puo *PollUpdateOne) AddInviteeIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.AddInviteeIDs(ids...)
+	return puo
+}
+
+// AddInvitees adds the "invitees" edges to the User entity.
+func (puo *PollUpdateOne) AddInvitees(u ...*User) *PollUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return puo.AddInviteeIDs(ids...)
+}
+
+// AddParticipantIDs adds the "participants" edge to the User entity by IDs.
+func (puo *PollUpdateOne) AddParticipantIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.AddParticipantIDs(ids...)
+	return puo
+}
+
+// AddParticipants adds the "participants" edges to the User entity.
+func (puo *PollUpdateOne) AddParticipants(u ...*User) *PollUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return puo.AddParticipantIDs(ids...)
+}
+
+// AddInviteIDs adds the "invites" edge to the Invite entity by IDs.
+func (puo *PollUpdateOne) AddInviteIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.AddInviteIDs(ids...)
+	return puo
+}
+
+// AddInvites adds the "invites" edges to the Invite entity.
+func (puo *PollUpdateOne) AddInvites(i ...*Invite) *PollUpdateOne {
+	ids := make([]int, len(i))
+	for j := range i {
+		ids[j] = i[j].ID
+	}
+	return puo.AddInviteIDs(ids...)
+}
+
+// SetTeam sets the "team" edge to the Team entity.
+func (puo *PollUpdateOne) SetTeam(t *Team) *PollUpdateOne {
+	return puo.SetTeamID(t.ID)
+}
+
 // Mutation returns the PollMutation object of the builder.
 func (puo *PollUpdateOne) Mutation() *PollMutation {
 	return puo.mutation
@@ -424,6 +1263,75 @@ func (puo *PollUpdateOne) RemoveOptions(p ...*PollOption) *PollUpdateOne {
 	return puo.RemoveOptionIDs(ids...)
 }
 
+// ClearInvitees clears all "invitees" edges to the User entity.
+func (puo *PollUpdateOne) ClearInvitees() *PollUpdateOne {
+	puo.mutation.ClearInvitees()
+	return puo
+}
+
+// RemoveInviteeIDs removes the "invitees" edge to User entities by IDs.
+func (puo *PollUpdateOne) RemoveInviteeIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.RemoveInviteeIDs(ids...)
+	return puo
+}
+
+// RemoveInvitees removes "invitees" edges to User entities.
+func (puo *PollUpdateOne) RemoveInvitees(u ...*User) *PollUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return puo.RemoveInviteeIDs(ids...)
+}
+
+// ClearParticipants clears all "participants" edges to the User entity.
+func (puo *PollUpdateOne) ClearParticipants() *PollUpdateOne {
+	puo.mutation.ClearParticipants()
+	return puo
+}
+
+// RemoveParticipantIDs removes the "participants" edge to User entities by IDs.
+func (puo *PollUpdateOne) RemoveParticipantIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.RemoveParticipantIDs(ids...)
+	return puo
+}
+
+// RemoveParticipants removes "participants" edges to User entities.
+func (puo *PollUpdateOne) RemoveParticipants(u ...*User) *PollUpdateOne {
+	ids := make([]int, len(u))
+	for i := range u {
+		ids[i] = u[i].ID
+	}
+	return puo.RemoveParticipantIDs(ids...)
+}
+
+// ClearInvites clears all "invites" edges to the Invite entity.
+func (puo *PollUpdateOne) ClearInvites() *PollUpdateOne {
+	puo.mutation.ClearInvites()
+	return puo
+}
+
+// RemoveInviteIDs removes the "invites" edge to Invite entities by IDs.
+func (puo *PollUpdateOne) RemoveInviteIDs(ids ...int) *PollUpdateOne {
+	puo.mutation.RemoveInviteIDs(ids...)
+	return puo
+}
+
+// RemoveInvites removes "invites" edges to Invite entities.
+func (puo *PollUpdateOne) RemoveInvites(i ...*Invite) *PollUpdateOne {
+	ids := make([]int, len(i))
+	for j := range i {
+		ids[j] = i[j].ID
+	}
+	return puo.RemoveInviteIDs(ids...)
+}
+
+// ClearTeam clears the "team" edge to the Team entity.
+func (puo *PollUpdateOne) ClearTeam() *PollUpdateOne {
+	puo.mutation.ClearTeam()
+	return puo
+}
+
 // Where appends a list predicates to the PollUpdate builder.
 func (puo *PollUpdateOne) Where(ps ...predicate.Poll) *PollUpdateOne {
 	puo.mutation.Where(ps...)
@@ -439,7 +1347,9 @@ func (puo *PollUpdateOne) Select(field string, fields ...string) *PollUpdateOne
 
 // Save executes the query and returns the updated Poll entity.
 func (puo *PollUpdateOne) Save(ctx context.Context) (*Poll, error) {
-	puo.defaults()
+	if err := puo.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, puo.sqlSave, puo.mutation, puo.hooks)
 }
 
@@ -466,11 +1376,15 @@ func (puo *PollUpdateOne) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (puo *PollUpdateOne) defaults() {
+func (puo *PollUpdateOne) defaults() error {
 	if _, ok := puo.mutation.UpdatedAt(); !ok {
+		if poll.UpdateDefaultUpdatedAt == nil {
+			return fmt.Errorf("ent: uninitialized poll.UpdateDefaultUpdatedAt (forgotten import ent/runtime?)")
+		}
 		v := poll.UpdateDefaultUpdatedAt()
 		puo.mutation.SetUpdatedAt(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -480,6 +1394,41 @@ func (puo *PollUpdateOne) check() error {
 			return &ValidationError{Name: "title", err: fmt.Errorf(`ent: validator failed for field "Poll.title": %w`, err)}
 		}
 	}
+	if v, ok := puo.mutation.BallotType(); ok {
+		if err := poll.BallotTypeValidator(v); err != nil {
+			return &ValidationError{Name: "ballot_type", err: fmt.Errorf(`ent: validator failed for field "Poll.ballot_type": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.MinChoices(); ok {
+		if err := poll.MinChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "min_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.min_choices": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.MaxChoices(); ok {
+		if err := poll.MaxChoicesValidator(v); err != nil {
+			return &ValidationError{Name: "max_choices", err: fmt.Errorf(`ent: validator failed for field "Poll.max_choices": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.ScoreMin(); ok {
+		if err := poll.ScoreMinValidator(v); err != nil {
+			return &ValidationError{Name: "score_min", err: fmt.Errorf(`ent: validator failed for field "Poll.score_min": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.ScoreMax(); ok {
+		if err := poll.ScoreMaxValidator(v); err != nil {
+			return &ValidationError{Name: "score_max", err: fmt.Errorf(`ent: validator failed for field "Poll.score_max": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.Visibility(); ok {
+		if err := poll.VisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.visibility": %w`, err)}
+		}
+	}
+	if v, ok := puo.mutation.ResultsVisibility(); ok {
+		if err := poll.ResultsVisibilityValidator(v); err != nil {
+			return &ValidationError{Name: "results_visibility", err: fmt.Errorf(`ent: validator failed for field "Poll.results_visibility": %w`, err)}
+		}
+	}
 	if puo.mutation.CreatorCleared() && len(puo.mutation.CreatorIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Poll.creator"`)
 	}
@@ -530,6 +1479,57 @@ func (puo *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error)
 	if value, ok := puo.mutation.UpdatedAt(); ok {
 		_spec.SetField(poll.FieldUpdatedAt, field.TypeTime, value)
 	}
+	if value, ok := puo.mutation.OpensAt(); ok {
+		_spec.SetField(poll.FieldOpensAt, field.TypeTime, value)
+	}
+	if puo.mutation.OpensAtCleared() {
+		_spec.ClearField(poll.FieldOpensAt, field.TypeTime)
+	}
+	if value, ok := puo.mutation.ClosesAt(); ok {
+		_spec.SetField(poll.FieldClosesAt, field.TypeTime, value)
+	}
+	if puo.mutation.ClosesAtCleared() {
+		_spec.ClearField(poll.FieldClosesAt, field.TypeTime)
+	}
+	if value, ok := puo.mutation.BallotType(); ok {
+		_spec.SetField(poll.FieldBallotType, field.TypeEnum, value)
+	}
+	if value, ok := puo.mutation.MinChoices(); ok {
+		_spec.SetField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedMinChoices(); ok {
+		_spec.AddField(poll.FieldMinChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.MaxChoices(); ok {
+		_spec.SetField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedMaxChoices(); ok {
+		_spec.AddField(poll.FieldMaxChoices, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.ScoreMin(); ok {
+		_spec.SetField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedScoreMin(); ok {
+		_spec.AddField(poll.FieldScoreMin, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.ScoreMax(); ok {
+		_spec.SetField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.AddedScoreMax(); ok {
+		_spec.AddField(poll.FieldScoreMax, field.TypeInt, value)
+	}
+	if value, ok := puo.mutation.Visibility(); ok {
+		_spec.SetField(poll.FieldVisibility, field.TypeEnum, value)
+	}
+	if value, ok := puo.mutation.ResultsVisibility(); ok {
+		_spec.SetField(poll.FieldResultsVisibility, field.TypeEnum, value)
+	}
+	if value, ok := puo.mutation.LinkKey(); ok {
+		_spec.SetField(poll.FieldLinkKey, field.TypeString, value)
+	}
+	if puo.mutation.LinkKeyCleared() {
+		_spec.ClearField(poll.FieldLinkKey, field.TypeString)
+	}
 	if puo.mutation.CreatorCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -604,6 +1604,170 @@ func (puo *PollUpdateOne) sqlSave(ctx context.Context) (_node *Poll, err error)
 		}
 		_spec.Edges.Add = append(_spec.Edges.Add, edge)
 	}
+	if puo.mutation.InviteesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InviteesTable,
+			Columns: poll.InviteesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedInviteesIDs(); len(nodes) > 0 && !puo.mutation.InviteesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InviteesTable,
+			Columns: poll.InviteesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.InviteesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.InviteesTable,
+			Columns: poll.InviteesPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if puo.mutation.ParticipantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.ParticipantsTable,
+			Columns: poll.ParticipantsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedParticipantsIDs(); len(nodes) > 0 && !puo.mutation.ParticipantsCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.ParticipantsTable,
+			Columns: poll.ParticipantsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.ParticipantsIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2M,
+			Inverse: false,
+			Table:   poll.ParticipantsTable,
+			Columns: poll.ParticipantsPrimaryKey,
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(user.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if puo.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.RemovedInvitesIDs(); len(nodes) > 0 && !puo.mutation.InvitesCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.InvitesIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.O2M,
+			Inverse: false,
+			Table:   poll.InvitesTable,
+			Columns: []string{poll.InvitesColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(invite.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
+	if puo.mutation.TeamCleared() {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TeamTable,
+			Columns: []string{poll.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		_spec.Edges.Clear = append(_spec.Edges.Clear, edge)
+	}
+	if nodes := puo.mutation.TeamIDs(); len(nodes) > 0 {
+		edge := &sqlgraph.EdgeSpec{
+			Rel:     sqlgraph.M2O,
+			Inverse: true,
+			Table:   poll.TeamTable,
+			Columns: []string{poll.TeamColumn},
+			Bidi:    false,
+			Target: &sqlgraph.EdgeTarget{
+				IDSpec: sqlgraph.NewFieldSpec(team.FieldID, field.TypeInt),
+			},
+		}
+		for _, k := range nodes {
+			edge.Target.Nodes = append(edge.Target.Nodes, k)
+		}
+		_spec.Edges.Add = append(_spec.Edges.Add, edge)
+	}
 	_node = &Poll{config: puo.config}
 	_spec.Assign = _node.assignValues
 	_spec.ScanValues = _node.scanValues
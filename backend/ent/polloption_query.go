@@ -5,6 +5,7 @@ package ent
 import (
 	"context"
 	"database/sql/driver"
+	"errors"
 	"fmt"
 	"math"
 	"poll_app/ent/poll"
@@ -401,6 +402,12 @@ func (poq *PollOptionQuery) prepareQuery(ctx context.Context) error {
 		}
 		poq.sql = prev
 	}
+	if polloption.Policy == nil {
+		return errors.New("ent: uninitialized polloption.Policy (forgotten import ent/runtime?)")
+	}
+	if err := polloption.Policy.EvalQuery(ctx, poq); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -497,6 +504,9 @@ func (poq *PollOptionQuery) loadVotes(ctx context.Context, query *VoteQuery, nod
 		}
 	}
 	query.withFKs = true
+	if len(query.ctx.Fields) > 0 {
+		query.ctx.AppendFieldOnce(vote.FieldOptionID)
+	}
 	query.Where(predicate.Vote(func(s *sql.Selector) {
 		s.Where(sql.InValues(s.C(polloption.VotesColumn), fks...))
 	}))
@@ -505,13 +515,10 @@ func (poq *PollOptionQuery) loadVotes(ctx context.Context, query *VoteQuery, nod
 		return err
 	}
 	for _, n := range neighbors {
-		fk := n.poll_option_votes
-		if fk == nil {
-			return fmt.Errorf(`foreign-key "poll_option_votes" is nil for node %v`, n.ID)
-		}
-		node, ok := nodeids[*fk]
+		fk := n.OptionID
+		node, ok := nodeids[fk]
 		if !ok {
-			return fmt.Errorf(`unexpected referenced foreign-key "poll_option_votes" returned %v for node %v`, *fk, n.ID)
+			return fmt.Errorf(`unexpected referenced foreign-key "option_id" returned %v for node %v`, fk, n.ID)
 		}
 		assign(node, n)
 	}
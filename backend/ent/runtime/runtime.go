@@ -2,7 +2,296 @@
 
 package runtime
 
-// The schema-stitching logic is generated in poll_app/ent/runtime.go
+import (
+	"context"
+	"poll_app/ent/auditlog"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/externalidentity"
+	"poll_app/ent/invite"
+	"poll_app/ent/notification"
+	"poll_app/ent/passkey"
+	"poll_app/ent/passkeyceremony"
+	"poll_app/ent/poll"
+	"poll_app/ent/polloption"
+	"poll_app/ent/recoverycode"
+	"poll_app/ent/refreshtoken"
+	"poll_app/ent/schema"
+	"poll_app/ent/session"
+	"poll_app/ent/team"
+	"poll_app/ent/user"
+	"poll_app/ent/vote"
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/privacy"
+)
+
+// The init function reads all schema descriptors with runtime code
+// (default values, validators, hooks and policies) and stitches it
+// to their package variables.
+func init() {
+	auditlogFields := schema.AuditLog{}.Fields()
+	_ = auditlogFields
+	// auditlogDescCreatedAt is the schema descriptor for created_at field.
+	auditlogDescCreatedAt := auditlogFields[4].Descriptor()
+	// auditlog.DefaultCreatedAt holds the default value on creation for the created_at field.
+	auditlog.DefaultCreatedAt = auditlogDescCreatedAt.Default.(func() time.Time)
+	authtokenFields := schema.AuthToken{}.Fields()
+	_ = authtokenFields
+	// authtokenDescAttempts is the schema descriptor for attempts field.
+	authtokenDescAttempts := authtokenFields[4].Descriptor()
+	// authtoken.DefaultAttempts holds the default value on creation for the attempts field.
+	authtoken.DefaultAttempts = authtokenDescAttempts.Default.(int)
+	// authtokenDescCreatedAt is the schema descriptor for created_at field.
+	authtokenDescCreatedAt := authtokenFields[7].Descriptor()
+	// authtoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	authtoken.DefaultCreatedAt = authtokenDescCreatedAt.Default.(func() time.Time)
+	externalidentityFields := schema.ExternalIdentity{}.Fields()
+	_ = externalidentityFields
+	// externalidentityDescIssuer is the schema descriptor for issuer field.
+	externalidentityDescIssuer := externalidentityFields[0].Descriptor()
+	// externalidentity.IssuerValidator is a validator for the "issuer" field. It is called by the builders before save.
+	externalidentity.IssuerValidator = externalidentityDescIssuer.Validators[0].(func(string) error)
+	// externalidentityDescSubject is the schema descriptor for subject field.
+	externalidentityDescSubject := externalidentityFields[1].Descriptor()
+	// externalidentity.SubjectValidator is a validator for the "subject" field. It is called by the builders before save.
+	externalidentity.SubjectValidator = externalidentityDescSubject.Validators[0].(func(string) error)
+	// externalidentityDescEmail is the schema descriptor for email field.
+	externalidentityDescEmail := externalidentityFields[2].Descriptor()
+	// externalidentity.DefaultEmail holds the default value on creation for the email field.
+	externalidentity.DefaultEmail = externalidentityDescEmail.Default.(string)
+	// externalidentityDescLastLoginAt is the schema descriptor for last_login_at field.
+	externalidentityDescLastLoginAt := externalidentityFields[3].Descriptor()
+	// externalidentity.DefaultLastLoginAt holds the default value on creation for the last_login_at field.
+	externalidentity.DefaultLastLoginAt = externalidentityDescLastLoginAt.Default.(func() time.Time)
+	// externalidentityDescCreatedAt is the schema descriptor for created_at field.
+	externalidentityDescCreatedAt := externalidentityFields[4].Descriptor()
+	// externalidentity.DefaultCreatedAt holds the default value on creation for the created_at field.
+	externalidentity.DefaultCreatedAt = externalidentityDescCreatedAt.Default.(func() time.Time)
+	inviteFields := schema.Invite{}.Fields()
+	_ = inviteFields
+	// inviteDescMaxUses is the schema descriptor for max_uses field.
+	inviteDescMaxUses := inviteFields[2].Descriptor()
+	// invite.MaxUsesValidator is a validator for the "max_uses" field. It is called by the builders before save.
+	invite.MaxUsesValidator = inviteDescMaxUses.Validators[0].(func(int) error)
+	// inviteDescUses is the schema descriptor for uses field.
+	inviteDescUses := inviteFields[3].Descriptor()
+	// invite.DefaultUses holds the default value on creation for the uses field.
+	invite.DefaultUses = inviteDescUses.Default.(int)
+	// invite.UsesValidator is a validator for the "uses" field. It is called by the builders before save.
+	invite.UsesValidator = inviteDescUses.Validators[0].(func(int) error)
+	// inviteDescCreatedAt is the schema descriptor for created_at field.
+	inviteDescCreatedAt := inviteFields[5].Descriptor()
+	// invite.DefaultCreatedAt holds the default value on creation for the created_at field.
+	invite.DefaultCreatedAt = inviteDescCreatedAt.Default.(func() time.Time)
+	notificationFields := schema.Notification{}.Fields()
+	_ = notificationFields
+	// notificationDescMessage is the schema descriptor for message field.
+	notificationDescMessage := notificationFields[0].Descriptor()
+	// notification.MessageValidator is a validator for the "message" field. It is called by the builders before save.
+	notification.MessageValidator = notificationDescMessage.Validators[0].(func(string) error)
+	// notificationDescType is the schema descriptor for type field.
+	notificationDescType := notificationFields[1].Descriptor()
+	// notification.DefaultType holds the default value on creation for the type field.
+	notification.DefaultType = notificationDescType.Default.(string)
+	// notificationDescRead is the schema descriptor for read field.
+	notificationDescRead := notificationFields[3].Descriptor()
+	// notification.DefaultRead holds the default value on creation for the read field.
+	notification.DefaultRead = notificationDescRead.Default.(bool)
+	// notificationDescCreatedAt is the schema descriptor for created_at field.
+	notificationDescCreatedAt := notificationFields[4].Descriptor()
+	// notification.DefaultCreatedAt holds the default value on creation for the created_at field.
+	notification.DefaultCreatedAt = notificationDescCreatedAt.Default.(func() time.Time)
+	passkeyFields := schema.Passkey{}.Fields()
+	_ = passkeyFields
+	// passkeyDescAttestationType is the schema descriptor for attestation_type field.
+	passkeyDescAttestationType := passkeyFields[2].Descriptor()
+	// passkey.DefaultAttestationType holds the default value on creation for the attestation_type field.
+	passkey.DefaultAttestationType = passkeyDescAttestationType.Default.(string)
+	// passkeyDescSignCount is the schema descriptor for sign_count field.
+	passkeyDescSignCount := passkeyFields[5].Descriptor()
+	// passkey.DefaultSignCount holds the default value on creation for the sign_count field.
+	passkey.DefaultSignCount = passkeyDescSignCount.Default.(uint32)
+	// passkeyDescBackupEligible is the schema descriptor for backup_eligible field.
+	passkeyDescBackupEligible := passkeyFields[6].Descriptor()
+	// passkey.DefaultBackupEligible holds the default value on creation for the backup_eligible field.
+	passkey.DefaultBackupEligible = passkeyDescBackupEligible.Default.(bool)
+	// passkeyDescBackupState is the schema descriptor for backup_state field.
+	passkeyDescBackupState := passkeyFields[7].Descriptor()
+	// passkey.DefaultBackupState holds the default value on creation for the backup_state field.
+	passkey.DefaultBackupState = passkeyDescBackupState.Default.(bool)
+	// passkeyDescName is the schema descriptor for name field.
+	passkeyDescName := passkeyFields[8].Descriptor()
+	// passkey.DefaultName holds the default value on creation for the name field.
+	passkey.DefaultName = passkeyDescName.Default.(string)
+	// passkeyDescCreatedAt is the schema descriptor for created_at field.
+	passkeyDescCreatedAt := passkeyFields[10].Descriptor()
+	// passkey.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passkey.DefaultCreatedAt = passkeyDescCreatedAt.Default.(func() time.Time)
+	passkeyceremonyFields := schema.PasskeyCeremony{}.Fields()
+	_ = passkeyceremonyFields
+	// passkeyceremonyDescCreatedAt is the schema descriptor for created_at field.
+	passkeyceremonyDescCreatedAt := passkeyceremonyFields[4].Descriptor()
+	// passkeyceremony.DefaultCreatedAt holds the default value on creation for the created_at field.
+	passkeyceremony.DefaultCreatedAt = passkeyceremonyDescCreatedAt.Default.(func() time.Time)
+	poll.Policy = privacy.NewPolicies(schema.Poll{})
+	poll.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := poll.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	pollFields := schema.Poll{}.Fields()
+	_ = pollFields
+	// pollDescTitle is the schema descriptor for title field.
+	pollDescTitle := pollFields[0].Descriptor()
+	// poll.TitleValidator is a validator for the "title" field. It is called by the builders before save.
+	poll.TitleValidator = pollDescTitle.Validators[0].(func(string) error)
+	// pollDescCreatedAt is the schema descriptor for created_at field.
+	pollDescCreatedAt := pollFields[2].Descriptor()
+	// poll.DefaultCreatedAt holds the default value on creation for the created_at field.
+	poll.DefaultCreatedAt = pollDescCreatedAt.Default.(func() time.Time)
+	// pollDescUpdatedAt is the schema descriptor for updated_at field.
+	pollDescUpdatedAt := pollFields[3].Descriptor()
+	// poll.DefaultUpdatedAt holds the default value on creation for the updated_at field.
+	poll.DefaultUpdatedAt = pollDescUpdatedAt.Default.(func() time.Time)
+	// poll.UpdateDefaultUpdatedAt holds the default value on update for the updated_at field.
+	poll.UpdateDefaultUpdatedAt = pollDescUpdatedAt.UpdateDefault.(func() time.Time)
+	// pollDescMinChoices is the schema descriptor for min_choices field.
+	pollDescMinChoices := pollFields[7].Descriptor()
+	// poll.DefaultMinChoices holds the default value on creation for the min_choices field.
+	poll.DefaultMinChoices = pollDescMinChoices.Default.(int)
+	// poll.MinChoicesValidator is a validator for the "min_choices" field. It is called by the builders before save.
+	poll.MinChoicesValidator = pollDescMinChoices.Validators[0].(func(int) error)
+	// pollDescMaxChoices is the schema descriptor for max_choices field.
+	pollDescMaxChoices := pollFields[8].Descriptor()
+	// poll.DefaultMaxChoices holds the default value on creation for the max_choices field.
+	poll.DefaultMaxChoices = pollDescMaxChoices.Default.(int)
+	// poll.MaxChoicesValidator is a validator for the "max_choices" field. It is called by the builders before save.
+	poll.MaxChoicesValidator = pollDescMaxChoices.Validators[0].(func(int) error)
+	// pollDescScoreMin is the schema descriptor for score_min field.
+	pollDescScoreMin := pollFields[9].Descriptor()
+	// poll.DefaultScoreMin holds the default value on creation for the score_min field.
+	poll.DefaultScoreMin = pollDescScoreMin.Default.(int)
+	// poll.ScoreMinValidator is a validator for the "score_min" field. It is called by the builders before save.
+	poll.ScoreMinValidator = pollDescScoreMin.Validators[0].(func(int) error)
+	// pollDescScoreMax is the schema descriptor for score_max field.
+	pollDescScoreMax := pollFields[10].Descriptor()
+	// poll.DefaultScoreMax holds the default value on creation for the score_max field.
+	poll.DefaultScoreMax = pollDescScoreMax.Default.(int)
+	// poll.ScoreMaxValidator is a validator for the "score_max" field. It is called by the builders before save.
+	poll.ScoreMaxValidator = pollDescScoreMax.Validators[0].(func(int) error)
+	// pollDescAnonymous is the schema descriptor for anonymous field.
+	pollDescAnonymous := pollFields[11].Descriptor()
+	// poll.DefaultAnonymous holds the default value on creation for the anonymous field.
+	poll.DefaultAnonymous = pollDescAnonymous.Default.(bool)
+	// pollDescLinkKey is the schema descriptor for link_key field.
+	pollDescLinkKey := pollFields[15].Descriptor()
+	// poll.DefaultLinkKey holds the default value on creation for the link_key field.
+	poll.DefaultLinkKey = pollDescLinkKey.Default.(func() string)
+	polloption.Policy = privacy.NewPolicies(schema.PollOption{})
+	polloption.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := polloption.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	polloptionFields := schema.PollOption{}.Fields()
+	_ = polloptionFields
+	// polloptionDescText is the schema descriptor for text field.
+	polloptionDescText := polloptionFields[0].Descriptor()
+	// polloption.TextValidator is a validator for the "text" field. It is called by the builders before save.
+	polloption.TextValidator = polloptionDescText.Validators[0].(func(string) error)
+	recoverycodeFields := schema.RecoveryCode{}.Fields()
+	_ = recoverycodeFields
+	// recoverycodeDescCreatedAt is the schema descriptor for created_at field.
+	recoverycodeDescCreatedAt := recoverycodeFields[2].Descriptor()
+	// recoverycode.DefaultCreatedAt holds the default value on creation for the created_at field.
+	recoverycode.DefaultCreatedAt = recoverycodeDescCreatedAt.Default.(func() time.Time)
+	refreshtokenFields := schema.RefreshToken{}.Fields()
+	_ = refreshtokenFields
+	// refreshtokenDescCreatedAt is the schema descriptor for created_at field.
+	refreshtokenDescCreatedAt := refreshtokenFields[3].Descriptor()
+	// refreshtoken.DefaultCreatedAt holds the default value on creation for the created_at field.
+	refreshtoken.DefaultCreatedAt = refreshtokenDescCreatedAt.Default.(func() time.Time)
+	sessionFields := schema.Session{}.Fields()
+	_ = sessionFields
+	// sessionDescUserAgent is the schema descriptor for user_agent field.
+	sessionDescUserAgent := sessionFields[0].Descriptor()
+	// session.DefaultUserAgent holds the default value on creation for the user_agent field.
+	session.DefaultUserAgent = sessionDescUserAgent.Default.(string)
+	// sessionDescIP is the schema descriptor for ip field.
+	sessionDescIP := sessionFields[1].Descriptor()
+	// session.DefaultIP holds the default value on creation for the ip field.
+	session.DefaultIP = sessionDescIP.Default.(string)
+	// sessionDescLastSeenAt is the schema descriptor for last_seen_at field.
+	sessionDescLastSeenAt := sessionFields[3].Descriptor()
+	// session.DefaultLastSeenAt holds the default value on creation for the last_seen_at field.
+	session.DefaultLastSeenAt = sessionDescLastSeenAt.Default.(func() time.Time)
+	// sessionDescCreatedAt is the schema descriptor for created_at field.
+	sessionDescCreatedAt := sessionFields[5].Descriptor()
+	// session.DefaultCreatedAt holds the default value on creation for the created_at field.
+	session.DefaultCreatedAt = sessionDescCreatedAt.Default.(func() time.Time)
+	teamFields := schema.Team{}.Fields()
+	_ = teamFields
+	// teamDescName is the schema descriptor for name field.
+	teamDescName := teamFields[0].Descriptor()
+	// team.NameValidator is a validator for the "name" field. It is called by the builders before save.
+	team.NameValidator = teamDescName.Validators[0].(func(string) error)
+	// teamDescCreatedAt is the schema descriptor for created_at field.
+	teamDescCreatedAt := teamFields[1].Descriptor()
+	// team.DefaultCreatedAt holds the default value on creation for the created_at field.
+	team.DefaultCreatedAt = teamDescCreatedAt.Default.(func() time.Time)
+	userFields := schema.User{}.Fields()
+	_ = userFields
+	// userDescUsername is the schema descriptor for username field.
+	userDescUsername := userFields[0].Descriptor()
+	// user.UsernameValidator is a validator for the "username" field. It is called by the builders before save.
+	user.UsernameValidator = userDescUsername.Validators[0].(func(string) error)
+	// userDescEmail is the schema descriptor for email field.
+	userDescEmail := userFields[1].Descriptor()
+	// user.EmailValidator is a validator for the "email" field. It is called by the builders before save.
+	user.EmailValidator = userDescEmail.Validators[0].(func(string) error)
+	// userDescEmailVerified is the schema descriptor for email_verified field.
+	userDescEmailVerified := userFields[2].Descriptor()
+	// user.DefaultEmailVerified holds the default value on creation for the email_verified field.
+	user.DefaultEmailVerified = userDescEmailVerified.Default.(bool)
+	// userDescPassword is the schema descriptor for password field.
+	userDescPassword := userFields[3].Descriptor()
+	// user.PasswordValidator is a validator for the "password" field. It is called by the builders before save.
+	user.PasswordValidator = userDescPassword.Validators[0].(func(string) error)
+	// userDescTotpEnabled is the schema descriptor for totp_enabled field.
+	userDescTotpEnabled := userFields[5].Descriptor()
+	// user.DefaultTotpEnabled holds the default value on creation for the totp_enabled field.
+	user.DefaultTotpEnabled = userDescTotpEnabled.Default.(bool)
+	// userDescTotpLastStep is the schema descriptor for totp_last_step field.
+	userDescTotpLastStep := userFields[6].Descriptor()
+	// user.DefaultTotpLastStep holds the default value on creation for the totp_last_step field.
+	user.DefaultTotpLastStep = userDescTotpLastStep.Default.(int64)
+	// userDescCreatedAt is the schema descriptor for created_at field.
+	userDescCreatedAt := userFields[8].Descriptor()
+	// user.DefaultCreatedAt holds the default value on creation for the created_at field.
+	user.DefaultCreatedAt = userDescCreatedAt.Default.(func() time.Time)
+	vote.Policy = privacy.NewPolicies(schema.Vote{})
+	vote.Hooks[0] = func(next ent.Mutator) ent.Mutator {
+		return ent.MutateFunc(func(ctx context.Context, m ent.Mutation) (ent.Value, error) {
+			if err := vote.Policy.EvalMutation(ctx, m); err != nil {
+				return nil, err
+			}
+			return next.Mutate(ctx, m)
+		})
+	}
+	voteFields := schema.Vote{}.Fields()
+	_ = voteFields
+	// voteDescCreatedAt is the schema descriptor for created_at field.
+	voteDescCreatedAt := voteFields[0].Descriptor()
+	// vote.DefaultCreatedAt holds the default value on creation for the created_at field.
+	vote.DefaultCreatedAt = voteDescCreatedAt.Default.(func() time.Time)
+}
 
 const (
 	Version = "v0.14.3"                                         // Version of ent codegen.
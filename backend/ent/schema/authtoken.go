@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// AuthToken holds the schema definition for the AuthToken entity, a
+// single-use token emailed to a user or handed out mid-login.
+type AuthToken struct {
+	ent.Schema
+}
+
+// Fields of the AuthToken.
+func (AuthToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("kind").
+			Values("password_reset", "email_verification", "two_factor", "oidc_login", "magic_link").
+			Immutable(),
+		field.String("token_hash").
+			Unique().
+			Immutable().
+			Sensitive(), // sha256 of the token; the token itself is never stored
+		field.Time("expires_at").
+			Immutable(),
+		field.Time("used_at").
+			Optional().
+			Nillable(),
+		field.Int("attempts").
+			Default(0), // failed attempts, for tokens that check a second factor
+		field.String("ip").
+			Optional(), // address that asked for the token
+		field.String("email").
+			Optional().
+			Immutable(), // the user's email when the token was issued
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the AuthToken.
+func (AuthToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("auth_tokens").
+			Unique().
+			Required(),
+	}
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"entgo.io/ent/schema/index"
+)
+
+// ExternalIdentity holds the schema definition for the ExternalIdentity
+// entity, an account at an OpenID Connect provider linked to a user.
+type ExternalIdentity struct {
+	ent.Schema
+}
+
+// Fields of the ExternalIdentity.
+func (ExternalIdentity) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("issuer").
+			NotEmpty().
+			Immutable(),
+		field.String("subject").
+			NotEmpty().
+			Immutable(), // the provider's stable user ID, the "sub" claim
+		field.String("email").
+			Default(""), // as of the last login, for display
+		field.Time("last_login_at").
+			Default(time.Now),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the ExternalIdentity.
+func (ExternalIdentity) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("external_identities").
+			Unique().
+			Required(),
+	}
+}
+
+// Indexes of the ExternalIdentity.
+func (ExternalIdentity) Indexes() []ent.Index {
+	return []ent.Index{
+		index.Fields("issuer", "subject").
+			Unique(),
+	}
+}
@@ -0,0 +1,53 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Invite holds the schema definition for the Invite entity.
+type Invite struct {
+	ent.Schema
+}
+
+// Fields of the Invite.
+func (Invite) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("token_hash").
+			Unique().
+			Immutable().
+			Sensitive(), // sha256 of the token; the token itself is never stored
+		field.Time("expires_at").
+			Optional().
+			Nillable(),
+		field.Int("max_uses").
+			Optional().
+			Nillable().
+			Positive(), // nil means unlimited
+		field.Int("uses").
+			Default(0).
+			NonNegative(),
+		field.Time("revoked_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now),
+	}
+}
+
+// Edges of the Invite.
+func (Invite) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("poll", Poll.Type).
+			Ref("invites").
+			Unique().
+			Required(),
+		edge.From("creator", User.Type).
+			Ref("created_invites").
+			Unique().
+			Required(),
+	}
+}
@@ -1,6 +1,9 @@
 package schema
 
 import (
+	"poll_app/ent/privacy"
+	"poll_app/rule"
+
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
@@ -29,3 +32,12 @@ func (PollOption) Edges() []ent.Edge {
 		edge.To("votes", Vote.Type),
 	}
 }
+
+// Policy of the PollOption.
+func (PollOption) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			rule.FilterVisiblePollOptions(),
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// Passkey holds the schema definition for the Passkey entity, a WebAuthn
+// credential a user can log in with instead of a password.
+type Passkey struct {
+	ent.Schema
+}
+
+// Fields of the Passkey.
+func (Passkey) Fields() []ent.Field {
+	return []ent.Field{
+		field.Bytes("credential_id").
+			Unique().
+			Immutable(),
+		field.Bytes("public_key").
+			Immutable(), // COSE encoded
+		field.String("attestation_type").
+			Default("").
+			Immutable(),
+		field.Bytes("aaguid").
+			Optional().
+			Immutable(), // identifies the authenticator model
+		field.Strings("transports").
+			Optional().
+			Immutable(),
+		field.Uint32("sign_count").
+			Default(0),
+		field.Bool("backup_eligible").
+			Default(false).
+			Immutable(),
+		field.Bool("backup_state").
+			Default(false), // synced to other devices, e.g. by a password manager
+		field.String("name").
+			Default("Passkey"),
+		field.Time("last_used_at").
+			Optional().
+			Nillable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the Passkey.
+func (Passkey) Edges() []ent.Edge {
+	return []ent.Edge{
+		edge.From("user", User.Type).
+			Ref("passkeys").
+			Unique().
+			Required(),
+	}
+}
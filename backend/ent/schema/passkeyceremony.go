@@ -0,0 +1,48 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+	"github.com/go-webauthn/webauthn/webauthn"
+)
+
+// PasskeyCeremony holds the schema definition for the PasskeyCeremony
+// entity, the server side state of a WebAuthn registration or login between
+// its begin and finish requests.
+type PasskeyCeremony struct {
+	ent.Schema
+}
+
+// Fields of the PasskeyCeremony.
+func (PasskeyCeremony) Fields() []ent.Field {
+	return []ent.Field{
+		field.Enum("kind").
+			Values("registration", "login").
+			Immutable(),
+		field.String("token_hash").
+			Unique().
+			Immutable().
+			Sensitive(), // sha256 of the token; the token itself is never stored
+		field.JSON("session", webauthn.SessionData{}).
+			Immutable(), // holds the challenge
+		field.Time("expires_at").
+			Immutable(),
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the PasskeyCeremony.
+func (PasskeyCeremony) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Set for registrations; a login does not know its user until it finishes
+		edge.From("user", User.Type).
+			Ref("passkey_ceremonies").
+			Unique().
+			Immutable(),
+	}
+}
@@ -1,8 +1,13 @@
 package schema
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"time"
 
+	"poll_app/ent/privacy"
+	"poll_app/rule"
+
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
@@ -25,7 +30,55 @@ func (Poll) Fields() []ent.Field {
 		field.Time("updated_at").
 			Default(time.Now).
 			UpdateDefault(time.Now),
+		field.Time("opens_at").
+			Optional().
+			Nillable(), // nil means open from creation
+		field.Time("closes_at").
+			Optional().
+			Nillable(), // nil means never closes
+		field.Enum("ballot_type").
+			Values("single", "multiple", "ranked", "score").
+			Default("single"),
+		field.Int("min_choices").
+			Default(1).
+			Positive(),
+		field.Int("max_choices").
+			Default(1).
+			Positive(),
+		field.Int("score_min").
+			Default(0).
+			NonNegative(), // rating range for score ballots
+		field.Int("score_max").
+			Default(5).
+			NonNegative(),
+		field.Bool("anonymous").
+			Default(false).
+			Immutable(), // votes are keyed by voter_hash instead of user
+		field.Enum("visibility").
+			Values("public", "unlisted", "private", "team").
+			Default("public"),
+		field.Enum("results_visibility").
+			Values("always", "after_vote", "after_close", "creator_only").
+			Default("always"),
+		field.Int("team_id").
+			Optional().
+			Nillable(), // set for team visibility
+		field.String("link_key").
+			Optional().
+			Nillable().
+			DefaultFunc(newLinkKey).
+			Unique().
+			Sensitive(), // secret in the link to an unlisted poll
+	}
+}
+
+// newLinkKey returns a random URL-safe link key
+func newLinkKey() string {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
 	}
+	return base64.RawURLEncoding.EncodeToString(b)
 }
 
 // Edges of the Poll.
@@ -36,5 +89,23 @@ func (Poll) Edges() []ent.Edge {
 			Unique().
 			Required(),
 		edge.To("options", PollOption.Type),
+		edge.To("invitees", User.Type),
+		// Users who voted on an anonymous poll, without their choices.
+		// Named votes already link to the user.
+		edge.To("participants", User.Type),
+		edge.To("invites", Invite.Type),
+		edge.From("team", Team.Type).
+			Ref("polls").
+			Field("team_id").
+			Unique(),
+	}
+}
+
+// Policy of the Poll.
+func (Poll) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			rule.FilterVisiblePolls(),
+		},
 	}
 }
@@ -0,0 +1,43 @@
+package schema
+
+import (
+	"time"
+
+	"entgo.io/ent"
+	"entgo.io/ent/schema/edge"
+	"entgo.io/ent/schema/field"
+)
+
+// RefreshToken holds the schema definition for the RefreshToken entity.
+type RefreshToken struct {
+	ent.Schema
+}
+
+// Fields of the RefreshToken.
+func (RefreshToken) Fields() []ent.Field {
+	return []ent.Field{
+		field.String("token_hash").
+			Unique().
+			Immutable().
+			Sensitive(), // sha256 of the token; the token itself is never stored
+		field.Time("expires_at").
+			Immutable(),
+		field.Time("used_at").
+			Optional().
+			Nillable(), // set once the token has been exchanged for a new one
+		field.Time("created_at").
+			Default(time.Now).
+			Immutable(),
+	}
+}
+
+// Edges of the RefreshToken.
+func (RefreshToken) Edges() []ent.Edge {
+	return []ent.Edge{
+		// Every token rotated from one login belongs to its session
+		edge.From("session", Session.Type).
+			Ref("refresh_tokens").
+			Unique().
+			Required(),
+	}
+}
@@ -22,9 +22,25 @@ func (User) Fields() []ent.Field {
 		field.String("email").
 			Unique().
 			NotEmpty(),
+		field.Bool("email_verified").
+			Default(false),
 		field.String("password").
+			Optional().
 			NotEmpty().
+			Sensitive(), // unset for users who only sign in through SSO
+		// TOTP two-factor authentication. The secret is set during
+		// enrollment and only used for login once totp_enabled is true.
+		field.String("totp_secret").
+			Optional().
+			Nillable().
 			Sensitive(),
+		field.Bool("totp_enabled").
+			Default(false),
+		field.Int64("totp_last_step").
+			Default(0), // time step of the last accepted code, so codes cannot be replayed
+		field.Bytes("webauthn_id").
+			Optional().
+			Unique(), // random WebAuthn user handle, set when the first passkey is registered
 		field.Time("created_at").
 			Default(time.Now),
 	}
@@ -36,5 +52,20 @@ func (User) Edges() []ent.Edge {
 		edge.To("polls", Poll.Type),
 		edge.To("votes", Vote.Type),
 		edge.To("notifications", Notification.Type),
+		edge.To("owned_teams", Team.Type),
+		edge.From("teams", Team.Type).
+			Ref("members"),
+		edge.From("invited_polls", Poll.Type).
+			Ref("invitees"),
+		edge.From("participated_polls", Poll.Type).
+			Ref("participants"),
+		edge.To("created_invites", Invite.Type),
+		edge.To("sessions", Session.Type),
+		edge.To("auth_tokens", AuthToken.Type),
+		edge.To("recovery_codes", RecoveryCode.Type),
+		edge.To("passkeys", Passkey.Type),
+		edge.To("passkey_ceremonies", PasskeyCeremony.Type),
+		edge.To("external_identities", ExternalIdentity.Type),
+		edge.To("audit_logs", AuditLog.Type),
 	}
 }
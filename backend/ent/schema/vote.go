@@ -3,6 +3,9 @@ package schema
 import (
 	"time"
 
+	"poll_app/ent/privacy"
+	"poll_app/rule"
+
 	"entgo.io/ent"
 	"entgo.io/ent/schema/edge"
 	"entgo.io/ent/schema/field"
@@ -19,6 +22,16 @@ func (Vote) Fields() []ent.Field {
 	return []ent.Field{
 		field.Time("created_at").
 			Default(time.Now),
+		field.Int("rank").
+			Optional(), // 1-based position on ranked ballots, 0 otherwise
+		field.Int("score").
+			Optional(), // rating given on score ballots
+		field.String("voter_hash").
+			Optional().
+			Nillable().
+			Sensitive(), // replaces the user edge on anonymous polls
+		field.Int("option_id").
+			StorageKey("poll_option_votes"), // exposed for aggregating counts per option
 	}
 }
 
@@ -27,20 +40,33 @@ func (Vote) Edges() []ent.Edge {
 	return []ent.Edge{
 		edge.From("user", User.Type).
 			Ref("votes").
-			Unique().
-			Required(),
+			Unique(), // unset on anonymous polls
 		edge.From("option", PollOption.Type).
 			Ref("votes").
+			Field("option_id").
 			Unique().
 			Required(),
 	}
 }
 
+// Policy of the Vote.
+func (Vote) Policy() ent.Policy {
+	return privacy.Policy{
+		Query: privacy.QueryPolicy{
+			rule.FilterVisibleVotes(),
+		},
+	}
+}
+
 // Indexes of the Vote.
 func (Vote) Indexes() []ent.Index {
 	return []ent.Index{
 		// Ensure a user can only vote once per poll option
 		index.Edges("user", "option").
 			Unique(),
+		// Same guarantee for anonymous voters
+		index.Fields("voter_hash").
+			Edges("option").
+			Unique(),
 	}
 }
@@ -21,8 +21,18 @@ type User struct {
 	Username string `json:"username,omitempty"`
 	// Email holds the value of the "email" field.
 	Email string `json:"email,omitempty"`
+	// EmailVerified holds the value of the "email_verified" field.
+	EmailVerified bool `json:"email_verified,omitempty"`
 	// Password holds the value of the "password" field.
 	Password string `json:"-"`
+	// TotpSecret holds the value of the "totp_secret" field.
+	TotpSecret *string `json:"-"`
+	// TotpEnabled holds the value of the "totp_enabled" field.
+	TotpEnabled bool `json:"totp_enabled,omitempty"`
+	// TotpLastStep holds the value of the "totp_last_step" field.
+	TotpLastStep int64 `json:"totp_last_step,omitempty"`
+	// WebauthnID holds the value of the "webauthn_id" field.
+	WebauthnID []byte `json:"webauthn_id,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
@@ -39,9 +49,33 @@ type UserEdges struct {
 	Votes []*Vote `json:"votes,omitempty"`
 	// Notifications holds the value of the notifications edge.
 	Notifications []*Notification `json:"notifications,omitempty"`
+	// OwnedTeams holds the value of the owned_teams edge.
+	OwnedTeams []*Team `json:"owned_teams,omitempty"`
+	// Teams holds the value of the teams edge.
+	Teams []*Team `json:"teams,omitempty"`
+	// InvitedPolls holds the value of the invited_polls edge.
+	InvitedPolls []*Poll `json:"invited_polls,omitempty"`
+	// ParticipatedPolls holds the value of the participated_polls edge.
+	ParticipatedPolls []*Poll `json:"participated_polls,omitempty"`
+	// CreatedInvites holds the value of the created_invites edge.
+	CreatedInvites []*Invite `json:"created_invites,omitempty"`
+	// Sessions holds the value of the sessions edge.
+	Sessions []*Session `json:"sessions,omitempty"`
+	// AuthTokens holds the value of the auth_tokens edge.
+	AuthTokens []*AuthToken `json:"auth_tokens,omitempty"`
+	// RecoveryCodes holds the value of the recovery_codes edge.
+	RecoveryCodes []*RecoveryCode `json:"recovery_codes,omitempty"`
+	// Passkeys holds the value of the passkeys edge.
+	Passkeys []*Passkey `json:"passkeys,omitempty"`
+	// PasskeyCeremonies holds the value of the passkey_ceremonies edge.
+	PasskeyCeremonies []*PasskeyCeremony `json:"passkey_ceremonies,omitempty"`
+	// ExternalIdentities holds the value of the external_identities edge.
+	ExternalIdentities []*ExternalIdentity `json:"external_identities,omitempty"`
+	// AuditLogs holds the value of the audit_logs edge.
+	AuditLogs []*AuditLog `json:"audit_logs,omitempty"`
 	// loadedTypes holds the information for reporting if a
 	// type was loaded (or requested) in eager-loading or not.
-	loadedTypes [3]bool
+	loadedTypes [15]bool
 }
 
 // PollsOrErr returns the Polls value or an error if the edge
@@ -71,14 +105,126 @@ func (e UserEdges) NotificationsOrErr() ([]*Notification, error) {
 	return nil, &NotLoadedError{edge: "notifications"}
 }
 
+// OwnedTeamsOrErr returns the OwnedTeams value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) OwnedTeamsOrErr() ([]*Team, error) {
+	if e.loadedTypes[3] {
+		return e.OwnedTeams, nil
+	}
+	return nil, &NotLoadedError{edge: "owned_teams"}
+}
+
+// TeamsOrErr returns the Teams value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) TeamsOrErr() ([]*Team, error) {
+	if e.loadedTypes[4] {
+		return e.Teams, nil
+	}
+	return nil, &NotLoadedError{edge: "teams"}
+}
+
+// InvitedPollsOrErr returns the InvitedPolls value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) InvitedPollsOrErr() ([]*Poll, error) {
+	if e.loadedTypes[5] {
+		return e.InvitedPolls, nil
+	}
+	return nil, &NotLoadedError{edge: "invited_polls"}
+}
+
+// ParticipatedPollsOrErr returns the ParticipatedPolls value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) ParticipatedPollsOrErr() ([]*Poll, error) {
+	if e.loadedTypes[6] {
+		return e.ParticipatedPolls, nil
+	}
+	return nil, &NotLoadedError{edge: "participated_polls"}
+}
+
+// CreatedInvitesOrErr returns the CreatedInvites value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) CreatedInvitesOrErr() ([]*Invite, error) {
+	if e.loadedTypes[7] {
+		return e.CreatedInvites, nil
+	}
+	return nil, &NotLoadedError{edge: "created_invites"}
+}
+
+// SessionsOrErr returns the Sessions value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) SessionsOrErr() ([]*Session, error) {
+	if e.loadedTypes[8] {
+		return e.Sessions, nil
+	}
+	return nil, &NotLoadedError{edge: "sessions"}
+}
+
+// AuthTokensOrErr returns the AuthTokens value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) AuthTokensOrErr() ([]*AuthToken, error) {
+	if e.loadedTypes[9] {
+		return e.AuthTokens, nil
+	}
+	return nil, &NotLoadedError{edge: "auth_tokens"}
+}
+
+// RecoveryCodesOrErr returns the RecoveryCodes value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) RecoveryCodesOrErr() ([]*RecoveryCode, error) {
+	if e.loadedTypes[10] {
+		return e.RecoveryCodes, nil
+	}
+	return nil, &NotLoadedError{edge: "recovery_codes"}
+}
+
+// PasskeysOrErr returns the Passkeys value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PasskeysOrErr() ([]*Passkey, error) {
+	if e.loadedTypes[11] {
+		return e.Passkeys, nil
+	}
+	return nil, &NotLoadedError{edge: "passkeys"}
+}
+
+// PasskeyCeremoniesOrErr returns the PasskeyCeremonies value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) PasskeyCeremoniesOrErr() ([]*PasskeyCeremony, error) {
+	if e.loadedTypes[12] {
+		return e.PasskeyCeremonies, nil
+	}
+	return nil, &NotLoadedError{edge: "passkey_ceremonies"}
+}
+
+// ExternalIdentitiesOrErr returns the ExternalIdentities value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) ExternalIdentitiesOrErr() ([]*ExternalIdentity, error) {
+	if e.loadedTypes[13] {
+		return e.ExternalIdentities, nil
+	}
+	return nil, &NotLoadedError{edge: "external_identities"}
+}
+
+// AuditLogsOrErr returns the AuditLogs value or an error if the edge
+// was not loaded in eager-loading.
+func (e UserEdges) AuditLogsOrErr() ([]*AuditLog, error) {
+	if e.loadedTypes[14] {
+		return e.AuditLogs, nil
+	}
+	return nil, &NotLoadedError{edge: "audit_logs"}
+}
+
 // scanValues returns the types for scanning values from sql.Rows.
 func (*User) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case user.FieldID:
+		case user.FieldWebauthnID:
+			values[i] = new([]byte)
+		case user.FieldEmailVerified, user.FieldTotpEnabled:
+			values[i] = new(sql.NullBool)
+		case user.FieldID, user.FieldTotpLastStep:
 			values[i] = new(sql.NullInt64)
-		case user.FieldUsername, user.FieldEmail, user.FieldPassword:
+		case user.FieldUsername, user.FieldEmail, user.FieldPassword, user.FieldTotpSecret:
 			values[i] = new(sql.NullString)
 		case user.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
@@ -115,12 +261,43 @@ func (u *User) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				u.Email = value.String
 			}
+		case user.FieldEmailVerified:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field email_verified", values[i])
+			} else if value.Valid {
+				u.EmailVerified = value.Bool
+			}
 		case user.FieldPassword:
 			if value, ok := values[i].(*sql.NullString); !ok {
 				return fmt.Errorf("unexpected type %T for field password", values[i])
 			} else if value.Valid {
 				u.Password = value.String
 			}
+		case user.FieldTotpSecret:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_secret", values[i])
+			} else if value.Valid {
+				u.TotpSecret = new(string)
+				*u.TotpSecret = value.String
+			}
+		case user.FieldTotpEnabled:
+			if value, ok := values[i].(*sql.NullBool); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_enabled", values[i])
+			} else if value.Valid {
+				u.TotpEnabled = value.Bool
+			}
+		case user.FieldTotpLastStep:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field totp_last_step", values[i])
+			} else if value.Valid {
+				u.TotpLastStep = value.Int64
+			}
+		case user.FieldWebauthnID:
+			if value, ok := values[i].(*[]byte); !ok {
+				return fmt.Errorf("unexpected type %T for field webauthn_id", values[i])
+			} else if value != nil {
+				u.WebauthnID = *value
+			}
 		case user.FieldCreatedAt:
 			if value, ok := values[i].(*sql.NullTime); !ok {
 				return fmt.Errorf("unexpected type %T for field created_at", values[i])
@@ -155,6 +332,66 @@ func (u *User) QueryNotifications() *NotificationQuery {
 	return NewUserClient(u.config).QueryNotifications(u)
 }
 
+// QueryOwnedTeams queries the "owned_teams" edge of the User entity.
+func (u *User) QueryOwnedTeams() *TeamQuery {
+	return NewUserClient(u.config).QueryOwnedTeams(u)
+}
+
+// QueryTeams queries the "teams" edge of the User entity.
+func (u *User) QueryTeams() *TeamQuery {
+	return NewUserClient(u.config).QueryTeams(u)
+}
+
+// QueryInvitedPolls queries the "invited_polls" edge of the User entity.
+func (u *User) QueryInvitedPolls() *PollQuery {
+	return NewUserClient(u.config).QueryInvitedPolls(u)
+}
+
+// QueryParticipatedPolls queries the "participated_polls" edge of the User entity.
+func (u *User) QueryParticipatedPolls() *PollQuery {
+	return NewUserClient(u.config).QueryParticipatedPolls(u)
+}
+
+// QueryCreatedInvites queries the "created_invites" edge of the User entity.
+func (u *User) QueryCreatedInvites() *InviteQuery {
+	return NewUserClient(u.config).QueryCreatedInvites(u)
+}
+
+// QuerySessions queries the "sessions" edge of the User entity.
+func (u *User) QuerySessions() *SessionQuery {
+	return NewUserClient(u.config).QuerySessions(u)
+}
+
+// QueryAuthTokens queries the "auth_tokens" edge of the User entity.
+func (u *User) QueryAuthTokens() *AuthTokenQuery {
+	return NewUserClient(u.config).QueryAuthTokens(u)
+}
+
+// QueryRecoveryCodes queries the "recovery_codes" edge of the User entity.
+func (u *User) QueryRecoveryCodes() *RecoveryCodeQuery {
+	return NewUserClient(u.config).QueryRecoveryCodes(u)
+}
+
+// QueryPasskeys queries the "passkeys" edge of the User entity.
+func (u *User) QueryPasskeys() *PasskeyQuery {
+	return NewUserClient(u.config).QueryPasskeys(u)
+}
+
+// QueryPasskeyCeremonies queries the "passkey_ceremonies" edge of the User entity.
+func (u *User) QueryPasskeyCeremonies() *PasskeyCeremonyQuery {
+	return NewUserClient(u.config).QueryPasskeyCeremonies(u)
+}
+
+// QueryExternalIdentities queries the "external_identities" edge of the User entity.
+func (u *User) QueryExternalIdentities() *ExternalIdentityQuery {
+	return NewUserClient(u.config).QueryExternalIdentities(u)
+}
+
+// QueryAuditLogs queries the "audit_logs" edge of the User entity.
+func (u *User) QueryAuditLogs() *AuditLogQuery {
+	return NewUserClient(u.config).QueryAuditLogs(u)
+}
+
 // Update returns a builder for updating this User.
 // Note that you need to call User.Unwrap() before calling this method if this User
 // was returned from a transaction, and the transaction was committed or rolled back.
@@ -184,8 +421,22 @@ func (u *User) String() string {
 	builder.WriteString("email=")
 	builder.WriteString(u.Email)
 	builder.WriteString(", ")
+	builder.WriteString("email_verified=")
+	builder.WriteString(fmt.Sprintf("%v", u.EmailVerified))
+	builder.WriteString(", ")
 	builder.WriteString("password=<sensitive>")
 	builder.WriteString(", ")
+	builder.WriteString("totp_secret=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("totp_enabled=")
+	builder.WriteString(fmt.Sprintf("%v", u.TotpEnabled))
+	builder.WriteString(", ")
+	builder.WriteString("totp_last_step=")
+	builder.WriteString(fmt.Sprintf("%v", u.TotpLastStep))
+	builder.WriteString(", ")
+	builder.WriteString("webauthn_id=")
+	builder.WriteString(fmt.Sprintf("%v", u.WebauthnID))
+	builder.WriteString(", ")
 	builder.WriteString("created_at=")
 	builder.WriteString(u.CreatedAt.Format(time.ANSIC))
 	builder.WriteByte(')')
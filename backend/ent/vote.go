@@ -21,12 +21,19 @@ type Vote struct {
 	ID int `json:"id,omitempty"`
 	// CreatedAt holds the value of the "created_at" field.
 	CreatedAt time.Time `json:"created_at,omitempty"`
+	// Rank holds the value of the "rank" field.
+	Rank int `json:"rank,omitempty"`
+	// Score holds the value of the "score" field.
+	Score int `json:"score,omitempty"`
+	// VoterHash holds the value of the "voter_hash" field.
+	VoterHash *string `json:"-"`
+	// OptionID holds the value of the "option_id" field.
+	OptionID int `json:"option_id,omitempty"`
 	// Edges holds the relations/edges for other nodes in the graph.
 	// The values are being populated by the VoteQuery when eager-loading is set.
-	Edges             VoteEdges `json:"edges"`
-	poll_option_votes *int
-	user_votes        *int
-	selectValues      sql.SelectValues
+	Edges        VoteEdges `json:"edges"`
+	user_votes   *int
+	selectValues sql.SelectValues
 }
 
 // VoteEdges holds the relations/edges for other nodes in the graph.
@@ -67,13 +74,13 @@ func (*Vote) scanValues(columns []string) ([]any, error) {
 	values := make([]any, len(columns))
 	for i := range columns {
 		switch columns[i] {
-		case vote.FieldID:
+		case vote.FieldID, vote.FieldRank, vote.FieldScore, vote.FieldOptionID:
 			values[i] = new(sql.NullInt64)
+		case vote.FieldVoterHash:
+			values[i] = new(sql.NullString)
 		case vote.FieldCreatedAt:
 			values[i] = new(sql.NullTime)
-		case vote.ForeignKeys[0]: // poll_option_votes
-			values[i] = new(sql.NullInt64)
-		case vote.ForeignKeys[1]: // user_votes
+		case vote.ForeignKeys[0]: // user_votes
 			values[i] = new(sql.NullInt64)
 		default:
 			values[i] = new(sql.UnknownType)
@@ -102,14 +109,32 @@ func (v *Vote) assignValues(columns []string, values []any) error {
 			} else if value.Valid {
 				v.CreatedAt = value.Time
 			}
-		case vote.ForeignKeys[0]:
+		case vote.FieldRank:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field rank", values[i])
+			} else if value.Valid {
+				v.Rank = int(value.Int64)
+			}
+		case vote.FieldScore:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
-				return fmt.Errorf("unexpected type %T for edge-field poll_option_votes", value)
+				return fmt.Errorf("unexpected type %T for field score", values[i])
 			} else if value.Valid {
-				v.poll_option_votes = new(int)
-				*v.poll_option_votes = int(value.Int64)
+				v.Score = int(value.Int64)
 			}
-		case vote.ForeignKeys[1]:
+		case vote.FieldVoterHash:
+			if value, ok := values[i].(*sql.NullString); !ok {
+				return fmt.Errorf("unexpected type %T for field voter_hash", values[i])
+			} else if value.Valid {
+				v.VoterHash = new(string)
+				*v.VoterHash = value.String
+			}
+		case vote.FieldOptionID:
+			if value, ok := values[i].(*sql.NullInt64); !ok {
+				return fmt.Errorf("unexpected type %T for field option_id", values[i])
+			} else if value.Valid {
+				v.OptionID = int(value.Int64)
+			}
+		case vote.ForeignKeys[0]:
 			if value, ok := values[i].(*sql.NullInt64); !ok {
 				return fmt.Errorf("unexpected type %T for edge-field user_votes", value)
 			} else if value.Valid {
@@ -164,6 +189,17 @@ func (v *Vote) String() string {
 	builder.WriteString(fmt.Sprintf("id=%v, ", v.ID))
 	builder.WriteString("created_at=")
 	builder.WriteString(v.CreatedAt.Format(time.ANSIC))
+	builder.WriteString(", ")
+	builder.WriteString("rank=")
+	builder.WriteString(fmt.Sprintf("%v", v.Rank))
+	builder.WriteString(", ")
+	builder.WriteString("score=")
+	builder.WriteString(fmt.Sprintf("%v", v.Score))
+	builder.WriteString(", ")
+	builder.WriteString("voter_hash=<sensitive>")
+	builder.WriteString(", ")
+	builder.WriteString("option_id=")
+	builder.WriteString(fmt.Sprintf("%v", v.OptionID))
 	builder.WriteByte(')')
 	return builder.String()
 }
@@ -5,6 +5,7 @@ package vote
 import (
 	"time"
 
+	"entgo.io/ent"
 	"entgo.io/ent/dialect/sql"
 	"entgo.io/ent/dialect/sql/sqlgraph"
 )
@@ -16,6 +17,14 @@ const (
 	FieldID = "id"
 	// FieldCreatedAt holds the string denoting the created_at field in the database.
 	FieldCreatedAt = "created_at"
+	// FieldRank holds the string denoting the rank field in the database.
+	FieldRank = "rank"
+	// FieldScore holds the string denoting the score field in the database.
+	FieldScore = "score"
+	// FieldVoterHash holds the string denoting the voter_hash field in the database.
+	FieldVoterHash = "voter_hash"
+	// FieldOptionID holds the string denoting the option_id field in the database.
+	FieldOptionID = "poll_option_votes"
 	// EdgeUser holds the string denoting the user edge name in mutations.
 	EdgeUser = "user"
 	// EdgeOption holds the string denoting the option edge name in mutations.
@@ -42,12 +51,15 @@ const (
 var Columns = []string{
 	FieldID,
 	FieldCreatedAt,
+	FieldRank,
+	FieldScore,
+	FieldVoterHash,
+	FieldOptionID,
 }
 
 // ForeignKeys holds the SQL foreign-keys that are owned by the "votes"
 // table and are not defined as standalone fields in the schema.
 var ForeignKeys = []string{
-	"poll_option_votes",
 	"user_votes",
 }
 
@@ -66,7 +78,14 @@ func ValidColumn(column string) bool {
 	return false
 }
 
+// Note that the variables below are initialized by the runtime
+// package on the initialization of the application. Therefore,
+// it should be imported in the main as follows:
+//
+//	import _ "poll_app/ent/runtime"
 var (
+	Hooks  [1]ent.Hook
+	Policy ent.Policy
 	// DefaultCreatedAt holds the default value on creation for the "created_at" field.
 	DefaultCreatedAt func() time.Time
 )
@@ -84,6 +103,26 @@ func ByCreatedAt(opts ...sql.OrderTermOption) OrderOption {
 	return sql.OrderByField(FieldCreatedAt, opts...).ToFunc()
 }
 
+// ByRank orders the results by the rank field.
+func ByRank(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldRank, opts...).ToFunc()
+}
+
+// ByScore orders the results by the score field.
+func ByScore(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldScore, opts...).ToFunc()
+}
+
+// ByVoterHash orders the results by the voter_hash field.
+func ByVoterHash(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldVoterHash, opts...).ToFunc()
+}
+
+// ByOptionID orders the results by the option_id field.
+func ByOptionID(opts ...sql.OrderTermOption) OrderOption {
+	return sql.OrderByField(FieldOptionID, opts...).ToFunc()
+}
+
 // ByUserField orders the results by user field.
 func ByUserField(field string, opts ...sql.OrderTermOption) OrderOption {
 	return func(s *sql.Selector) {
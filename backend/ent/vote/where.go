@@ -60,6 +60,26 @@ func CreatedAt(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
 }
 
+// Rank applies equality check predicate on the "rank" field. It's identical to RankEQ.
+func Rank(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldRank, v))
+}
+
+// Score applies equality check predicate on the "score" field. It's identical to ScoreEQ.
+func Score(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldScore, v))
+}
+
+// VoterHash applies equality check predicate on the "voter_hash" field. It's identical to VoterHashEQ.
+func VoterHash(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldVoterHash, v))
+}
+
+// OptionID applies equality check predicate on the "option_id" field. It's identical to OptionIDEQ.
+func OptionID(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldOptionID, v))
+}
+
 // CreatedAtEQ applies the EQ predicate on the "created_at" field.
 func CreatedAtEQ(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldEQ(FieldCreatedAt, v))
@@ -100,6 +120,201 @@ func CreatedAtLTE(v time.Time) predicate.Vote {
 	return predicate.Vote(sql.FieldLTE(FieldCreatedAt, v))
 }
 
+// RankEQ applies the EQ predicate on the "rank" field.
+func RankEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldRank, v))
+}
+
+// RankNEQ applies the NEQ predicate on the "rank" field.
+func RankNEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldRank, v))
+}
+
+// RankIn applies the In predicate on the "rank" field.
+func RankIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldRank, vs...))
+}
+
+// RankNotIn applies the NotIn predicate on the "rank" field.
+func RankNotIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldRank, vs...))
+}
+
+// RankGT applies the GT predicate on the "rank" field.
+func RankGT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldRank, v))
+}
+
+// RankGTE applies the GTE predicate on the "rank" field.
+func RankGTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldRank, v))
+}
+
+// RankLT applies the LT predicate on the "rank" field.
+func RankLT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldRank, v))
+}
+
+// RankLTE applies the LTE predicate on the "rank" field.
+func RankLTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldRank, v))
+}
+
+// RankIsNil applies the IsNil predicate on the "rank" field.
+func RankIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldRank))
+}
+
+// RankNotNil applies the NotNil predicate on the "rank" field.
+func RankNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldRank))
+}
+
+// ScoreEQ applies the EQ predicate on the "score" field.
+func ScoreEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldScore, v))
+}
+
+// ScoreNEQ applies the NEQ predicate on the "score" field.
+func ScoreNEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldScore, v))
+}
+
+// ScoreIn applies the In predicate on the "score" field.
+func ScoreIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldScore, vs...))
+}
+
+// ScoreNotIn applies the NotIn predicate on the "score" field.
+func ScoreNotIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldScore, vs...))
+}
+
+// ScoreGT applies the GT predicate on the "score" field.
+func ScoreGT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldScore, v))
+}
+
+// ScoreGTE applies the GTE predicate on the "score" field.
+func ScoreGTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldScore, v))
+}
+
+// ScoreLT applies the LT predicate on the "score" field.
+func ScoreLT(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldScore, v))
+}
+
+// ScoreLTE applies the LTE predicate on the "score" field.
+func ScoreLTE(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldScore, v))
+}
+
+// ScoreIsNil applies the IsNil predicate on the "score" field.
+func ScoreIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldScore))
+}
+
+// ScoreNotNil applies the NotNil predicate on the "score" field.
+func ScoreNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldScore))
+}
+
+// VoterHashEQ applies the EQ predicate on the "voter_hash" field.
+func VoterHashEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldVoterHash, v))
+}
+
+// VoterHashNEQ applies the NEQ predicate on the "voter_hash" field.
+func VoterHashNEQ(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldVoterHash, v))
+}
+
+// VoterHashIn applies the In predicate on the "voter_hash" field.
+func VoterHashIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldVoterHash, vs...))
+}
+
+// VoterHashNotIn applies the NotIn predicate on the "voter_hash" field.
+func VoterHashNotIn(vs ...string) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldVoterHash, vs...))
+}
+
+// VoterHashGT applies the GT predicate on the "voter_hash" field.
+func VoterHashGT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGT(FieldVoterHash, v))
+}
+
+// VoterHashGTE applies the GTE predicate on the "voter_hash" field.
+func VoterHashGTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldGTE(FieldVoterHash, v))
+}
+
+// VoterHashLT applies the LT predicate on the "voter_hash" field.
+func VoterHashLT(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLT(FieldVoterHash, v))
+}
+
+// VoterHashLTE applies the LTE predicate on the "voter_hash" field.
+func VoterHashLTE(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldLTE(FieldVoterHash, v))
+}
+
+// VoterHashContains applies the Contains predicate on the "voter_hash" field.
+func VoterHashContains(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContains(FieldVoterHash, v))
+}
+
+// VoterHashHasPrefix applies the HasPrefix predicate on the "voter_hash" field.
+func VoterHashHasPrefix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasPrefix(FieldVoterHash, v))
+}
+
+// VoterHashHasSuffix applies the HasSuffix predicate on the "voter_hash" field.
+func VoterHashHasSuffix(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldHasSuffix(FieldVoterHash, v))
+}
+
+// VoterHashIsNil applies the IsNil predicate on the "voter_hash" field.
+func VoterHashIsNil() predicate.Vote {
+	return predicate.Vote(sql.FieldIsNull(FieldVoterHash))
+}
+
+// VoterHashNotNil applies the NotNil predicate on the "voter_hash" field.
+func VoterHashNotNil() predicate.Vote {
+	return predicate.Vote(sql.FieldNotNull(FieldVoterHash))
+}
+
+// VoterHashEqualFold applies the EqualFold predicate on the "voter_hash" field.
+func VoterHashEqualFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldEqualFold(FieldVoterHash, v))
+}
+
+// VoterHashContainsFold applies the ContainsFold predicate on the "voter_hash" field.
+func VoterHashContainsFold(v string) predicate.Vote {
+	return predicate.Vote(sql.FieldContainsFold(FieldVoterHash, v))
+}
+
+// OptionIDEQ applies the EQ predicate on the "option_id" field.
+func OptionIDEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldEQ(FieldOptionID, v))
+}
+
+// OptionIDNEQ applies the NEQ predicate on the "option_id" field.
+func OptionIDNEQ(v int) predicate.Vote {
+	return predicate.Vote(sql.FieldNEQ(FieldOptionID, v))
+}
+
+// OptionIDIn applies the In predicate on the "option_id" field.
+func OptionIDIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldIn(FieldOptionID, vs...))
+}
+
+// OptionIDNotIn applies the NotIn predicate on the "option_id" field.
+func OptionIDNotIn(vs ...int) predicate.Vote {
+	return predicate.Vote(sql.FieldNotIn(FieldOptionID, vs...))
+}
+
 // HasUser applies the HasEdge predicate on the "user" edge.
 func HasUser() predicate.Vote {
 	return predicate.Vote(func(s *sql.Selector) {
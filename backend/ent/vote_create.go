@@ -36,23 +36,73 @@ func (vc *VoteCreate) SetNillableCreatedAt(t *time.Time) *VoteCreate {
 	return vc
 }
 
+// SetRank sets the "rank" field.
+func (vc *VoteCreate) SetRank(i int) *VoteCreate {
+	vc.mutation.SetRank(i)
+	return vc
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (vc *VoteCreate) SetNillableRank(i *int) *VoteCreate {
+	if i != nil {
+		vc.SetRank(*i)
+	}
+	return vc
+}
+
+// SetScore sets the "score" field.
+func (vc *VoteCreate) SetScore(i int) *VoteCreate {
+	vc.mutation.SetScore(i)
+	return vc
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (vc *VoteCreate) SetNillableScore(i *int) *VoteCreate {
+	if i != nil {
+		vc.SetScore(*i)
+	}
+	return vc
+}
+
+// SetVoterHash sets the "voter_hash" field.
+func (vc *VoteCreate) SetVoterHash(s string) *VoteCreate {
+	vc.mutation.SetVoterHash(s)
+	return vc
+}
+
+// SetNillableVoterHash sets the "voter_hash" field if the given value is not nil.
+func (vc *VoteCreate) SetNillableVoterHash(s *string) *VoteCreate {
+	if s != nil {
+		vc.SetVoterHash(*s)
+	}
+	return vc
+}
+
+// SetOptionID sets the "option_id" field.
+func (vc *VoteCreate) SetOptionID(i int) *VoteCreate {
+	vc.mutation.SetOptionID(i)
+	return vc
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (vc *VoteCreate) SetUserID(id int) *VoteCreate {
 	vc.mutation.SetUserID(id)
 	return vc
 }
 
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (vc *VoteCreate) SetNillableUserID(id *int) *VoteCreate {
+	if id != nil {
+		vc = vc.SetUserID(*id)
+	}
+	return vc
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (vc *VoteCreate) SetUser(u *User) *VoteCreate {
 	return vc.SetUserID(u.ID)
 }
 
-// SetOptionID sets the "option" edge to the PollOption entity by ID.
-func (vc *VoteCreate) SetOptionID(id int) *VoteCreate {
-	vc.mutation.SetOptionID(id)
-	return vc
-}
-
 // SetOption sets the "option" edge to the PollOption entity.
 func (vc *VoteCreate) SetOption(p *PollOption) *VoteCreate {
 	return vc.SetOptionID(p.ID)
@@ -65,7 +115,9 @@ func (vc *VoteCreate) Mutation() *VoteMutation {
 
 // Save creates the Vote in the database.
 func (vc *VoteCreate) Save(ctx context.Context) (*Vote, error) {
-	vc.defaults()
+	if err := vc.defaults(); err != nil {
+		return nil, err
+	}
 	return withHooks(ctx, vc.sqlSave, vc.mutation, vc.hooks)
 }
 
@@ -92,11 +144,15 @@ func (vc *VoteCreate) ExecX(ctx context.Context) {
 }
 
 // defaults sets the default values of the builder before save.
-func (vc *VoteCreate) defaults() {
+func (vc *VoteCreate) defaults() error {
 	if _, ok := vc.mutation.CreatedAt(); !ok {
+		if vote.DefaultCreatedAt == nil {
+			return fmt.Errorf("ent: uninitialized vote.DefaultCreatedAt (forgotten import ent/runtime?)")
+		}
 		v := vote.DefaultCreatedAt()
 		vc.mutation.SetCreatedAt(v)
 	}
+	return nil
 }
 
 // check runs all checks and user-defined validators on the builder.
@@ -104,8 +160,8 @@ func (vc *VoteCreate) check() error {
 	if _, ok := vc.mutation.CreatedAt(); !ok {
 		return &ValidationError{Name: "created_at", err: errors.New(`ent: missing required field "Vote.created_at"`)}
 	}
-	if len(vc.mutation.UserIDs()) == 0 {
-		return &ValidationError{Name: "user", err: errors.New(`ent: missing required edge "Vote.user"`)}
+	if _, ok := vc.mutation.OptionID(); !ok {
+		return &ValidationError{Name: "option_id", err: errors.New(`ent: missing required field "Vote.option_id"`)}
 	}
 	if len(vc.mutation.OptionIDs()) == 0 {
 		return &ValidationError{Name: "option", err: errors.New(`ent: missing required edge "Vote.option"`)}
@@ -140,6 +196,18 @@ func (vc *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
 		_node.CreatedAt = value
 	}
+	if value, ok := vc.mutation.Rank(); ok {
+		_spec.SetField(vote.FieldRank, field.TypeInt, value)
+		_node.Rank = value
+	}
+	if value, ok := vc.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+		_node.Score = value
+	}
+	if value, ok := vc.mutation.VoterHash(); ok {
+		_spec.SetField(vote.FieldVoterHash, field.TypeString, value)
+		_node.VoterHash = &value
+	}
 	if nodes := vc.mutation.UserIDs(); len(nodes) > 0 {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -171,7 +239,7 @@ func (vc *VoteCreate) createSpec() (*Vote, *sqlgraph.CreateSpec) {
 		for _, k := range nodes {
 			edge.Target.Nodes = append(edge.Target.Nodes, k)
 		}
-		_node.poll_option_votes = &nodes[0]
+		_node.OptionID = nodes[0]
 		_spec.Edges = append(_spec.Edges, edge)
 	}
 	return _node, _spec
@@ -4,6 +4,7 @@ package ent
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"poll_app/ent/polloption"
@@ -400,6 +401,12 @@ func (vq *VoteQuery) prepareQuery(ctx context.Context) error {
 		}
 		vq.sql = prev
 	}
+	if vote.Policy == nil {
+		return errors.New("ent: uninitialized vote.Policy (forgotten import ent/runtime?)")
+	}
+	if err := vote.Policy.EvalQuery(ctx, vq); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -413,7 +420,7 @@ func (vq *VoteQuery) sqlAll(ctx context.Context, hooks ...queryHook) ([]*Vote, e
 			vq.withOption != nil,
 		}
 	)
-	if vq.withUser != nil || vq.withOption != nil {
+	if vq.withUser != nil {
 		withFKs = true
 	}
 	if withFKs {
@@ -488,10 +495,7 @@ func (vq *VoteQuery) loadOption(ctx context.Context, query *PollOptionQuery, nod
 	ids := make([]int, 0, len(nodes))
 	nodeids := make(map[int][]*Vote)
 	for i := range nodes {
-		if nodes[i].poll_option_votes == nil {
-			continue
-		}
-		fk := *nodes[i].poll_option_votes
+		fk := nodes[i].OptionID
 		if _, ok := nodeids[fk]; !ok {
 			ids = append(ids, fk)
 		}
@@ -508,7 +512,7 @@ func (vq *VoteQuery) loadOption(ctx context.Context, query *PollOptionQuery, nod
 	for _, n := range neighbors {
 		nodes, ok := nodeids[n.ID]
 		if !ok {
-			return fmt.Errorf(`unexpected foreign-key "poll_option_votes" returned %v`, n.ID)
+			return fmt.Errorf(`unexpected foreign-key "option_id" returned %v`, n.ID)
 		}
 		for i := range nodes {
 			assign(nodes[i], n)
@@ -542,6 +546,9 @@ func (vq *VoteQuery) querySpec() *sqlgraph.QuerySpec {
 				_spec.Node.Columns = append(_spec.Node.Columns, fields[i])
 			}
 		}
+		if vq.withOption != nil {
+			_spec.Node.AddColumnOnce(vote.FieldOptionID)
+		}
 	}
 	if ps := vq.predicates; len(ps) > 0 {
 		_spec.Predicate = func(selector *sql.Selector) {
@@ -44,23 +44,113 @@ func (vu *VoteUpdate) SetNillableCreatedAt(t *time.Time) *VoteUpdate {
 	return vu
 }
 
+// SetRank sets the "rank" field.
+func (vu *VoteUpdate) SetRank(i int) *VoteUpdate {
+	vu.mutation.ResetRank()
+	vu.mutation.SetRank(i)
+	return vu
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (vu *VoteUpdate) SetNillableRank(i *int) *VoteUpdate {
+	if i != nil {
+		vu.SetRank(*i)
+	}
+	return vu
+}
+
+// AddRank adds i to the "rank" field.
+func (vu *VoteUpdate) AddRank(i int) *VoteUpdate {
+	vu.mutation.AddRank(i)
+	return vu
+}
+
+// ClearRank clears the value of the "rank" field.
+func (vu *VoteUpdate) ClearRank() *VoteUpdate {
+	vu.mutation.ClearRank()
+	return vu
+}
+
+// SetScore sets the "score" field.
+func (vu *VoteUpdate) SetScore(i int) *VoteUpdate {
+	vu.mutation.ResetScore()
+	vu.mutation.SetScore(i)
+	return vu
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (vu *VoteUpdate) SetNillableScore(i *int) *VoteUpdate {
+	if i != nil {
+		vu.SetScore(*i)
+	}
+	return vu
+}
+
+// AddScore adds i to the "score" field.
+func (vu *VoteUpdate) AddScore(i int) *VoteUpdate {
+	vu.mutation.AddScore(i)
+	return vu
+}
+
+// ClearScore clears the value of the "score" field.
+func (vu *VoteUpdate) ClearScore() *VoteUpdate {
+	vu.mutation.ClearScore()
+	return vu
+}
+
+// SetVoterHash sets the "voter_hash" field.
+func (vu *VoteUpdate) SetVoterHash(s string) *VoteUpdate {
+	vu.mutation.SetVoterHash(s)
+	return vu
+}
+
+// SetNillableVoterHash sets the "voter_hash" field if the given value is not nil.
+func (vu *VoteUpdate) SetNillableVoterHash(s *string) *VoteUpdate {
+	if s != nil {
+		vu.SetVoterHash(*s)
+	}
+	return vu
+}
+
+// ClearVoterHash clears the value of the "voter_hash" field.
+func (vu *VoteUpdate) ClearVoterHash() *VoteUpdate {
+	vu.mutation.ClearVoterHash()
+	return vu
+}
+
+// SetOptionID sets the "option_id" field.
+func (vu *VoteUpdate) SetOptionID(i int) *VoteUpdate {
+	vu.mutation.SetOptionID(i)
+	return vu
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (vu *VoteUpdate) SetNillableOptionID(i *int) *VoteUpdate {
+	if i != nil {
+		vu.SetOptionID(*i)
+	}
+	return vu
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (vu *VoteUpdate) SetUserID(id int) *VoteUpdate {
 	vu.mutation.SetUserID(id)
 	return vu
 }
 
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (vu *VoteUpdate) SetNillableUserID(id *int) *VoteUpdate {
+	if id != nil {
+		vu = vu.SetUserID(*id)
+	}
+	return vu
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (vu *VoteUpdate) SetUser(u *User) *VoteUpdate {
 	return vu.SetUserID(u.ID)
 }
 
-// SetOptionID sets the "option" edge to the PollOption entity by ID.
-func (vu *VoteUpdate) SetOptionID(id int) *VoteUpdate {
-	vu.mutation.SetOptionID(id)
-	return vu
-}
-
 // SetOption sets the "option" edge to the PollOption entity.
 func (vu *VoteUpdate) SetOption(p *PollOption) *VoteUpdate {
 	return vu.SetOptionID(p.ID)
@@ -112,9 +202,6 @@ func (vu *VoteUpdate) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (vu *VoteUpdate) check() error {
-	if vu.mutation.UserCleared() && len(vu.mutation.UserIDs()) > 0 {
-		return errors.New(`ent: clearing a required unique edge "Vote.user"`)
-	}
 	if vu.mutation.OptionCleared() && len(vu.mutation.OptionIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.option"`)
 	}
@@ -136,6 +223,30 @@ func (vu *VoteUpdate) sqlSave(ctx context.Context) (n int, err error) {
 	if value, ok := vu.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := vu.mutation.Rank(); ok {
+		_spec.SetField(vote.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := vu.mutation.AddedRank(); ok {
+		_spec.AddField(vote.FieldRank, field.TypeInt, value)
+	}
+	if vu.mutation.RankCleared() {
+		_spec.ClearField(vote.FieldRank, field.TypeInt)
+	}
+	if value, ok := vu.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+	}
+	if value, ok := vu.mutation.AddedScore(); ok {
+		_spec.AddField(vote.FieldScore, field.TypeInt, value)
+	}
+	if vu.mutation.ScoreCleared() {
+		_spec.ClearField(vote.FieldScore, field.TypeInt)
+	}
+	if value, ok := vu.mutation.VoterHash(); ok {
+		_spec.SetField(vote.FieldVoterHash, field.TypeString, value)
+	}
+	if vu.mutation.VoterHashCleared() {
+		_spec.ClearField(vote.FieldVoterHash, field.TypeString)
+	}
 	if vu.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -228,23 +339,113 @@ func (vuo *VoteUpdateOne) SetNillableCreatedAt(t *time.Time) *VoteUpdateOne {
 	return vuo
 }
 
+// SetRank sets the "rank" field.
+func (vuo *VoteUpdateOne) SetRank(i int) *VoteUpdateOne {
+	vuo.mutation.ResetRank()
+	vuo.mutation.SetRank(i)
+	return vuo
+}
+
+// SetNillableRank sets the "rank" field if the given value is not nil.
+func (vuo *VoteUpdateOne) SetNillableRank(i *int) *VoteUpdateOne {
+	if i != nil {
+		vuo.SetRank(*i)
+	}
+	return vuo
+}
+
+// AddRank adds i to the "rank" field.
+func (vuo *VoteUpdateOne) AddRank(i int) *VoteUpdateOne {
+	vuo.mutation.AddRank(i)
+	return vuo
+}
+
+// ClearRank clears the value of the "rank" field.
+func (vuo *VoteUpdateOne) ClearRank() *VoteUpdateOne {
+	vuo.mutation.ClearRank()
+	return vuo
+}
+
+// SetScore sets the "score" field.
+func (vuo *VoteUpdateOne) SetScore(i int) *VoteUpdateOne {
+	vuo.mutation.ResetScore()
+	vuo.mutation.SetScore(i)
+	return vuo
+}
+
+// SetNillableScore sets the "score" field if the given value is not nil.
+func (vuo *VoteUpdateOne) SetNillableScore(i *int) *VoteUpdateOne {
+	if i != nil {
+		vuo.SetScore(*i)
+	}
+	return vuo
+}
+
+// AddScore adds i to the "score" field.
+func (vuo *VoteUpdateOne) AddScore(i int) *VoteUpdateOne {
+	vuo.mutation.AddScore(i)
+	return vuo
+}
+
+// ClearScore clears the value of the "score" field.
+func (vuo *VoteUpdateOne) ClearScore() *VoteUpdateOne {
+	vuo.mutation.ClearScore()
+	return vuo
+}
+
+// SetVoterHash sets the "voter_hash" field.
+func (vuo *VoteUpdateOne) SetVoterHash(s string) *VoteUpdateOne {
+	vuo.mutation.SetVoterHash(s)
+	return vuo
+}
+
+// SetNillableVoterHash sets the "voter_hash" field if the given value is not nil.
+func (vuo *VoteUpdateOne) SetNillableVoterHash(s *string) *VoteUpdateOne {
+	if s != nil {
+		vuo.SetVoterHash(*s)
+	}
+	return vuo
+}
+
+// ClearVoterHash clears the value of the "voter_hash" field.
+func (vuo *VoteUpdateOne) ClearVoterHash() *VoteUpdateOne {
+	vuo.mutation.ClearVoterHash()
+	return vuo
+}
+
+// SetOptionID sets the "option_id" field.
+func (vuo *VoteUpdateOne) SetOptionID(i int) *VoteUpdateOne {
+	vuo.mutation.SetOptionID(i)
+	return vuo
+}
+
+// SetNillableOptionID sets the "option_id" field if the given value is not nil.
+func (vuo *VoteUpdateOne) SetNillableOptionID(i *int) *VoteUpdateOne {
+	if i != nil {
+		vuo.SetOptionID(*i)
+	}
+	return vuo
+}
+
 // SetUserID sets the "user" edge to the User entity by ID.
 func (vuo *VoteUpdateOne) SetUserID(id int) *VoteUpdateOne {
 	vuo.mutation.SetUserID(id)
 	return vuo
 }
 
+// SetNillableUserID sets the "user" edge to the User entity by ID if the given value is not nil.
+func (vuo *VoteUpdateOne) SetNillableUserID(id *int) *VoteUpdateOne {
+	if id != nil {
+		vuo = vuo.SetUserID(*id)
+	}
+	return vuo
+}
+
 // SetUser sets the "user" edge to the User entity.
 func (vuo *VoteUpdateOne) SetUser(u *User) *VoteUpdateOne {
 	return vuo.SetUserID(u.ID)
 }
 
-// SetOptionID sets the "option" edge to the PollOption entity by ID.
-func (vuo *VoteUpdateOne) SetOptionID(id int) *VoteUpdateOne {
-	vuo.mutation.SetOptionID(id)
-	return vuo
-}
-
 // SetOption sets the "option" edge to the PollOption entity.
 func (vuo *VoteUpdateOne) SetOption(p *PollOption) *VoteUpdateOne {
 	return vuo.SetOptionID(p.ID)
@@ -309,9 +510,6 @@ func (vuo *VoteUpdateOne) ExecX(ctx context.Context) {
 
 // check runs all checks and user-defined validators on the builder.
 func (vuo *VoteUpdateOne) check() error {
-	if vuo.mutation.UserCleared() && len(vuo.mutation.UserIDs()) > 0 {
-		return errors.New(`ent: clearing a required unique edge "Vote.user"`)
-	}
 	if vuo.mutation.OptionCleared() && len(vuo.mutation.OptionIDs()) > 0 {
 		return errors.New(`ent: clearing a required unique edge "Vote.option"`)
 	}
@@ -350,6 +548,30 @@ func (vuo *VoteUpdateOne) sqlSave(ctx context.Context) (_node *Vote, err error)
 	if value, ok := vuo.mutation.CreatedAt(); ok {
 		_spec.SetField(vote.FieldCreatedAt, field.TypeTime, value)
 	}
+	if value, ok := vuo.mutation.Rank(); ok {
+		_spec.SetField(vote.FieldRank, field.TypeInt, value)
+	}
+	if value, ok := vuo.mutation.AddedRank(); ok {
+		_spec.AddField(vote.FieldRank, field.TypeInt, value)
+	}
+	if vuo.mutation.RankCleared() {
+		_spec.ClearField(vote.FieldRank, field.TypeInt)
+	}
+	if value, ok := vuo.mutation.Score(); ok {
+		_spec.SetField(vote.FieldScore, field.TypeInt, value)
+	}
+	if value, ok := vuo.mutation.AddedScore(); ok {
+		_spec.AddField(vote.FieldScore, field.TypeInt, value)
+	}
+	if vuo.mutation.ScoreCleared() {
+		_spec.ClearField(vote.FieldScore, field.TypeInt)
+	}
+	if value, ok := vuo.mutation.VoterHash(); ok {
+		_spec.SetField(vote.FieldVoterHash, field.TypeString, value)
+	}
+	if vuo.mutation.VoterHashCleared() {
+		_spec.ClearField(vote.FieldVoterHash, field.TypeString)
+	}
 	if vuo.mutation.UserCleared() {
 		edge := &sqlgraph.EdgeSpec{
 			Rel:     sqlgraph.M2O,
@@ -0,0 +1,81 @@
+package events
+
+import "sync"
+
+// feedBuffer is how many messages a connection may fall behind before it is
+// dropped. Dropped subscribers reconnect and resynchronise.
+const feedBuffer = 16
+
+// Feed delivers messages to every open connection of a user, such as one per
+// browser tab. Unlike Hub it carries payloads and does not coalesce them.
+type Feed struct {
+	mu   sync.Mutex
+	subs map[int]map[chan any]struct{}
+}
+
+func NewFeed() *Feed {
+	return &Feed{subs: make(map[int]map[chan any]struct{})}
+}
+
+// Publish sends msg to all of the user's subscribers. It never blocks: a
+// subscriber whose buffer is full has its channel closed instead.
+func (f *Feed) Publish(userID int, msg any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	for ch := range f.subs[userID] {
+		select {
+		case ch <- msg:
+		default:
+			f.remove(userID, ch)
+			close(ch)
+		}
+	}
+}
+
+// HasSubscribers reports whether the user has any open connection
+func (f *Feed) HasSubscribers(userID int) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return len(f.subs[userID]) > 0
+}
+
+// CloseAll closes the channels of all subscribers
+func (f *Feed) CloseAll() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for userID, subs := range f.subs {
+		for ch := range subs {
+			close(ch)
+		}
+		delete(f.subs, userID)
+	}
+}
+
+// Subscribe registers a connection for the user's messages. The channel is
+// closed if the subscriber falls behind or by CloseAll. cancel must be called
+// once the subscriber is done.
+func (f *Feed) Subscribe(userID int) (msgs <-chan any, cancel func()) {
+	ch := make(chan any, feedBuffer)
+
+	f.mu.Lock()
+	if f.subs[userID] == nil {
+		f.subs[userID] = make(map[chan any]struct{})
+	}
+	f.subs[userID][ch] = struct{}{}
+	f.mu.Unlock()
+
+	cancel = func() {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.remove(userID, ch)
+	}
+	return ch, cancel
+}
+
+func (f *Feed) remove(userID int, ch chan any) {
+	delete(f.subs[userID], ch)
+	if len(f.subs[userID]) == 0 {
+		delete(f.subs, userID)
+	}
+}
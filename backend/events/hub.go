@@ -0,0 +1,140 @@
+// Package events is an in-process pub/sub hub announcing changes to polls.
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// Event kinds
+const (
+	KindUpdated = "updated"
+	KindDeleted = "deleted"
+)
+
+// Event announces that a poll changed. Subscribers fetch the new state
+// themselves, so events carry no payload.
+type Event struct {
+	// ID increases with every event published on the hub. IDs start from the
+	// hub's creation time so a client resuming on another instance is not
+	// mistaken for being up to date.
+	ID     uint64
+	PollID int
+	Kind   string
+}
+
+// replayWindow is how long the hub remembers a poll's last event once nobody
+// is subscribed to it, for clients reconnecting with a Last-Event-ID. Later
+// they are sent the poll again, as if they had missed a change.
+const replayWindow = 5 * time.Minute
+
+// clock tells the hub the time, and is replaced in tests
+var clock = time.Now
+
+// lastEvent is the last event of a poll
+type lastEvent struct {
+	id uint64
+	// at is when the event was published or, if later, when the poll's last
+	// subscriber left
+	at time.Time
+}
+
+// Hub fans events out to the subscribers of each poll.
+type Hub struct {
+	mu     sync.Mutex
+	lastID uint64
+	latest map[int]lastEvent // by poll ID
+	subs   map[int]map[chan Event]struct{}
+	swept  time.Time
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		lastID: uint64(time.Now().UnixNano()),
+		latest: make(map[int]lastEvent),
+		subs:   make(map[int]map[chan Event]struct{}),
+		swept:  clock(),
+	}
+}
+
+// sweep forgets the last events of polls nobody has subscribed to within
+// replayWindow, at most once a window, so the hub does not grow with every
+// poll that ever changed
+func (h *Hub) sweep() {
+	now := clock()
+	if now.Sub(h.swept) < replayWindow {
+		return
+	}
+	h.swept = now
+	for pollID, last := range h.latest {
+		if len(h.subs[pollID]) == 0 && now.Sub(last.at) > replayWindow {
+			delete(h.latest, pollID)
+		}
+	}
+}
+
+// Publish announces a change to a poll. It never blocks: a subscriber that
+// has not consumed its previous event only keeps the newest one.
+func (h *Hub) Publish(pollID int, kind string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.publish(pollID, kind)
+}
+
+// PublishAll announces a change to every poll with subscribers
+func (h *Hub) PublishAll(kind string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for pollID := range h.subs {
+		h.publish(pollID, kind)
+	}
+}
+
+func (h *Hub) publish(pollID int, kind string) {
+	h.sweep()
+	h.lastID++
+	e := Event{ID: h.lastID, PollID: pollID, Kind: kind}
+	h.latest[pollID] = lastEvent{id: e.ID, at: clock()}
+	for ch := range h.subs[pollID] {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- e
+	}
+}
+
+// Subscribe registers for events on a poll. It also returns the ID of the
+// poll's last event, or 0 if there was none within replayWindow, so callers
+// can tell whether a client resuming from a Last-Event-ID missed anything. cancel must be called
+// once the subscriber is done.
+func (h *Hub) Subscribe(pollID int) (events <-chan Event, lastID uint64, cancel func()) {
+	ch := make(chan Event, 1)
+
+	h.mu.Lock()
+	h.sweep()
+	if h.subs[pollID] == nil {
+		h.subs[pollID] = make(map[chan Event]struct{})
+	}
+	h.subs[pollID][ch] = struct{}{}
+	lastID = h.latest[pollID].id
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel = func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subs[pollID], ch)
+			if len(h.subs[pollID]) == 0 {
+				delete(h.subs, pollID)
+				// The replay window starts once nobody is left to reconnect
+				if last, ok := h.latest[pollID]; ok {
+					last.at = clock()
+					h.latest[pollID] = last
+				}
+			}
+		})
+	}
+	return ch, lastID, cancel
+}
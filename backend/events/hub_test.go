@@ -0,0 +1,53 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+// TestHubForgetsIdlePolls checks that a poll's last event is kept for
+// subscribers and for clients reconnecting within the replay window, and
+// dropped afterwards
+func TestHubForgetsIdlePolls(t *testing.T) {
+	now := time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)
+	clock = func() time.Time { return now }
+	t.Cleanup(func() { clock = time.Now })
+	h := NewHub()
+
+	// A poll nobody watches is forgotten once the window has passed
+	h.Publish(1, KindUpdated)
+	_, last, cancel := h.Subscribe(2)
+	if last != 0 {
+		t.Fatalf("last ID of a new poll = %d, want 0", last)
+	}
+	h.Publish(2, KindUpdated)
+	now = now.Add(replayWindow + time.Second)
+	h.Publish(3, KindUpdated)
+	if _, ok := h.latest[1]; ok {
+		t.Error("kept the last event of a poll without subscribers")
+	}
+	_, last, cancelAgain := h.Subscribe(2)
+	if last == 0 {
+		t.Error("forgot the last event of a poll with subscribers")
+	}
+	cancelAgain()
+
+	// Once its last subscriber leaves, a client can still resume for a window
+	cancel()
+	now = now.Add(replayWindow - time.Second)
+	_, last, cancel = h.Subscribe(2)
+	if last == 0 {
+		t.Error("forgot the last event within the replay window")
+	}
+	cancel()
+
+	now = now.Add(replayWindow + time.Second)
+	h.Publish(3, KindUpdated)
+	if len(h.latest) != 1 {
+		t.Errorf("remembers %d polls, want only the one just published", len(h.latest))
+	}
+	if _, last, cancel = h.Subscribe(2); last != 0 {
+		t.Errorf("last ID after the window = %d, want 0", last)
+	}
+	cancel()
+}
@@ -0,0 +1,85 @@
+package events
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// Channel is the Postgres notification channel shared by all instances
+const Channel = "poll_app_events"
+
+// Message is an event relayed between backend instances. Postgres limits
+// notification payloads to 8000 bytes, so messages carry IDs rather than
+// the changed rows.
+type Message struct {
+	// PollID and Kind are set for poll changes
+	PollID int    `json:"poll_id,omitempty"`
+	Kind   string `json:"kind,omitempty"`
+	// UserID and Data are set for messages to a user's connections
+	UserID int             `json:"user_id,omitempty"`
+	Data   json.RawMessage `json:"data,omitempty"`
+}
+
+// Execer runs a statement. Both ent clients and transactions are Execers.
+type Execer interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+}
+
+// Notify sends m to every listening instance, including this one. Sent within
+// a transaction, m is only delivered if the transaction commits.
+func Notify(ctx context.Context, ex Execer, m Message) error {
+	b, err := json.Marshal(m)
+	if err != nil {
+		return err
+	}
+	_, err = ex.ExecContext(ctx, "SELECT pg_notify($1, $2)", Channel, string(b))
+	return err
+}
+
+// Listen passes the messages sent with Notify to deliver until ctx is done.
+// The connection is re-established whenever it drops. Messages sent while
+// disconnected are lost, so resync is called after each reconnect.
+func Listen(ctx context.Context, dsn string, deliver func(Message), resync func()) error {
+	l := pq.NewListener(dsn, time.Second, time.Minute, func(ev pq.ListenerEventType, err error) {
+		switch ev {
+		case pq.ListenerEventDisconnected:
+			log.Printf("event relay disconnected: %v", err)
+		case pq.ListenerEventConnectionAttemptFailed:
+			log.Printf("event relay failed to connect: %v", err)
+		case pq.ListenerEventReconnected:
+			log.Printf("event relay reconnected")
+		}
+	})
+	defer l.Close()
+
+	if err := l.Listen(Channel); err != nil {
+		return err
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case n := <-l.Notify:
+			// A nil notification follows a reconnect
+			if n == nil {
+				resync()
+				continue
+			}
+			var m Message
+			if err := json.Unmarshal([]byte(n.Extra), &m); err != nil {
+				log.Printf("event relay: invalid message: %v", err)
+				continue
+			}
+			deliver(m)
+		case <-time.After(90 * time.Second):
+			// Notice dead connections that would otherwise go unreported
+			go l.Ping()
+		}
+	}
+}
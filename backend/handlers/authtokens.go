@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/user"
+	"poll_app/mail"
+)
+
+var mailer mail.Mailer = &mail.LogMailer{}
+
+// SetMailer sets how emails are delivered. By default they are only logged.
+func SetMailer(m mail.Mailer) {
+	mailer = m
+}
+
+var frontendURL = "http://localhost:3000"
+
+// SetFrontendURL sets the base URL of links in emails
+func SetFrontendURL(url string) {
+	frontendURL = url
+}
+
+// sendMail delivers m in the background, so response times do not reveal
+// whether an email was sent
+func sendMail(m mail.Message) {
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		if err := mailer.Send(ctx, m); err != nil {
+			log.Printf("failed to send mail: %v", err)
+		}
+	}()
+}
+
+var errInvalidAuthToken = errors.New("invalid or expired token")
+
+// createAuthToken stores a new single-use token of kind for u and returns it.
+// Earlier unused tokens of the same kind stop working.
+func (h *Handler) createAuthToken(ctx context.Context, u *ent.User, kind authtoken.Kind, ttl time.Duration) (string, error) {
+	return h.createAuthTokenFor(ctx, u, kind, ttl, "")
+}
+
+// createAuthTokenFor is createAuthToken recording the address that asked for
+// the token
+func (h *Handler) createAuthTokenFor(ctx context.Context, u *ent.User, kind authtoken.Kind, ttl time.Duration, ip string) (string, error) {
+	token, tokenHash, err := newToken()
+	if err != nil {
+		return "", err
+	}
+
+	now := time.Now()
+	_, err = h.client.AuthToken.Update().
+		Where(
+			authtoken.HasUserWith(user.ID(u.ID)),
+			authtoken.KindEQ(kind),
+			authtoken.UsedAtIsNil(),
+		).
+		SetUsedAt(now).
+		Save(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	err = h.client.AuthToken.Create().
+		SetKind(kind).
+		SetTokenHash(tokenHash).
+		SetExpiresAt(now.Add(ttl)).
+		SetIP(ip).
+		SetEmail(u.Email).
+		SetUser(u).
+		Exec(ctx)
+	if err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// authTokenSentSince reports whether a token of kind was issued to u after t
+func (h *Handler) authTokenSentSince(ctx context.Context, u *ent.User, kind authtoken.Kind, t time.Time) (bool, error) {
+	return h.client.AuthToken.Query().
+		Where(
+			authtoken.HasUserWith(user.ID(u.ID)),
+			authtoken.KindEQ(kind),
+			authtoken.CreatedAtGT(t),
+		).
+		Exist(ctx)
+}
+
+// consumeAuthToken marks a token of kind used within tx and returns it with
+// its user. It fails with errInvalidAuthToken if the token is unknown, used
+// or expired.
+func consumeAuthToken(ctx context.Context, tx *ent.Tx, token string, kind authtoken.Kind) (*ent.AuthToken, error) {
+	at, err := tx.AuthToken.Query().
+		Where(authtoken.TokenHash(hashToken(token)), authtoken.KindEQ(kind)).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return nil, errInvalidAuthToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Claimed atomically so a token cannot be used twice concurrently
+	n, err := tx.AuthToken.Update().
+		Where(
+			authtoken.ID(at.ID),
+			authtoken.UsedAtIsNil(),
+			authtoken.ExpiresAtGT(time.Now()),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if n == 0 {
+		return nil, errInvalidAuthToken
+	}
+	return at, nil
+}
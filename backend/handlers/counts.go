@@ -0,0 +1,139 @@
+package handlers
+
+import (
+	"context"
+	"database/sql"
+
+	"poll_app/ent"
+	"poll_app/ent/user"
+	"poll_app/ent/vote"
+)
+
+// optionCounts is the aggregated vote total of one option
+type optionCounts struct {
+	Votes int
+	// Scores maps each rating to the number of votes giving it, on score polls
+	Scores map[int]int
+}
+
+// voteCounts maps option IDs to their aggregated votes
+type voteCounts map[int]*optionCounts
+
+func (c voteCounts) votes(optionID int) int {
+	if oc := c[optionID]; oc != nil {
+		return oc.Votes
+	}
+	return 0
+}
+
+// countVotes aggregates the votes on the options of polls with a single GROUP
+// BY query. Ranked polls count first preferences only. The options must have
+// been loaded on each poll.
+func (h *Handler) countVotes(ctx context.Context, polls ...*ent.Poll) (voteCounts, error) {
+	optionIDs := pollOptionIDs(polls)
+	counts := make(voteCounts, len(optionIDs))
+	if len(optionIDs) == 0 {
+		return counts, nil
+	}
+
+	var rows []struct {
+		OptionID int           `json:"poll_option_votes"`
+		Score    sql.NullInt64 `json:"score"`
+		Count    int           `json:"count"`
+	}
+	err := h.client.Vote.Query().
+		Where(
+			vote.OptionIDIn(optionIDs...),
+			vote.Or(vote.RankIsNil(), vote.Rank(1)),
+		).
+		GroupBy(vote.FieldOptionID, vote.FieldScore).
+		Aggregate(ent.Count()).
+		Scan(ctx, &rows)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, row := range rows {
+		oc := counts[row.OptionID]
+		if oc == nil {
+			oc = &optionCounts{}
+			counts[row.OptionID] = oc
+		}
+		oc.Votes += row.Count
+		if row.Score.Valid {
+			if oc.Scores == nil {
+				oc.Scores = make(map[int]int)
+			}
+			oc.Scores[int(row.Score.Int64)] += row.Count
+		}
+	}
+	return counts, nil
+}
+
+// userSelections loads the user's ballots on polls with a single query, keyed
+// by poll ID. The options must have been loaded on each poll.
+func (h *Handler) userSelections(ctx context.Context, userID int, polls ...*ent.Poll) (map[int]*userSelection, error) {
+	optionPolls := make(map[int]int) // optionID -> pollID
+	var voterHashes []string
+	for _, p := range polls {
+		for _, opt := range p.Edges.Options {
+			optionPolls[opt.ID] = p.ID
+		}
+		// Anonymous polls store the user's votes under a per-poll hash
+		if p.Anonymous {
+			voterHashes = append(voterHashes, voterHash(p.ID, userID))
+		}
+	}
+	selections := make(map[int]*userSelection)
+	if len(optionPolls) == 0 {
+		return selections, nil
+	}
+
+	// In ballot order for ranked polls
+	votes, err := h.client.Vote.Query().
+		Where(
+			vote.Or(
+				vote.HasUserWith(user.ID(userID)),
+				vote.VoterHashIn(voterHashes...),
+			),
+			vote.OptionIDIn(pollOptionIDs(polls)...),
+		).
+		Order(ent.Asc(vote.FieldRank)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, v := range votes {
+		pollID := optionPolls[v.OptionID]
+		if selections[pollID] == nil {
+			selections[pollID] = &userSelection{}
+		}
+		selections[pollID].add(v.OptionID, v)
+	}
+	return selections, nil
+}
+
+// pollDTO converts a single poll for the user, loading its vote counts and the
+// user's selection. The poll must have been loaded with its creator and options.
+func (h *Handler) pollDTO(ctx context.Context, p *ent.Poll, userID int) (PollDTO, error) {
+	counts, err := h.countVotes(ctx, p)
+	if err != nil {
+		return PollDTO{}, err
+	}
+	selections, err := h.userSelections(ctx, userID, p)
+	if err != nil {
+		return PollDTO{}, err
+	}
+	return pollToDTO(p, userID, selections[p.ID], counts), nil
+}
+
+func pollOptionIDs(polls []*ent.Poll) []int {
+	var ids []int
+	for _, p := range polls {
+		for _, opt := range p.Edges.Options {
+			ids = append(ids, opt.ID)
+		}
+	}
+	return ids
+}
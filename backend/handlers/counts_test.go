@@ -0,0 +1,161 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"poll_app/ent"
+	"poll_app/ent/poll"
+	"poll_app/ent/privacy"
+	"poll_app/viewer"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// seedPolls creates n polls of every ballot type with a vote from each voter
+func seedPolls(t *testing.T, client *ent.Client, creator *ent.User, voters []*ent.User, n int) {
+	t.Helper()
+	ctx := context.Background()
+	types := []poll.BallotType{poll.BallotTypeSingle, poll.BallotTypeMultiple, poll.BallotTypeRanked, poll.BallotTypeScore}
+	for i := 0; i < n; i++ {
+		anonymous := i%2 == 1
+		p := client.Poll.Create().
+			SetTitle(fmt.Sprintf("Poll %d", i)).
+			SetBallotType(types[i%len(types)]).
+			SetMaxChoices(2).
+			SetAnonymous(anonymous).
+			SetCreator(creator).
+			SaveX(ctx)
+		a := client.PollOption.Create().SetText("A").SetPoll(p).SaveX(ctx)
+		b := client.PollOption.Create().SetText("B").SetPoll(p).SaveX(ctx)
+		for _, v := range voters {
+			for rank, opt := range []*ent.PollOption{a, b} {
+				create := client.Vote.Create().SetOption(opt)
+				if anonymous {
+					create.SetVoterHash(voterHash(p.ID, v.ID))
+				} else {
+					create.SetUser(v)
+				}
+				switch p.BallotType {
+				case poll.BallotTypeRanked:
+					create.SetRank(rank + 1)
+				case poll.BallotTypeScore:
+					create.SetScore(rank + 3)
+				}
+				create.SaveX(ctx)
+			}
+		}
+	}
+}
+
+// seedVoters creates the poll creator and n voters, the first of whom is the
+// viewer in the query count tests
+func seedVoters(t *testing.T, client *ent.Client, n int) (*ent.User, []*ent.User) {
+	t.Helper()
+	creator := createUser(t, client, "creator")
+	voters := []*ent.User{creator}
+	for i := 0; i < n; i++ {
+		voters = append(voters, createUser(t, client, fmt.Sprintf("voter%d", i)))
+	}
+	return creator, voters
+}
+
+// countQueries serves a request as u and returns the number of queries it took
+func countQueries(t *testing.T, counter *queryCounter, handle httprouter.Handle, u *ent.User, target string, ps httprouter.Params, v any) int64 {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, target, nil)
+	ctx := context.WithValue(req.Context(), userContextKey, u)
+	req = req.WithContext(viewer.NewContext(ctx, viewer.Viewer{UserID: u.ID}))
+	rec := httptest.NewRecorder()
+	counter.reset()
+	handle(rec, req, ps)
+	n := counter.count()
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("status = %d, body %s", rec.Code, rec.Body)
+	}
+	if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+		t.Fatal(err)
+	}
+	if n == 0 {
+		t.Fatal("no queries were counted")
+	}
+	return n
+}
+
+// TestListPollsQueryCount checks that a page of polls takes the same number of
+// queries whatever its size and however many votes its polls have, so counts
+// and selections are not loaded per poll or per vote
+func TestListPollsQueryCount(t *testing.T) {
+	tests := []struct{ polls, voters int }{
+		{1, 2},
+		{12, 2},
+		{1, 20},
+		{12, 20},
+	}
+	var want int64
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d polls, %d voters", tt.polls, tt.voters), func(t *testing.T) {
+			var counter queryCounter
+			client := openTestClient(t, &counter)
+			h := NewHandler(client)
+			creator, voters := seedVoters(t, client, tt.voters)
+			seedPolls(t, client, creator, voters, tt.polls)
+
+			var page PollPageDTO
+			n := countQueries(t, &counter, h.ListPolls, voters[1], "/api/polls?limit=50", nil, &page)
+			if len(page.Polls) != tt.polls {
+				t.Fatalf("got %d polls, want %d", len(page.Polls), tt.polls)
+			}
+			for _, p := range page.Polls {
+				if len(p.UserVotedOptionIDs) == 0 {
+					t.Errorf("poll %d: missing the user's selection", p.ID)
+				}
+			}
+			if want == 0 {
+				want = n
+			}
+			if n != want {
+				t.Errorf("took %d queries, want %d as for %d polls with %d voters", n, want, tests[0].polls, tests[0].voters)
+			}
+		})
+	}
+}
+
+// TestGetPollQueryCount checks that loading a poll of each ballot type takes
+// the same number of queries however many votes it has
+func TestGetPollQueryCount(t *testing.T) {
+	for i, ballotType := range []poll.BallotType{poll.BallotTypeSingle, poll.BallotTypeMultiple, poll.BallotTypeRanked, poll.BallotTypeScore} {
+		t.Run(string(ballotType), func(t *testing.T) {
+			var want int64
+			for _, voters := range []int{2, 20} {
+				t.Run(fmt.Sprint(voters), func(t *testing.T) {
+					var counter queryCounter
+					client := openTestClient(t, &counter)
+					h := NewHandler(client)
+					creator, users := seedVoters(t, client, voters)
+					// seedPolls cycles through the ballot types, so the last poll has this one
+					seedPolls(t, client, creator, users, i+1)
+					p := client.Poll.Query().Where(poll.BallotTypeEQ(ballotType)).OnlyX(privacy.DecisionContext(context.Background(), privacy.Allow))
+
+					var dto PollDTO
+					ps := httprouter.Params{{Key: "id", Value: fmt.Sprint(p.ID)}}
+					n := countQueries(t, &counter, h.GetPoll, users[1], fmt.Sprintf("/api/polls/%d", p.ID), ps, &dto)
+					if got := dto.Options[0].VoteCount; got != len(users) {
+						t.Fatalf("option A has %d votes, want %d", got, len(users))
+					}
+					if want == 0 {
+						want = n
+					}
+					if n != want {
+						t.Errorf("took %d queries, want %d as for 2 voters", n, want)
+					}
+				})
+			}
+		})
+	}
+}
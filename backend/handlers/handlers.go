@@ -2,38 +2,82 @@ package handlers
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"log"
 	"net/http"
+	"slices"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
 	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/invite"
 	"poll_app/ent/notification"
 	"poll_app/ent/poll"
 	"poll_app/ent/polloption"
+	"poll_app/ent/predicate"
+	"poll_app/ent/session"
+	"poll_app/ent/team"
 	"poll_app/ent/user"
 	"poll_app/ent/vote"
+	"poll_app/events"
+	"poll_app/keyset"
+	"poll_app/tally"
+	"poll_app/viewer"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/julienschmidt/httprouter"
 	"golang.org/x/crypto/bcrypt"
 )
 
-var jwtSecret = []byte("your-secret-key-change-in-production")
+// tokenKeys signs and verifies access tokens. The default key only lasts as
+// long as the process.
+var tokenKeys = keyset.Ephemeral()
 
-// SetJWTSecret allows setting the JWT secret from environment
-func SetJWTSecret(secret string) {
-	jwtSecret = []byte(secret)
+// SetKeySet sets the keys that sign and verify access tokens
+func SetKeySet(ks *keyset.Set) {
+	tokenKeys = ks
+}
+
+var voterHashSecret = []byte("your-voter-secret-change-in-production")
+
+// SetVoterHashSecret sets the key used to derive anonymous voter hashes.
+// Changing it detaches existing anonymous votes from their voters.
+func SetVoterHashSecret(secret string) {
+	voterHashSecret = []byte(secret)
+}
+
+// voterHash identifies a user's votes on one anonymous poll without linking them to the user
+func voterHash(pollID, userID int) string {
+	mac := hmac.New(sha256.New, voterHashSecret)
+	fmt.Fprintf(mac, "%d:%d", pollID, userID)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// voterPredicate matches the user's votes on a poll, anonymous or not
+func voterPredicate(p *ent.Poll, userID int) predicate.Vote {
+	if p.Anonymous {
+		return vote.VoterHash(voterHash(p.ID, userID))
+	}
+	return vote.HasUserWith(user.ID(userID))
 }
 
 type Handler struct {
 	client *ent.Client
+	hub    *events.Hub
+	feed   *events.Feed
+	// relayed is set when events go through Postgres to all instances
+	relayed bool
 }
 
 func NewHandler(client *ent.Client) *Handler {
-	return &Handler{client: client}
+	return &Handler{client: client, hub: events.NewHub(), feed: events.NewFeed()}
 }
 
 type contextKey string
@@ -64,8 +108,10 @@ type LoginRequest struct {
 }
 
 type AuthResponse struct {
-	Token string  `json:"token"`
-	User  UserDTO `json:"user"`
+	// Token is the short-lived access token
+	Token        string         `json:"token"`
+	RefreshToken string         `json:"refresh_token"`
+	User         CurrentUserDTO `json:"user"`
 }
 
 type UserDTO struct {
@@ -74,6 +120,23 @@ type UserDTO struct {
 	Email    string `json:"email"`
 }
 
+// CurrentUserDTO is the signed-in user's own account
+type CurrentUserDTO struct {
+	UserDTO
+	EmailVerified    bool `json:"email_verified"`
+	TwoFactorEnabled bool `json:"two_factor_enabled"`
+	HasPassword      bool `json:"has_password"`
+}
+
+func currentUserToDTO(u *ent.User) CurrentUserDTO {
+	return CurrentUserDTO{
+		UserDTO:          UserDTO{ID: u.ID, Username: u.Username, Email: u.Email},
+		EmailVerified:    u.EmailVerified,
+		TwoFactorEnabled: u.TotpEnabled,
+		HasPassword:      u.Password != "",
+	}
+}
+
 func (h *Handler) SignUp(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	var req SignUpRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -85,6 +148,10 @@ func (h *Handler) SignUp(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		errorResponse(w, http.StatusBadRequest, "Username, email, and password are required")
 		return
 	}
+	if !validEmail(req.Email) {
+		errorResponse(w, http.StatusBadRequest, "Invalid email address")
+		return
+	}
 
 	// Hash password
 	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
@@ -98,7 +165,7 @@ func (h *Handler) SignUp(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		SetUsername(req.Username).
 		SetEmail(req.Email).
 		SetPassword(string(hashedPassword)).
-		Save(context.Background())
+		Save(r.Context())
 	if err != nil {
 		if ent.IsConstraintError(err) {
 			errorResponse(w, http.StatusConflict, "Username or email already exists")
@@ -108,21 +175,18 @@ func (h *Handler) SignUp(w http.ResponseWriter, r *http.Request, _ httprouter.Pa
 		return
 	}
 
-	// Generate JWT
-	token, err := generateToken(u.ID)
+	// Signup still succeeds if this fails; the user can ask for a new email
+	if err := h.sendVerificationEmail(r.Context(), u); err != nil {
+		log.Printf("failed to send verification email: %v", err)
+	}
+
+	resp, err := h.startSession(r.Context(), r, u)
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
 
-	jsonResponse(w, http.StatusCreated, AuthResponse{
-		Token: token,
-		User: UserDTO{
-			ID:       u.ID,
-			Username: u.Username,
-			Email:    u.Email,
-		},
-	})
+	jsonResponse(w, http.StatusCreated, resp)
 }
 
 func (h *Handler) Login(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
@@ -132,52 +196,62 @@ func (h *Handler) Login(w http.ResponseWriter, r *http.Request, _ httprouter.Par
 		return
 	}
 
+	// Too many wrong passwords lock the email out for a while
+	if left := loginLockedFor(r.Context(), req.Email); left > 0 {
+		setRetryAfter(w, left)
+		errorResponse(w, http.StatusTooManyRequests, "Too many failed logins; try again later")
+		return
+	}
+
 	// Find user by email
-	u, err := h.client.User.Query().Where(user.Email(req.Email)).Only(context.Background())
+	u, err := h.client.User.Query().Where(user.Email(req.Email)).Only(r.Context())
 	if err != nil {
+		h.loginFailed(r, req.Email, nil)
 		errorResponse(w, http.StatusUnauthorized, "Invalid credentials")
 		return
 	}
 
 	// Check password
 	if err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(req.Password)); err != nil {
+		h.loginFailed(r, req.Email, u)
 		errorResponse(w, http.StatusUnauthorized, "Invalid credentials")
 		return
 	}
 
-	// Generate JWT
-	token, err := generateToken(u.ID)
+	// With two-factor authentication the session starts at LoginTwoFactor,
+	// which also clears the failed logins
+	if u.TotpEnabled {
+		challenge, err := h.createAuthToken(r.Context(), u, authtoken.KindTwoFactor, twoFactorChallengeTTL)
+		if err != nil {
+			errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+			return
+		}
+		jsonResponse(w, http.StatusOK, TwoFactorChallenge{TwoFactorRequired: true, ChallengeToken: challenge})
+		return
+	}
+
+	resp, err := h.startSession(r.Context(), r, u)
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
 		return
 	}
+	loginSucceeded(r.Context(), req.Email)
 
-	jsonResponse(w, http.StatusOK, AuthResponse{
-		Token: token,
-		User: UserDTO{
-			ID:       u.ID,
-			Username: u.Username,
-			Email:    u.Email,
-		},
-	})
+	jsonResponse(w, http.StatusOK, resp)
 }
 
 func (h *Handler) GetCurrentUser(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	u := r.Context().Value(userContextKey).(*ent.User)
-	jsonResponse(w, http.StatusOK, UserDTO{
-		ID:       u.ID,
-		Username: u.Username,
-		Email:    u.Email,
-	})
+	jsonResponse(w, http.StatusOK, currentUserToDTO(u))
 }
 
-func generateToken(userID int) (string, error) {
+func generateToken(userID, sessionID int) (string, error) {
 	claims := jwt.MapClaims{
 		"user_id": userID,
-		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+		"sid":     sessionID,
+		"exp":     time.Now().Add(accessTokenTTL).Unix(),
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString(jwtSecret)
+	return tokenKeys.Sign(claims)
 }
 
 func (h *Handler) AuthMiddleware(next httprouter.Handle) httprouter.Handle {
@@ -188,44 +262,100 @@ func (h *Handler) AuthMiddleware(next httprouter.Handle) httprouter.Handle {
 			return
 		}
 
-		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
-		token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-			return jwtSecret, nil
-		})
-		if err != nil || !token.Valid {
-			errorResponse(w, http.StatusUnauthorized, "Invalid token")
+		u, s, msg := h.authenticate(r.Context(), strings.TrimPrefix(authHeader, "Bearer "))
+		if msg != "" {
+			errorResponse(w, http.StatusUnauthorized, msg)
 			return
 		}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			errorResponse(w, http.StatusUnauthorized, "Invalid token claims")
-			return
-		}
+		ctx := context.WithValue(withUser(r.Context(), u), sessionContextKey, s)
+		next(w, r.WithContext(ctx), ps)
+	}
+}
 
-		userID := int(claims["user_id"].(float64))
-		u, err := h.client.User.Get(context.Background(), userID)
-		if err != nil {
-			errorResponse(w, http.StatusUnauthorized, "User not found")
-			return
-		}
+// authenticate resolves the user and session a JWT was issued to. It returns
+// an error message if the token is not valid or its session was revoked.
+func (h *Handler) authenticate(ctx context.Context, tokenString string) (*ent.User, *ent.Session, string) {
+	token, err := jwt.Parse(tokenString, tokenKeys.Keyfunc,
+		jwt.WithValidMethods(keyset.Methods()),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil || !token.Valid {
+		return nil, nil, "Invalid token"
+	}
 
-		ctx := context.WithValue(r.Context(), userContextKey, u)
-		next(w, r.WithContext(ctx), ps)
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, nil, "Invalid token claims"
+	}
+	userID, ok := claims["user_id"].(float64)
+	if !ok {
+		return nil, nil, "Invalid token claims"
 	}
+	sessionID, ok := claims["sid"].(float64)
+	if !ok {
+		return nil, nil, "Invalid token claims"
+	}
+
+	s, err := h.client.Session.Query().
+		Where(session.ID(int(sessionID)), session.HasUserWith(user.ID(int(userID)))).
+		WithUser().
+		Only(ctx)
+	if err != nil {
+		return nil, nil, "Session not found"
+	}
+	if s.RevokedAt != nil {
+		return nil, nil, "Session has been revoked"
+	}
+
+	if now := time.Now(); now.Sub(s.LastSeenAt) > lastSeenInterval {
+		_ = h.client.Session.UpdateOne(s).SetLastSeenAt(now).Exec(ctx)
+	}
+	return s.Edges.User, s, ""
+}
+
+// withUser stores u in ctx. The viewer scopes every ent query made with the
+// returned context to what u may see.
+func withUser(ctx context.Context, u *ent.User) context.Context {
+	ctx = context.WithValue(ctx, userContextKey, u)
+	return viewer.NewContext(ctx, viewer.Viewer{UserID: u.ID})
 }
 
 // Poll handlers
 type CreatePollRequest struct {
-	Title       string   `json:"title"`
-	Description string   `json:"description"`
-	Options     []string `json:"options"`
+	Title       string     `json:"title"`
+	Description string     `json:"description"`
+	Options     []string   `json:"options"`
+	OpensAt     *time.Time `json:"opens_at,omitempty"`
+	ClosesAt    *time.Time `json:"closes_at,omitempty"`
+	BallotType  string     `json:"ballot_type,omitempty"`
+	MinChoices  *int       `json:"min_choices,omitempty"`
+	MaxChoices  *int       `json:"max_choices,omitempty"`
+	ScoreMin    *int       `json:"score_min,omitempty"`
+	ScoreMax    *int       `json:"score_max,omitempty"`
+	Anonymous   bool       `json:"anonymous"`
+	Visibility  string     `json:"visibility,omitempty"`
+	TeamID      *int       `json:"team_id,omitempty"`
+	Invitees    []string   `json:"invitees,omitempty"` // usernames or emails
+	// ResultsVisibility is always, after_vote, after_close or creator_only
+	ResultsVisibility string `json:"results_visibility,omitempty"`
 }
 
 type UpdatePollRequest struct {
 	Title       string         `json:"title"`
 	Description string         `json:"description"`
 	Options     []OptionUpdate `json:"options"`
+	// OpensAt, ClosesAt and the choice limits are left unchanged when omitted
+	OpensAt    *time.Time `json:"opens_at,omitempty"`
+	ClosesAt   *time.Time `json:"closes_at,omitempty"`
+	MinChoices *int       `json:"min_choices,omitempty"`
+	MaxChoices *int       `json:"max_choices,omitempty"`
+	// Visibility, team and invitees are also left unchanged when omitted
+	Visibility string   `json:"visibility,omitempty"`
+	TeamID     *int     `json:"team_id,omitempty"`
+	Invitees   []string `json:"invitees,omitempty"`
+	// ResultsVisibility is left unchanged when omitted
+	ResultsVisibility string `json:"results_visibility,omitempty"`
 }
 
 type OptionUpdate struct {
@@ -234,14 +364,32 @@ type OptionUpdate struct {
 }
 
 type PollDTO struct {
-	ID                  int         `json:"id"`
-	Title               string      `json:"title"`
-	Description         string      `json:"description"`
-	Creator             UserDTO     `json:"creator"`
-	Options             []OptionDTO `json:"options"`
-	CreatedAt           time.Time   `json:"created_at"`
-	UpdatedAt           time.Time   `json:"updated_at"`
+	ID                int         `json:"id"`
+	Title             string      `json:"title"`
+	Description       string      `json:"description"`
+	Creator           UserDTO     `json:"creator"`
+	Options           []OptionDTO `json:"options"`
+	CreatedAt         time.Time   `json:"created_at"`
+	UpdatedAt         time.Time   `json:"updated_at"`
+	OpensAt           *time.Time  `json:"opens_at,omitempty"`
+	ClosesAt          *time.Time  `json:"closes_at,omitempty"`
+	Status            string      `json:"status"`
+	BallotType        string      `json:"ballot_type"`
+	MinChoices        int         `json:"min_choices"`
+	MaxChoices        int         `json:"max_choices"`
+	ScoreMin          *int        `json:"score_min,omitempty"`
+	ScoreMax          *int        `json:"score_max,omitempty"`
+	Anonymous         bool        `json:"anonymous"`
+	Visibility        string      `json:"visibility"`
+	ResultsVisibility string      `json:"results_visibility"`
+	// ResultsHidden means vote counts and score statistics were withheld
+	ResultsHidden       bool        `json:"results_hidden"`
+	TeamID              *int        `json:"team_id,omitempty"`
+	Invitees            []UserDTO   `json:"invitees,omitempty"` // creator only
+	LinkKey             string      `json:"link_key,omitempty"` // creator of an unlisted poll only
 	UserVotedOptionID   *int        `json:"user_voted_option_id,omitempty"`
+	UserVotedOptionIDs  []int       `json:"user_voted_option_ids,omitempty"`
+	UserScores          map[int]int `json:"user_scores,omitempty"`
 	PollEditedAfterVote bool        `json:"poll_edited_after_vote"`
 }
 
@@ -249,11 +397,65 @@ type OptionDTO struct {
 	ID        int    `json:"id"`
 	Text      string `json:"text"`
 	VoteCount int    `json:"vote_count"`
+	// Score ballots only
+	AverageScore   *float64         `json:"average_score,omitempty"`
+	MedianScore    *float64         `json:"median_score,omitempty"`
+	ScoreHistogram []ScoreBucketDTO `json:"score_histogram,omitempty"`
+}
+
+type ScoreBucketDTO struct {
+	Score int `json:"score"`
+	Count int `json:"count"`
+}
+
+// Poll statuses derived from the opens_at / closes_at window
+const (
+	PollStatusScheduled = "scheduled"
+	PollStatusOpen      = "open"
+	PollStatusClosed    = "closed"
+)
+
+func pollStatus(p *ent.Poll, now time.Time) string {
+	if p.ClosesAt != nil && !now.Before(*p.ClosesAt) {
+		return PollStatusClosed
+	}
+	if p.OpensAt != nil && now.Before(*p.OpensAt) {
+		return PollStatusScheduled
+	}
+	return PollStatusOpen
+}
+
+// checkPollOpen writes a 409 and returns false if the poll is not accepting votes
+func checkPollOpen(w http.ResponseWriter, p *ent.Poll) bool {
+	switch pollStatus(p, time.Now()) {
+	case PollStatusClosed:
+		errorResponse(w, http.StatusConflict, "Poll is closed and no longer accepts votes")
+		return false
+	case PollStatusScheduled:
+		errorResponse(w, http.StatusConflict, "Poll is not open for voting yet")
+		return false
+	}
+	return true
+}
+
+// maxScore bounds score ballots so histograms stay small
+const maxScore = 100
+
+func validateSchedule(opensAt, closesAt *time.Time) string {
+	if opensAt != nil && closesAt != nil && !closesAt.After(*opensAt) {
+		return "Closing time must be after opening time"
+	}
+	return ""
 }
 
 func (h *Handler) CreatePoll(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	u := r.Context().Value(userContextKey).(*ent.User)
 
+	if requireEmailVerification && !u.EmailVerified {
+		errorResponse(w, http.StatusForbidden, "Verify your email address to create polls")
+		return
+	}
+
 	var req CreatePollRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
 		errorResponse(w, http.StatusBadRequest, "Invalid request body")
@@ -265,8 +467,66 @@ func (h *Handler) CreatePoll(w http.ResponseWriter, r *http.Request, _ httproute
 		return
 	}
 
+	if msg := validateSchedule(req.OpensAt, req.ClosesAt); msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+	if req.ClosesAt != nil && !req.ClosesAt.After(time.Now()) {
+		errorResponse(w, http.StatusBadRequest, "Closing time must be in the future")
+		return
+	}
+
+	ballotType := poll.BallotTypeSingle
+	if req.BallotType != "" {
+		ballotType = poll.BallotType(req.BallotType)
+		if err := poll.BallotTypeValidator(ballotType); err != nil {
+			errorResponse(w, http.StatusBadRequest, "Invalid ballot type")
+			return
+		}
+	}
+	minChoices, maxChoices, msg := choiceLimits(ballotType, req.MinChoices, req.MaxChoices, len(req.Options))
+	if msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	scoreMin, scoreMax := poll.DefaultScoreMin, poll.DefaultScoreMax
+	if req.ScoreMin != nil {
+		scoreMin = *req.ScoreMin
+	}
+	if req.ScoreMax != nil {
+		scoreMax = *req.ScoreMax
+	}
+	if scoreMin < 0 || scoreMax <= scoreMin || scoreMax > maxScore {
+		errorResponse(w, http.StatusBadRequest, fmt.Sprintf("Score range must satisfy 0 <= score_min < score_max <= %d", maxScore))
+		return
+	}
+
+	visibility := poll.VisibilityPublic
+	if req.Visibility != "" {
+		visibility = poll.Visibility(req.Visibility)
+	}
+	if msg := h.checkAudience(r.Context(), u, visibility, req.TeamID); msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+	inviteeIDs, msg := h.resolveInvitees(r.Context(), req.Invitees)
+	if msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	resultsVisibility := poll.ResultsVisibilityAlways
+	if req.ResultsVisibility != "" {
+		resultsVisibility = poll.ResultsVisibility(req.ResultsVisibility)
+		if err := poll.ResultsVisibilityValidator(resultsVisibility); err != nil {
+			errorResponse(w, http.StatusBadRequest, "Invalid results visibility")
+			return
+		}
+	}
+
 	// Create poll with options in a transaction
-	tx, err := h.client.Tx(context.Background())
+	tx, err := h.client.Tx(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
 		return
@@ -275,8 +535,20 @@ func (h *Handler) CreatePoll(w http.ResponseWriter, r *http.Request, _ httproute
 	p, err := tx.Poll.Create().
 		SetTitle(req.Title).
 		SetDescription(req.Description).
+		SetNillableOpensAt(req.OpensAt).
+		SetNillableClosesAt(req.ClosesAt).
+		SetBallotType(ballotType).
+		SetMinChoices(minChoices).
+		SetMaxChoices(maxChoices).
+		SetScoreMin(scoreMin).
+		SetScoreMax(scoreMax).
+		SetAnonymous(req.Anonymous).
+		SetVisibility(visibility).
+		SetNillableTeamID(req.TeamID).
+		SetResultsVisibility(resultsVisibility).
+		AddInviteeIDs(inviteeIDs...).
 		SetCreator(u).
-		Save(context.Background())
+		Save(r.Context())
 	if err != nil {
 		tx.Rollback()
 		errorResponse(w, http.StatusInternalServerError, "Failed to create poll")
@@ -287,7 +559,7 @@ func (h *Handler) CreatePoll(w http.ResponseWriter, r *http.Request, _ httproute
 		_, err := tx.PollOption.Create().
 			SetText(optText).
 			SetPoll(p).
-			Save(context.Background())
+			Save(r.Context())
 		if err != nil {
 			tx.Rollback()
 			errorResponse(w, http.StatusInternalServerError, "Failed to create option")
@@ -304,59 +576,66 @@ func (h *Handler) CreatePoll(w http.ResponseWriter, r *http.Request, _ httproute
 	p, _ = h.client.Poll.Query().
 		Where(poll.ID(p.ID)).
 		WithCreator().
-		WithOptions(func(q *ent.PollOptionQuery) {
-			q.WithVotes()
-		}).
-		Only(context.Background())
+		WithInvitees().
+		WithOptions().
+		Only(r.Context())
 
-	jsonResponse(w, http.StatusCreated, pollToDTO(p, nil, nil))
+	dto, err := h.pollDTO(r.Context(), p, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch poll")
+		return
+	}
+	jsonResponse(w, http.StatusCreated, dto)
 }
 
+// ListPolls returns one page of the polls visible to the user. See
+// parsePollListQuery for the supported filters and sort keys.
 func (h *Handler) ListPolls(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	u := r.Context().Value(userContextKey).(*ent.User)
 
-	polls, err := h.client.Poll.Query().
+	lq, msg := parsePollListQuery(u, r.URL.Query())
+	if msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	// Unlisted polls are reachable by link but only listed for their creator
+	q := h.client.Poll.Query().
+		Where(poll.Or(
+			poll.VisibilityNEQ(poll.VisibilityUnlisted),
+			poll.HasCreatorWith(user.ID(u.ID)),
+		)).
+		Where(lq.Where...).
 		WithCreator().
-		WithOptions(func(q *ent.PollOptionQuery) {
-			q.WithVotes()
-		}).
-		Order(ent.Desc(poll.FieldCreatedAt)).
-		All(context.Background())
+		WithOptions()
+	polls, err := lq.apply(q).All(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to fetch polls")
 		return
 	}
 
-	// Get user's votes with timestamps
-	userVotes, _ := h.client.Vote.Query().
-		Where(vote.HasUserWith(user.ID(u.ID))).
-		WithOption().
-		All(context.Background())
-
-	userVoteMap := make(map[int]int)           // pollID -> optionID
-	userVoteTimeMap := make(map[int]time.Time) // pollID -> vote time
-	for _, v := range userVotes {
-		opt := v.Edges.Option
-		if opt != nil {
-			pollID, _ := h.client.Poll.Query().Where(poll.HasOptionsWith(polloption.ID(opt.ID))).OnlyID(context.Background())
-			userVoteMap[pollID] = opt.ID
-			userVoteTimeMap[pollID] = v.CreatedAt
-		}
+	// Counts and the user's selections for the whole page come from one query each
+	counts, err := h.countVotes(r.Context(), polls...)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch vote counts")
+		return
+	}
+	selections, err := h.userSelections(r.Context(), u.ID, polls...)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch votes")
+		return
 	}
 
-	dtos := make([]PollDTO, len(polls))
-	for i, p := range polls {
-		var votedOptionID *int
-		var voteTime *time.Time
-		if optID, ok := userVoteMap[p.ID]; ok {
-			votedOptionID = &optID
-			t := userVoteTimeMap[p.ID]
-			voteTime = &t
-		}
-		dtos[i] = pollToDTO(p, votedOptionID, voteTime)
+	page := PollPageDTO{Polls: []PollDTO{}}
+	if len(polls) > lq.Limit {
+		polls = polls[:lq.Limit]
+		page.NextCursor = lq.cursorAfter(polls[len(polls)-1], counts)
+	}
+	for _, p := range polls {
+		page.Polls = append(page.Polls, pollToDTO(p, u.ID, selections[p.ID], counts))
 	}
 
-	jsonResponse(w, http.StatusOK, dtos)
+	jsonResponse(w, http.StatusOK, page)
 }
 
 func (h *Handler) GetPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -368,33 +647,38 @@ func (h *Handler) GetPoll(w http.ResponseWriter, r *http.Request, ps httprouter.
 		return
 	}
 
+	dto, err := h.getPollDTO(r.Context(), id, u.ID)
+	if err != nil {
+		if ent.IsNotFound(err) {
+			errorResponse(w, http.StatusNotFound, "Poll not found")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch poll")
+		return
+	}
+	jsonResponse(w, http.StatusOK, dto)
+}
+
+// getPollDTO loads a poll as GetPoll returns it, including the invitees when
+// userID is the creator
+func (h *Handler) getPollDTO(ctx context.Context, id, userID int) (PollDTO, error) {
 	p, err := h.client.Poll.Query().
 		Where(poll.ID(id)).
 		WithCreator().
-		WithOptions(func(q *ent.PollOptionQuery) {
-			q.WithVotes()
-		}).
-		Only(context.Background())
+		WithOptions().
+		Only(ctx)
 	if err != nil {
-		errorResponse(w, http.StatusNotFound, "Poll not found")
-		return
+		return PollDTO{}, err
 	}
 
-	// Check if user has voted and get vote time
-	var votedOptionID *int
-	var userVoteTime *time.Time
-	for _, opt := range p.Edges.Options {
-		for _, v := range opt.Edges.Votes {
-			voter, _ := h.client.Vote.QueryUser(v).Only(context.Background())
-			if voter != nil && voter.ID == u.ID {
-				votedOptionID = &opt.ID
-				userVoteTime = &v.CreatedAt
-				break
-			}
+	if p.Edges.Creator.ID == userID {
+		p.Edges.Invitees, err = p.QueryInvitees().All(ctx)
+		if err != nil {
+			return PollDTO{}, err
 		}
 	}
 
-	jsonResponse(w, http.StatusOK, pollToDTO(p, votedOptionID, userVoteTime))
+	return h.pollDTO(ctx, p, userID)
 }
 
 func (h *Handler) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -410,7 +694,7 @@ func (h *Handler) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprout
 		Where(poll.ID(id)).
 		WithCreator().
 		WithOptions().
-		Only(context.Background())
+		Only(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusNotFound, "Poll not found")
 		return
@@ -428,17 +712,85 @@ func (h *Handler) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprout
 		return
 	}
 
+	opensAt, closesAt := p.OpensAt, p.ClosesAt
+	if req.OpensAt != nil {
+		opensAt = req.OpensAt
+	}
+	if req.ClosesAt != nil {
+		closesAt = req.ClosesAt
+	}
+	if msg := validateSchedule(opensAt, closesAt); msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	minChoices, maxChoices := p.MinChoices, p.MaxChoices
+	if req.MinChoices != nil {
+		minChoices = *req.MinChoices
+	}
+	if req.MaxChoices != nil {
+		maxChoices = *req.MaxChoices
+	} else if maxChoices > len(req.Options) {
+		// Removing options shrinks the default limit along with them
+		maxChoices = len(req.Options)
+	}
+	minChoices, maxChoices, msg := choiceLimits(p.BallotType, &minChoices, &maxChoices, len(req.Options))
+	if msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	visibility, teamID := p.Visibility, req.TeamID
+	if req.Visibility != "" {
+		visibility = poll.Visibility(req.Visibility)
+	}
+	if teamID == nil && visibility == poll.VisibilityTeam {
+		teamID = p.TeamID
+	}
+	if msg := h.checkAudience(r.Context(), u, visibility, teamID); msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+	inviteeIDs, msg := h.resolveInvitees(r.Context(), req.Invitees)
+	if msg != "" {
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	resultsVisibility := p.ResultsVisibility
+	if req.ResultsVisibility != "" {
+		resultsVisibility = poll.ResultsVisibility(req.ResultsVisibility)
+		if err := poll.ResultsVisibilityValidator(resultsVisibility); err != nil {
+			errorResponse(w, http.StatusBadRequest, "Invalid results visibility")
+			return
+		}
+	}
+
 	// Update poll
-	tx, err := h.client.Tx(context.Background())
+	tx, err := h.client.Tx(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
 		return
 	}
 
-	_, err = tx.Poll.UpdateOneID(id).
+	update := tx.Poll.UpdateOneID(id).
 		SetTitle(req.Title).
 		SetDescription(req.Description).
-		Save(context.Background())
+		SetNillableOpensAt(req.OpensAt).
+		SetNillableClosesAt(req.ClosesAt).
+		SetMinChoices(minChoices).
+		SetMaxChoices(maxChoices).
+		SetVisibility(visibility).
+		SetResultsVisibility(resultsVisibility)
+	if teamID != nil {
+		update.SetTeamID(*teamID)
+	} else {
+		update.ClearTeam()
+	}
+	if req.Invitees != nil {
+		update.ClearInvitees().AddInviteeIDs(inviteeIDs...)
+	}
+	_, err = update.Save(r.Context())
 	if err != nil {
 		tx.Rollback()
 		errorResponse(w, http.StatusInternalServerError, "Failed to update poll")
@@ -455,7 +807,7 @@ func (h *Handler) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprout
 	for _, opt := range req.Options {
 		if opt.ID > 0 {
 			// Update existing option
-			_, err := tx.PollOption.UpdateOneID(opt.ID).SetText(opt.Text).Save(context.Background())
+			_, err := tx.PollOption.UpdateOneID(opt.ID).SetText(opt.Text).Save(r.Context())
 			if err != nil {
 				tx.Rollback()
 				errorResponse(w, http.StatusInternalServerError, "Failed to update option")
@@ -464,7 +816,7 @@ func (h *Handler) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprout
 			newOptionIDs[opt.ID] = true
 		} else {
 			// Create new option
-			_, err := tx.PollOption.Create().SetText(opt.Text).SetPollID(id).Save(context.Background())
+			_, err := tx.PollOption.Create().SetText(opt.Text).SetPollID(id).Save(r.Context())
 			if err != nil {
 				tx.Rollback()
 				errorResponse(w, http.StatusInternalServerError, "Failed to create option")
@@ -474,15 +826,32 @@ func (h *Handler) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprout
 	}
 
 	// Delete removed options
+	removed := false
 	for optID := range existingOptionIDs {
 		if !newOptionIDs[optID] {
 			// Delete votes for this option first
-			_, _ = tx.Vote.Delete().Where(vote.HasOptionWith(polloption.ID(optID))).Exec(context.Background())
+			_, _ = tx.Vote.Delete().Where(vote.HasOptionWith(polloption.ID(optID))).Exec(r.Context())
 			// Delete the option
-			_ = tx.PollOption.DeleteOneID(optID).Exec(context.Background())
+			_ = tx.PollOption.DeleteOneID(optID).Exec(r.Context())
+			removed = true
+		}
+	}
+	if removed && p.BallotType == poll.BallotTypeRanked {
+		if err := rerankBallots(r.Context(), tx, id); err != nil {
+			tx.Rollback()
+			errorResponse(w, http.StatusInternalServerError, "Failed to update poll")
+			return
+		}
+	}
+	if p.Anonymous {
+		if err := pruneParticipants(r.Context(), tx, id); err != nil {
+			tx.Rollback()
+			errorResponse(w, http.StatusInternalServerError, "Failed to update poll")
+			return
 		}
 	}
 
+	h.publishPoll(r.Context(), tx, id, events.KindUpdated)
 	if err := tx.Commit(); err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
 		return
@@ -492,12 +861,48 @@ func (h *Handler) UpdatePoll(w http.ResponseWriter, r *http.Request, ps httprout
 	p, _ = h.client.Poll.Query().
 		Where(poll.ID(id)).
 		WithCreator().
-		WithOptions(func(q *ent.PollOptionQuery) {
-			q.WithVotes()
-		}).
-		Only(context.Background())
+		WithInvitees().
+		WithOptions().
+		Only(r.Context())
 
-	jsonResponse(w, http.StatusOK, pollToDTO(p, nil, nil))
+	dto, err := h.pollDTO(r.Context(), p, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch poll")
+		return
+	}
+	jsonResponse(w, http.StatusOK, dto)
+}
+
+// rerankBallots closes the gaps that removed options left in the ranked
+// ballots on a poll, so a ballot whose first choice was removed counts for its
+// next one
+func rerankBallots(ctx context.Context, tx *ent.Tx, pollID int) error {
+	votes, err := tx.Vote.Query().
+		Where(vote.HasOptionWith(polloption.HasPollWith(poll.ID(pollID)))).
+		WithUser().
+		Order(ent.Asc(vote.FieldRank)).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	// Ballots are keyed by voter hash on anonymous polls and by user otherwise
+	ranks := make(map[string]int)
+	for _, v := range votes {
+		var key string
+		if v.VoterHash != nil {
+			key = *v.VoterHash
+		} else {
+			key = strconv.Itoa(v.Edges.User.ID)
+		}
+		ranks[key]++
+		if v.Rank == ranks[key] {
+			continue
+		}
+		if err := tx.Vote.UpdateOne(v).SetRank(ranks[key]).Exec(ctx); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 func (h *Handler) DeletePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -513,7 +918,7 @@ func (h *Handler) DeletePoll(w http.ResponseWriter, r *http.Request, ps httprout
 		Where(poll.ID(id)).
 		WithCreator().
 		WithOptions().
-		Only(context.Background())
+		Only(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusNotFound, "Poll not found")
 		return
@@ -526,7 +931,7 @@ func (h *Handler) DeletePoll(w http.ResponseWriter, r *http.Request, ps httprout
 	}
 
 	// Delete in transaction
-	tx, err := h.client.Tx(context.Background())
+	tx, err := h.client.Tx(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
 		return
@@ -534,20 +939,24 @@ func (h *Handler) DeletePoll(w http.ResponseWriter, r *http.Request, ps httprout
 
 	// Delete votes for all options
 	for _, opt := range p.Edges.Options {
-		_, _ = tx.Vote.Delete().Where(vote.HasOptionWith(polloption.ID(opt.ID))).Exec(context.Background())
+		_, _ = tx.Vote.Delete().Where(vote.HasOptionWith(polloption.ID(opt.ID))).Exec(r.Context())
 	}
 
+	// Delete invite links
+	_, _ = tx.Invite.Delete().Where(invite.HasPollWith(poll.ID(id))).Exec(r.Context())
+
 	// Delete options
-	_, _ = tx.PollOption.Delete().Where(polloption.HasPollWith(poll.ID(id))).Exec(context.Background())
+	_, _ = tx.PollOption.Delete().Where(polloption.HasPollWith(poll.ID(id))).Exec(r.Context())
 
 	// Delete poll
-	err = tx.Poll.DeleteOneID(id).Exec(context.Background())
+	err = tx.Poll.DeleteOneID(id).Exec(r.Context())
 	if err != nil {
 		tx.Rollback()
 		errorResponse(w, http.StatusInternalServerError, "Failed to delete poll")
 		return
 	}
 
+	h.publishPoll(r.Context(), tx, id, events.KindDeleted)
 	if err := tx.Commit(); err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
 		return
@@ -556,9 +965,109 @@ func (h *Handler) DeletePoll(w http.ResponseWriter, r *http.Request, ps httprout
 	w.WriteHeader(http.StatusNoContent)
 }
 
+// ClosePoll stops a poll from accepting votes immediately
+func (h *Handler) ClosePoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.setPollClosed(w, r, ps, true)
+}
+
+// ReopenPoll lets a closed poll accept votes again
+func (h *Handler) ReopenPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	h.setPollClosed(w, r, ps, false)
+}
+
+func (h *Handler) setPollClosed(w http.ResponseWriter, r *http.Request, ps httprouter.Params, closed bool) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid poll ID")
+		return
+	}
+
+	p, err := h.client.Poll.Query().
+		Where(poll.ID(id)).
+		WithCreator().
+		Only(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Poll not found")
+		return
+	}
+
+	// Check ownership
+	if p.Edges.Creator.ID != u.ID {
+		errorResponse(w, http.StatusForbidden, "You can only close or reopen your own polls")
+		return
+	}
+
+	now := time.Now()
+	isClosed := pollStatus(p, now) == PollStatusClosed
+	// Keep updated_at as is so voters aren't told the poll was edited
+	update := h.client.Poll.UpdateOneID(id).SetUpdatedAt(p.UpdatedAt)
+	if closed {
+		if isClosed {
+			errorResponse(w, http.StatusConflict, "Poll is already closed")
+			return
+		}
+		update.SetClosesAt(now)
+		// A poll closed before it opened would fail validateSchedule on every edit
+		if p.OpensAt != nil && p.OpensAt.After(now) {
+			update.ClearOpensAt()
+		}
+	} else {
+		if !isClosed {
+			errorResponse(w, http.StatusConflict, "Poll is not closed")
+			return
+		}
+		update.ClearClosesAt()
+	}
+
+	if _, err := update.Save(r.Context()); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to update poll")
+		return
+	}
+	h.publishPoll(r.Context(), nil, id, events.KindUpdated)
+
+	// Fetch updated poll
+	p, _ = h.client.Poll.Query().
+		Where(poll.ID(id)).
+		WithCreator().
+		WithInvitees().
+		WithOptions().
+		Only(r.Context())
+
+	dto, err := h.pollDTO(r.Context(), p, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch poll")
+		return
+	}
+	jsonResponse(w, http.StatusOK, dto)
+}
+
 // Vote handlers
 type VoteRequest struct {
-	OptionID int `json:"option_id"`
+	// OptionID is kept for single-choice clients; OptionIDs takes precedence when set
+	OptionID  int   `json:"option_id,omitempty"`
+	OptionIDs []int `json:"option_ids,omitempty"`
+	// Scores maps option ID to rating on score ballots
+	Scores map[int]int `json:"scores,omitempty"`
+}
+
+func (req VoteRequest) selection() []int {
+	if len(req.Scores) > 0 {
+		ids := make([]int, 0, len(req.Scores))
+		for id := range req.Scores {
+			ids = append(ids, id)
+		}
+		sort.Ints(ids)
+		return ids
+	}
+	if len(req.OptionIDs) > 0 {
+		return req.OptionIDs
+	}
+	if req.OptionID != 0 {
+		return []int{req.OptionID}
+	}
+	return nil
 }
 
 func (h *Handler) Vote(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
@@ -576,77 +1085,144 @@ func (h *Handler) Vote(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 		return
 	}
 
-	// Verify option belongs to poll
-	opt, err := h.client.PollOption.Query().
-		Where(polloption.ID(req.OptionID), polloption.HasPollWith(poll.ID(pollID))).
-		Only(context.Background())
+	tx, err := h.client.Tx(r.Context())
 	if err != nil {
-		errorResponse(w, http.StatusBadRequest, "Invalid option for this poll")
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
 		return
 	}
 
-	// Check if user already voted on this poll
-	p, _ := h.client.Poll.Query().
+	// Lock the poll row with a write that changes nothing, so concurrent votes
+	// replace the selection one at a time and an edit either commits before
+	// the poll is read below or waits for the vote; otherwise two votes could
+	// each delete the old votes and both insert theirs. Unlike SELECT ... FOR
+	// UPDATE this works on SQLite.
+	if _, err := tx.ExecContext(r.Context(), "UPDATE polls SET updated_at = updated_at WHERE id = $1", pollID); err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to replace vote")
+		return
+	}
+
+	// Load the poll along with the user's existing votes
+	p, err := tx.Poll.Query().
 		Where(poll.ID(pollID)).
 		WithCreator().
 		WithOptions(func(q *ent.PollOptionQuery) {
 			q.WithVotes(func(vq *ent.VoteQuery) {
-				vq.Where(vote.HasUserWith(user.ID(u.ID)))
+				vq.Where(vote.Or(
+					vote.HasUserWith(user.ID(u.ID)),
+					vote.VoterHash(voterHash(pollID, u.ID)),
+				))
 			})
 		}).
-		Only(context.Background())
+		Only(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusNotFound, "Poll not found")
+		return
+	}
 
-	// Track if this is a vote change
-	isVoteChange := false
-	var previousOptionText string
+	if !checkPollOpen(w, p) {
+		tx.Rollback()
+		return
+	}
+
+	// Verify the selection belongs to the poll and fits its limits
+	optionIDs := req.selection()
+	if msg := validateSelection(p, optionIDs); msg != "" {
+		tx.Rollback()
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+	if msg := validateScores(p, req.Scores); msg != "" {
+		tx.Rollback()
+		errorResponse(w, http.StatusBadRequest, msg)
+		return
+	}
+
+	optionTexts := make(map[int]string, len(p.Edges.Options))
+	var previous []*ent.Vote
+	scoresChanged := false
 	for _, o := range p.Edges.Options {
+		optionTexts[o.ID] = o.Text
 		if len(o.Edges.Votes) > 0 {
-			isVoteChange = true
-			previousOptionText = o.Text
-			break
+			previous = append(previous, o.Edges.Votes[0])
+			scoresChanged = scoresChanged || o.Edges.Votes[0].Score != req.Scores[o.ID]
 		}
 	}
+	// In ballot order for ranked polls
+	sort.SliceStable(previous, func(i, j int) bool { return previous[i].Rank < previous[j].Rank })
+	previousIDs := make([]int, len(previous))
+	for i, v := range previous {
+		previousIDs[i] = v.OptionID
+	}
 
-	tx, err := h.client.Tx(context.Background())
+	// Replace the user's whole selection on this poll
+	_, err = tx.Vote.Delete().
+		Where(
+			voterPredicate(p, u.ID),
+			vote.HasOptionWith(polloption.HasPollWith(poll.ID(pollID))),
+		).
+		Exec(r.Context())
 	if err != nil {
-		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to replace vote")
 		return
 	}
 
-	// Remove existing vote if any
-	for _, o := range p.Edges.Options {
-		for _, v := range o.Edges.Votes {
-			_ = tx.Vote.DeleteOneID(v.ID).Exec(context.Background())
+	builders := make([]*ent.VoteCreate, len(optionIDs))
+	for i, optID := range optionIDs {
+		builders[i] = tx.Vote.Create().
+			SetOptionID(optID)
+		if p.Anonymous {
+			builders[i].SetVoterHash(voterHash(pollID, u.ID))
+		} else {
+			builders[i].SetUser(u)
+		}
+		switch p.BallotType {
+		case poll.BallotTypeRanked:
+			builders[i].SetRank(i + 1)
+		case poll.BallotTypeScore:
+			builders[i].SetScore(req.Scores[optID])
 		}
 	}
-
-	// Create new vote
-	_, err = tx.Vote.Create().
-		SetUser(u).
-		SetOption(opt).
-		Save(context.Background())
-	if err != nil {
+	if _, err := tx.Vote.CreateBulk(builders...).Save(r.Context()); err != nil {
 		tx.Rollback()
 		errorResponse(w, http.StatusInternalServerError, "Failed to create vote")
 		return
 	}
+	if p.Anonymous {
+		if err := addParticipant(r.Context(), tx, pollID, u.ID); err != nil {
+			tx.Rollback()
+			errorResponse(w, http.StatusInternalServerError, "Failed to create vote")
+			return
+		}
+	}
 
 	// Create notification for poll creator if vote was changed (not for creator's own votes)
+	var notif *ent.Notification
+	isVoteChange := len(previousIDs) > 0 && (!sameSelection(previousIDs, optionIDs, p.BallotType == poll.BallotTypeRanked) || scoresChanged)
 	if isVoteChange && p.Edges.Creator.ID != u.ID {
 		message := fmt.Sprintf("%s changed their vote on \"%s\" from \"%s\" to \"%s\"",
-			u.Username, p.Title, previousOptionText, opt.Text)
-		_, err = tx.Notification.Create().
+			voterName(p, u), p.Title, joinOptionTexts(optionTexts, previousIDs), joinOptionTexts(optionTexts, optionIDs))
+		if p.BallotType == poll.BallotTypeScore {
+			message = fmt.Sprintf("%s changed their ratings on \"%s\"", voterName(p, u), p.Title)
+		}
+		notif, err = tx.Notification.Create().
 			SetMessage(message).
 			SetType("vote_changed").
 			SetPollID(pollID).
 			SetUserID(p.Edges.Creator.ID).
-			Save(context.Background())
+			Save(r.Context())
 		if err != nil {
 			// Log but don't fail the vote
 			_ = err
 		}
 	}
 
+	h.publishPoll(r.Context(), tx, pollID, events.KindUpdated)
+	if notif != nil {
+		h.notify(r.Context(), tx, notif, p.Edges.Creator.ID)
+	}
 	if err := tx.Commit(); err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
 		return
@@ -656,14 +1232,166 @@ func (h *Handler) Vote(w http.ResponseWriter, r *http.Request, ps httprouter.Par
 	p, _ = h.client.Poll.Query().
 		Where(poll.ID(pollID)).
 		WithCreator().
-		WithOptions(func(q *ent.PollOptionQuery) {
-			q.WithVotes()
-		}).
-		Only(context.Background())
+		WithOptions().
+		Only(r.Context())
 
-	votedOptionID := req.OptionID
-	now := time.Now()
-	jsonResponse(w, http.StatusOK, pollToDTO(p, &votedOptionID, &now))
+	dto, err := h.pollDTO(r.Context(), p, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch poll")
+		return
+	}
+	jsonResponse(w, http.StatusOK, dto)
+}
+
+// validateSelection checks a ballot against the poll's options and choice limits
+func validateSelection(p *ent.Poll, optionIDs []int) string {
+	if len(optionIDs) == 0 {
+		return "At least one option is required"
+	}
+
+	valid := make(map[int]bool, len(p.Edges.Options))
+	for _, o := range p.Edges.Options {
+		valid[o.ID] = true
+	}
+	seen := make(map[int]bool, len(optionIDs))
+	for _, id := range optionIDs {
+		if !valid[id] {
+			return "Invalid option for this poll"
+		}
+		if seen[id] {
+			return "Each option can only be selected once"
+		}
+		seen[id] = true
+	}
+
+	if p.BallotType == poll.BallotTypeSingle {
+		if len(optionIDs) != 1 {
+			return "This poll allows exactly one choice"
+		}
+		return ""
+	}
+	if len(optionIDs) < p.MinChoices || len(optionIDs) > p.MaxChoices {
+		if p.MinChoices == p.MaxChoices {
+			return fmt.Sprintf("Select exactly %d options", p.MinChoices)
+		}
+		return fmt.Sprintf("Select between %d and %d options", p.MinChoices, p.MaxChoices)
+	}
+	return ""
+}
+
+// validateScores checks ratings against the poll's score range
+func validateScores(p *ent.Poll, scores map[int]int) string {
+	if p.BallotType != poll.BallotTypeScore {
+		if len(scores) > 0 {
+			return "Scores are only accepted on score polls"
+		}
+		return ""
+	}
+	if len(scores) == 0 {
+		return "Score polls require a rating for each selected option"
+	}
+	for _, score := range scores {
+		if score < p.ScoreMin || score > p.ScoreMax {
+			return fmt.Sprintf("Scores must be between %d and %d", p.ScoreMin, p.ScoreMax)
+		}
+	}
+	return ""
+}
+
+// choiceLimits resolves min/max choices for a ballot type, falling back to defaults
+func choiceLimits(ballotType poll.BallotType, minChoices, maxChoices *int, optionCount int) (int, int, string) {
+	if ballotType == poll.BallotTypeSingle {
+		return 1, 1, ""
+	}
+
+	minC, maxC := 1, optionCount
+	if minChoices != nil {
+		minC = *minChoices
+	}
+	if maxChoices != nil {
+		maxC = *maxChoices
+	}
+	if minC < 1 || maxC < minC {
+		return 0, 0, "Choice limits must satisfy 1 <= min_choices <= max_choices"
+	}
+	if maxC > optionCount {
+		return 0, 0, "max_choices cannot exceed the number of options"
+	}
+	return minC, maxC, ""
+}
+
+// checkAudience validates a poll's visibility and team. Team polls must name
+// a team the creator belongs to; other polls cannot have one.
+func (h *Handler) checkAudience(ctx context.Context, u *ent.User, visibility poll.Visibility, teamID *int) string {
+	if err := poll.VisibilityValidator(visibility); err != nil {
+		return "Invalid visibility"
+	}
+	if visibility != poll.VisibilityTeam {
+		if teamID != nil {
+			return "team_id is only allowed on team polls"
+		}
+		return ""
+	}
+	if teamID == nil {
+		return "Team polls require a team_id"
+	}
+	member, err := h.client.Team.Query().
+		Where(team.ID(*teamID), team.HasMembersWith(user.ID(u.ID))).
+		Exist(ctx)
+	if err != nil || !member {
+		return "Team not found"
+	}
+	return ""
+}
+
+// resolveInvitees looks up invited users by username or email
+func (h *Handler) resolveInvitees(ctx context.Context, names []string) ([]int, string) {
+	ids := make([]int, 0, len(names))
+	for _, name := range names {
+		invitee, err := h.findUser(ctx, name)
+		if err != nil {
+			return nil, fmt.Sprintf("Unknown invitee: %s", name)
+		}
+		ids = append(ids, invitee.ID)
+	}
+	return ids, ""
+}
+
+// voterName is how a voter is named in notifications
+func voterName(p *ent.Poll, u *ent.User) string {
+	if p.Anonymous {
+		return "Someone"
+	}
+	return u.Username
+}
+
+// sameSelection reports whether two selections pick the same options, and in
+// the same order if ordered
+func sameSelection(a, b []int, ordered bool) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	if ordered {
+		return slices.Equal(a, b)
+	}
+	set := make(map[int]bool, len(a))
+	for _, id := range a {
+		set[id] = true
+	}
+	for _, id := range b {
+		if !set[id] {
+			return false
+		}
+	}
+	return true
+}
+
+func joinOptionTexts(texts map[int]string, ids []int) string {
+	parts := make([]string, len(ids))
+	for i, id := range ids {
+		parts[i] = texts[id]
+	}
+	return strings.Join(parts, ", ")
 }
 
 // ClearVote removes a user's vote from a poll
@@ -682,75 +1410,118 @@ func (h *Handler) ClearVote(w http.ResponseWriter, r *http.Request, ps httproute
 		WithCreator().
 		WithOptions(func(q *ent.PollOptionQuery) {
 			q.WithVotes(func(vq *ent.VoteQuery) {
-				vq.Where(vote.HasUserWith(user.ID(u.ID)))
+				vq.Where(vote.Or(
+					vote.HasUserWith(user.ID(u.ID)),
+					vote.VoterHash(voterHash(pollID, u.ID)),
+				))
 			})
 		}).
-		Only(context.Background())
+		Only(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusNotFound, "Poll not found")
 		return
 	}
 
-	// Find the option text that was voted for (for notification)
-	var votedOptionText string
-	for _, opt := range p.Edges.Options {
-		if len(opt.Edges.Votes) > 0 {
-			votedOptionText = opt.Text
-			break
-		}
+	if !checkPollOpen(w, p) {
+		return
 	}
 
-	// Find and delete user's vote
-	voteDeleted := false
+	// Find the option texts that were voted for (for notification)
+	optionTexts := make(map[int]string, len(p.Edges.Options))
+	var votedIDs []int
 	for _, opt := range p.Edges.Options {
-		for _, v := range opt.Edges.Votes {
-			err := h.client.Vote.DeleteOneID(v.ID).Exec(context.Background())
-			if err == nil {
-				voteDeleted = true
-			}
+		optionTexts[opt.ID] = opt.Text
+		if len(opt.Edges.Votes) > 0 {
+			votedIDs = append(votedIDs, opt.ID)
 		}
 	}
 
-	if !voteDeleted {
+	if len(votedIDs) == 0 {
 		errorResponse(w, http.StatusBadRequest, "You haven't voted on this poll")
 		return
 	}
 
+	// Delete all of the user's votes on this poll
+	_, err = h.client.Vote.Delete().
+		Where(
+			voterPredicate(p, u.ID),
+			vote.HasOptionWith(polloption.HasPollWith(poll.ID(pollID))),
+		).
+		Exec(r.Context())
+	if err == nil && p.Anonymous {
+		err = h.client.User.UpdateOneID(u.ID).RemoveParticipatedPollIDs(pollID).Exec(r.Context())
+	}
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to clear vote")
+		return
+	}
+	h.publishPoll(r.Context(), nil, pollID, events.KindUpdated)
+
 	// Create notification for poll creator (not for creator's own votes)
 	if p.Edges.Creator.ID != u.ID {
 		message := fmt.Sprintf("%s removed their vote (\"%s\") from \"%s\"",
-			u.Username, votedOptionText, p.Title)
-		_, _ = h.client.Notification.Create().
+			voterName(p, u), joinOptionTexts(optionTexts, votedIDs), p.Title)
+		notif, err := h.client.Notification.Create().
 			SetMessage(message).
 			SetType("vote_cleared").
 			SetPollID(pollID).
 			SetUserID(p.Edges.Creator.ID).
-			Save(context.Background())
+			Save(r.Context())
+		if err == nil {
+			h.notify(r.Context(), nil, notif, p.Edges.Creator.ID)
+		}
 	}
 
 	// Fetch updated poll
 	p, _ = h.client.Poll.Query().
 		Where(poll.ID(pollID)).
 		WithCreator().
-		WithOptions(func(q *ent.PollOptionQuery) {
-			q.WithVotes()
-		}).
-		Only(context.Background())
+		WithOptions().
+		Only(r.Context())
 
-	jsonResponse(w, http.StatusOK, pollToDTO(p, nil, nil))
+	dto, err := h.pollDTO(r.Context(), p, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch poll")
+		return
+	}
+	jsonResponse(w, http.StatusOK, dto)
 }
 
 func (h *Handler) GetVoters(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
 	optionID, err := strconv.Atoi(ps.ByName("id"))
 	if err != nil {
 		errorResponse(w, http.StatusBadRequest, "Invalid option ID")
 		return
 	}
 
+	p, err := h.client.Poll.Query().
+		Where(poll.HasOptionsWith(polloption.ID(optionID))).
+		WithCreator().
+		Only(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Option not found")
+		return
+	}
+	if p.Anonymous {
+		errorResponse(w, http.StatusForbidden, "Voters are hidden on anonymous polls")
+		return
+	}
+	voted, err := h.hasVoted(r.Context(), p, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch voters")
+		return
+	}
+	if resultsHidden(p, u.ID, voted) {
+		errorResponse(w, http.StatusForbidden, "Results are hidden for this poll")
+		return
+	}
+
 	votes, err := h.client.Vote.Query().
-		Where(vote.HasOptionWith(polloption.ID(optionID))).
+		Where(vote.HasOptionWith(polloption.ID(optionID)), vote.HasUser()).
 		WithUser().
-		All(context.Background())
+		All(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to fetch voters")
 		return
@@ -768,20 +1539,57 @@ func (h *Handler) GetVoters(w http.ResponseWriter, r *http.Request, ps httproute
 	jsonResponse(w, http.StatusOK, voters)
 }
 
-func pollToDTO(p *ent.Poll, votedOptionID *int, userVoteTime *time.Time) PollDTO {
-	options := make([]OptionDTO, len(p.Edges.Options))
-	for i, opt := range p.Edges.Options {
-		options[i] = OptionDTO{
-			ID:        opt.ID,
-			Text:      opt.Text,
-			VoteCount: len(opt.Edges.Votes),
-		}
+// userSelection is the current user's ballot on a poll
+type userSelection struct {
+	OptionIDs []int
+	Scores    map[int]int
+	VotedAt   time.Time
+}
+
+func (s *userSelection) add(optionID int, v *ent.Vote) {
+	s.OptionIDs = append(s.OptionIDs, optionID)
+	if s.Scores == nil {
+		s.Scores = make(map[int]int)
+	}
+	s.Scores[optionID] = v.Score
+	if v.CreatedAt.After(s.VotedAt) {
+		s.VotedAt = v.CreatedAt
 	}
+}
 
-	// Check if poll was edited after user voted
+// pollToDTO converts a poll for viewerID, whose own ballot is sel. Counts are
+// withheld when the poll's results visibility hides them from the viewer.
+func pollToDTO(p *ent.Poll, viewerID int, sel *userSelection, counts voteCounts) PollDTO {
+	hidden := resultsHidden(p, viewerID, sel != nil && len(sel.OptionIDs) > 0)
+	options := optionsToDTO(p, counts, hidden)
+
+	var votedOptionID *int
+	var votedOptionIDs []int
+	var userScores map[int]int
 	pollEditedAfterVote := false
-	if userVoteTime != nil && p.UpdatedAt.After(*userVoteTime) {
-		pollEditedAfterVote = true
+	if sel != nil && len(sel.OptionIDs) > 0 {
+		votedOptionIDs = sel.OptionIDs
+		votedOptionID = &votedOptionIDs[0]
+		if p.BallotType == poll.BallotTypeScore {
+			userScores = sel.Scores
+		}
+		// Check if poll was edited after user voted
+		pollEditedAfterVote = p.UpdatedAt.After(sel.VotedAt)
+	}
+
+	var scoreMin, scoreMax *int
+	if p.BallotType == poll.BallotTypeScore {
+		scoreMin, scoreMax = &p.ScoreMin, &p.ScoreMax
+	}
+
+	// Invitees are only loaded for the poll's creator
+	var invitees []UserDTO
+	for _, invitee := range p.Edges.Invitees {
+		invitees = append(invitees, UserDTO{ID: invitee.ID, Username: invitee.Username, Email: invitee.Email})
+	}
+	var linkKey string
+	if p.Visibility == poll.VisibilityUnlisted && p.LinkKey != nil && p.Edges.Creator.ID == viewerID {
+		linkKey = *p.LinkKey
 	}
 
 	return PollDTO{
@@ -796,11 +1604,94 @@ func pollToDTO(p *ent.Poll, votedOptionID *int, userVoteTime *time.Time) PollDTO
 		Options:             options,
 		CreatedAt:           p.CreatedAt,
 		UpdatedAt:           p.UpdatedAt,
+		OpensAt:             p.OpensAt,
+		ClosesAt:            p.ClosesAt,
+		Status:              pollStatus(p, time.Now()),
+		BallotType:          string(p.BallotType),
+		MinChoices:          p.MinChoices,
+		MaxChoices:          p.MaxChoices,
+		ScoreMin:            scoreMin,
+		ScoreMax:            scoreMax,
+		Anonymous:           p.Anonymous,
+		Visibility:          string(p.Visibility),
+		TeamID:              p.TeamID,
+		ResultsVisibility:   string(p.ResultsVisibility),
+		ResultsHidden:       hidden,
+		Invitees:            invitees,
+		LinkKey:             linkKey,
 		UserVotedOptionID:   votedOptionID,
+		UserVotedOptionIDs:  votedOptionIDs,
+		UserScores:          userScores,
 		PollEditedAfterVote: pollEditedAfterVote,
 	}
 }
 
+// optionsToDTO converts a poll's options, leaving out their counts and score
+// statistics if hidden
+func optionsToDTO(p *ent.Poll, counts voteCounts, hidden bool) []OptionDTO {
+	options := make([]OptionDTO, len(p.Edges.Options))
+	for i, opt := range p.Edges.Options {
+		options[i] = OptionDTO{
+			ID:   opt.ID,
+			Text: opt.Text,
+		}
+		if hidden {
+			continue
+		}
+		options[i].VoteCount = counts.votes(opt.ID)
+		if p.BallotType == poll.BallotTypeScore {
+			addScoreSummary(&options[i], p, counts[opt.ID])
+		}
+	}
+	return options
+}
+
+// resultsHidden reports whether a poll's results are withheld from viewerID.
+// The creator always sees them, and every policy but creator_only reveals
+// them once the poll has closed.
+func resultsHidden(p *ent.Poll, viewerID int, voted bool) bool {
+	if p.Edges.Creator != nil && p.Edges.Creator.ID == viewerID {
+		return false
+	}
+	closed := pollStatus(p, time.Now()) == PollStatusClosed
+	switch p.ResultsVisibility {
+	case poll.ResultsVisibilityAfterVote:
+		return !voted && !closed
+	case poll.ResultsVisibilityAfterClose:
+		return !closed
+	case poll.ResultsVisibilityCreatorOnly:
+		return true
+	}
+	return false
+}
+
+// hasVoted reports whether userID has a ballot on p
+func (h *Handler) hasVoted(ctx context.Context, p *ent.Poll, userID int) (bool, error) {
+	return h.client.Vote.Query().
+		Where(
+			voterPredicate(p, userID),
+			vote.HasOptionWith(polloption.HasPollWith(poll.ID(p.ID))),
+		).
+		Exist(ctx)
+}
+
+func addScoreSummary(dto *OptionDTO, p *ent.Poll, counts *optionCounts) {
+	var scores map[int]int
+	if counts != nil {
+		scores = counts.Scores
+	}
+	summary := tally.SummarizeScoreCounts(scores, p.ScoreMin, p.ScoreMax)
+
+	dto.ScoreHistogram = make([]ScoreBucketDTO, len(summary.Histogram))
+	for i, count := range summary.Histogram {
+		dto.ScoreHistogram[i] = ScoreBucketDTO{Score: p.ScoreMin + i, Count: count}
+	}
+	if summary.Count > 0 {
+		dto.AverageScore = &summary.Average
+		dto.MedianScore = &summary.Median
+	}
+}
+
 // Notification DTOs
 type NotificationDTO struct {
 	ID        int       `json:"id"`
@@ -819,7 +1710,7 @@ func (h *Handler) GetNotifications(w http.ResponseWriter, r *http.Request, _ htt
 		Where(notification.HasUserWith(user.ID(u.ID))).
 		Order(ent.Desc(notification.FieldCreatedAt)).
 		Limit(50).
-		All(context.Background())
+		All(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to fetch notifications")
 		return
@@ -827,14 +1718,7 @@ func (h *Handler) GetNotifications(w http.ResponseWriter, r *http.Request, _ htt
 
 	dtos := make([]NotificationDTO, len(notifications))
 	for i, n := range notifications {
-		dtos[i] = NotificationDTO{
-			ID:        n.ID,
-			Message:   n.Message,
-			Type:      n.Type,
-			PollID:    n.PollID,
-			Read:      n.Read,
-			CreatedAt: n.CreatedAt,
-		}
+		dtos[i] = notificationToDTO(n)
 	}
 
 	jsonResponse(w, http.StatusOK, dtos)
@@ -844,12 +1728,7 @@ func (h *Handler) GetNotifications(w http.ResponseWriter, r *http.Request, _ htt
 func (h *Handler) GetUnreadCount(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
 	u := r.Context().Value(userContextKey).(*ent.User)
 
-	count, err := h.client.Notification.Query().
-		Where(
-			notification.HasUserWith(user.ID(u.ID)),
-			notification.Read(false),
-		).
-		Count(context.Background())
+	count, err := h.unreadCount(r.Context(), u.ID)
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to count notifications")
 		return
@@ -874,7 +1753,7 @@ func (h *Handler) MarkNotificationRead(w http.ResponseWriter, r *http.Request, p
 			notification.ID(notifID),
 			notification.HasUserWith(user.ID(u.ID)),
 		).
-		Only(context.Background())
+		Only(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusNotFound, "Notification not found")
 		return
@@ -882,11 +1761,12 @@ func (h *Handler) MarkNotificationRead(w http.ResponseWriter, r *http.Request, p
 
 	_, err = h.client.Notification.UpdateOne(n).
 		SetRead(true).
-		Save(context.Background())
+		Save(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to update notification")
 		return
 	}
+	h.publishUser(r.Context(), nil, u.ID, userEvent{Type: MessageRead, IDs: []int{n.ID}})
 
 	jsonResponse(w, http.StatusOK, map[string]string{"message": "Notification marked as read"})
 }
@@ -901,11 +1781,12 @@ func (h *Handler) MarkAllNotificationsRead(w http.ResponseWriter, r *http.Reques
 			notification.Read(false),
 		).
 		SetRead(true).
-		Save(context.Background())
+		Save(r.Context())
 	if err != nil {
 		errorResponse(w, http.StatusInternalServerError, "Failed to update notifications")
 		return
 	}
+	h.publishUser(r.Context(), nil, u.ID, userEvent{Type: MessageReadAll})
 
 	jsonResponse(w, http.StatusOK, map[string]string{"message": "All notifications marked as read"})
 }
@@ -0,0 +1,113 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+
+	"poll_app/ent"
+	"poll_app/ent/enttest"
+	"poll_app/ratelimit"
+
+	"entgo.io/ent/dialect"
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/julienschmidt/httprouter"
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// queryCounter counts the statements a client sends to the database
+type queryCounter struct {
+	n atomic.Int64
+}
+
+func (c *queryCounter) reset()       { c.n.Store(0) }
+func (c *queryCounter) count() int64 { return c.n.Load() }
+
+// openTestClient opens a client on a private in-memory SQLite database with
+// the schema created, counting its statements in counter if not nil. The
+// database has a single connection, so concurrent transactions wait for each
+// other as they would for row locks instead of failing on a locked table.
+// Rate limits and lockouts start afresh too.
+func openTestClient(t *testing.T, counter *queryCounter) *ent.Client {
+	t.Helper()
+	SetRateLimitStore(ratelimit.NewMemoryStore())
+	sqlDrv, err := entsql.Open(dialect.SQLite, fmt.Sprintf("file:%s?mode=memory&cache=shared&_fk=1", t.Name()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDrv.DB().SetMaxOpenConns(1)
+	var drv dialect.Driver = sqlDrv
+	if counter != nil {
+		drv = dialect.DebugWithContext(drv, func(context.Context, ...any) { counter.n.Add(1) })
+	}
+	client := enttest.NewClient(t, enttest.WithOptions(ent.Driver(drv)))
+	t.Cleanup(func() { client.Close() })
+	return client
+}
+
+// createUser stores a user with the given name and the password "password"
+func createUser(t *testing.T, client *ent.Client, name string) *ent.User {
+	t.Helper()
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := client.User.Create().
+		SetUsername(name).
+		SetEmail(name + "@example.com").
+		SetPassword(string(hash)).
+		Save(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// serve calls handle with body encoded as JSON, signed in as u if not nil
+func serve(t *testing.T, handle httprouter.Handle, u *ent.User, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	return serveParams(t, handle, u, nil, body)
+}
+
+// serveID is serve for routes with an :id parameter
+func serveID(t *testing.T, handle httprouter.Handle, u *ent.User, id int, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	return serveParams(t, handle, u, httprouter.Params{{Key: "id", Value: strconv.Itoa(id)}}, body)
+}
+
+func serveParams(t *testing.T, handle httprouter.Handle, u *ent.User, ps httprouter.Params, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	var b bytes.Buffer
+	if body != nil {
+		if err := json.NewEncoder(&b).Encode(body); err != nil {
+			t.Fatal(err)
+		}
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", &b)
+	if u != nil {
+		req = req.WithContext(withUser(req.Context(), u))
+	}
+	rec := httptest.NewRecorder()
+	handle(rec, req, ps)
+	return rec
+}
+
+// decode reads the JSON response of rec into v, failing unless it has status
+func decode(t *testing.T, rec *httptest.ResponseRecorder, status int, v any) {
+	t.Helper()
+	if rec.Code != status {
+		t.Fatalf("status = %d, want %d, body %s", rec.Code, status, rec.Body)
+	}
+	if v != nil {
+		if err := json.Unmarshal(rec.Body.Bytes(), v); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,360 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/invite"
+	"poll_app/ent/poll"
+	"poll_app/ent/predicate"
+	"poll_app/ent/privacy"
+	"poll_app/ent/user"
+
+	entsql "entgo.io/ent/dialect/sql"
+	"github.com/julienschmidt/httprouter"
+)
+
+type CreateInviteRequest struct {
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+}
+
+type RedeemInviteRequest struct {
+	Token string `json:"token"`
+}
+
+type InviteDTO struct {
+	ID     int `json:"id"`
+	PollID int `json:"poll_id"`
+	// Token is only returned when the invite is created
+	Token     string     `json:"token,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+	MaxUses   *int       `json:"max_uses,omitempty"`
+	Uses      int        `json:"uses"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+type RedeemInviteResponse struct {
+	PollID int `json:"poll_id"`
+}
+
+type JoinPollRequest struct {
+	Key string `json:"key"`
+}
+
+// inviteHasUsesLeft matches invites without a limit or below their max_uses
+func inviteHasUsesLeft() predicate.Invite {
+	return invite.Or(
+		invite.MaxUsesIsNil(),
+		predicate.Invite(func(s *entsql.Selector) {
+			s.Where(entsql.ColumnsLT(s.C(invite.FieldUses), s.C(invite.FieldMaxUses)))
+		}),
+	)
+}
+
+// creatorPoll loads a poll for invite management, writing an error unless u created it
+func (h *Handler) creatorPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params, u *ent.User) (*ent.Poll, bool) {
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid poll ID")
+		return nil, false
+	}
+
+	p, err := h.client.Poll.Query().
+		Where(poll.ID(id)).
+		WithCreator().
+		Only(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Poll not found")
+		return nil, false
+	}
+	if p.Edges.Creator.ID != u.ID {
+		errorResponse(w, http.StatusForbidden, "Only the poll creator can manage invites")
+		return nil, false
+	}
+	return p, true
+}
+
+func (h *Handler) CreateInvite(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	p, ok := h.creatorPoll(w, r, ps, u)
+	if !ok {
+		return
+	}
+
+	var req CreateInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		errorResponse(w, http.StatusBadRequest, "Expiry must be in the future")
+		return
+	}
+	if req.MaxUses != nil && *req.MaxUses < 1 {
+		errorResponse(w, http.StatusBadRequest, "max_uses must be at least 1")
+		return
+	}
+
+	token, tokenHash, err := newToken()
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to generate invite")
+		return
+	}
+
+	inv, err := h.client.Invite.Create().
+		SetTokenHash(tokenHash).
+		SetNillableExpiresAt(req.ExpiresAt).
+		SetNillableMaxUses(req.MaxUses).
+		SetPoll(p).
+		SetCreator(u).
+		Save(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to create invite")
+		return
+	}
+
+	dto := inviteToDTO(inv, p.ID)
+	dto.Token = token
+	jsonResponse(w, http.StatusCreated, dto)
+}
+
+// ListInvites returns a poll's invites that can still be redeemed
+func (h *Handler) ListInvites(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	p, ok := h.creatorPoll(w, r, ps, u)
+	if !ok {
+		return
+	}
+
+	invites, err := h.client.Invite.Query().
+		Where(
+			invite.HasPollWith(poll.ID(p.ID)),
+			invite.RevokedAtIsNil(),
+			invite.Or(invite.ExpiresAtIsNil(), invite.ExpiresAtGT(time.Now())),
+			inviteHasUsesLeft(),
+		).
+		Order(ent.Desc(invite.FieldCreatedAt)).
+		All(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch invites")
+		return
+	}
+
+	dtos := make([]InviteDTO, len(invites))
+	for i, inv := range invites {
+		dtos[i] = inviteToDTO(inv, p.ID)
+	}
+	jsonResponse(w, http.StatusOK, dtos)
+}
+
+func (h *Handler) RevokeInvite(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid invite ID")
+		return
+	}
+
+	inv, err := h.client.Invite.Query().
+		Where(invite.ID(id), invite.HasPollWith(poll.HasCreatorWith(user.ID(u.ID)))).
+		Only(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Invite not found")
+		return
+	}
+	if inv.RevokedAt != nil {
+		errorResponse(w, http.StatusConflict, "Invite is already revoked")
+		return
+	}
+
+	if err := h.client.Invite.UpdateOne(inv).SetRevokedAt(time.Now()).Exec(r.Context()); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to revoke invite")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RedeemInvite adds the current user to a poll's invitees, which grants access
+// to private polls. Users who can already see the poll through an invite or as
+// its creator do not use up the invite.
+func (h *Handler) RedeemInvite(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	var req RedeemInviteRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		errorResponse(w, http.StatusBadRequest, "Invite token is required")
+		return
+	}
+
+	// The poll is not visible to the user until the invite is redeemed
+	allow := privacy.DecisionContext(r.Context(), privacy.Allow)
+
+	inv, err := h.client.Invite.Query().
+		Where(invite.TokenHash(hashToken(req.Token))).
+		WithPoll().
+		Only(allow)
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Invite not found")
+		return
+	}
+	pollID := inv.Edges.Poll.ID
+
+	switch {
+	case inv.RevokedAt != nil:
+		errorResponse(w, http.StatusGone, "Invite has been revoked")
+		return
+	case inv.ExpiresAt != nil && !inv.ExpiresAt.After(time.Now()):
+		errorResponse(w, http.StatusGone, "Invite has expired")
+		return
+	}
+
+	member, err := h.client.Poll.Query().
+		Where(
+			poll.ID(pollID),
+			poll.Or(poll.HasCreatorWith(user.ID(u.ID)), poll.HasInviteesWith(user.ID(u.ID))),
+		).
+		Exist(allow)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to redeem invite")
+		return
+	}
+	if member {
+		jsonResponse(w, http.StatusOK, RedeemInviteResponse{PollID: pollID})
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+
+	// Claim a use only if one is left, so concurrent redemptions cannot overshoot max_uses
+	n, err := tx.Invite.Update().
+		Where(invite.ID(inv.ID), invite.RevokedAtIsNil(), inviteHasUsesLeft()).
+		AddUses(1).
+		Save(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to redeem invite")
+		return
+	}
+	if n == 0 {
+		tx.Rollback()
+		errorResponse(w, http.StatusGone, "Invite has no uses left")
+		return
+	}
+
+	// Added from the user side so the poll's updated_at is left alone
+	if err := tx.User.UpdateOneID(u.ID).AddInvitedPollIDs(pollID).Exec(r.Context()); err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to redeem invite")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, RedeemInviteResponse{PollID: pollID})
+}
+
+// JoinPoll adds the current user to the invitees of an unlisted poll when they
+// open its link, which carries the poll's link key. Unlisted polls stay hidden
+// from everyone else, so knowing a poll ID is not enough to see one.
+func (h *Handler) JoinPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid poll ID")
+		return
+	}
+
+	var req JoinPollRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" {
+		errorResponse(w, http.StatusBadRequest, "Link key is required")
+		return
+	}
+
+	// The poll is not visible to the user until they join it
+	allow := privacy.DecisionContext(r.Context(), privacy.Allow)
+
+	found, err := h.client.Poll.Query().
+		Where(poll.ID(id), poll.VisibilityEQ(poll.VisibilityUnlisted), poll.LinkKey(req.Key)).
+		Exist(allow)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to join poll")
+		return
+	}
+	if !found {
+		errorResponse(w, http.StatusNotFound, "Poll not found")
+		return
+	}
+
+	member, err := h.client.Poll.Query().
+		Where(
+			poll.ID(id),
+			poll.Or(poll.HasCreatorWith(user.ID(u.ID)), poll.HasInviteesWith(user.ID(u.ID))),
+		).
+		Exist(allow)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to join poll")
+		return
+	}
+	if !member {
+		// Added from the user side so the poll's updated_at is left alone
+		if err := h.client.User.UpdateOneID(u.ID).AddInvitedPollIDs(id).Exec(r.Context()); err != nil {
+			errorResponse(w, http.StatusInternalServerError, "Failed to join poll")
+			return
+		}
+	}
+
+	jsonResponse(w, http.StatusOK, RedeemInviteResponse{PollID: id})
+}
+
+// BackfillLinkKeys gives a link key to the polls created before polls had one
+func (h *Handler) BackfillLinkKeys(ctx context.Context) error {
+	ctx = privacy.DecisionContext(ctx, privacy.Allow)
+	polls, err := h.client.Poll.Query().
+		Where(poll.LinkKeyIsNil()).
+		All(ctx)
+	if err != nil {
+		return err
+	}
+	for _, p := range polls {
+		key, _, err := newToken()
+		if err != nil {
+			return err
+		}
+		err = h.client.Poll.UpdateOne(p).
+			SetLinkKey(key).
+			SetUpdatedAt(p.UpdatedAt).
+			Exec(ctx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func inviteToDTO(inv *ent.Invite, pollID int) InviteDTO {
+	return InviteDTO{
+		ID:        inv.ID,
+		PollID:    pollID,
+		ExpiresAt: inv.ExpiresAt,
+		MaxUses:   inv.MaxUses,
+		Uses:      inv.Uses,
+		CreatedAt: inv.CreatedAt,
+	}
+}
@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"poll_app/ent/poll"
+)
+
+// TestJoinUnlistedPoll checks that an unlisted poll is hidden from users who
+// only know its ID, and opens to anyone presenting its link key
+func TestJoinUnlistedPoll(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	creator := createUser(t, client, "creator")
+	jane := createUser(t, client, "jane")
+	p, _ := createPoll(t, client, creator, poll.BallotTypeSingle, 1, 1)
+	p = client.Poll.UpdateOne(p).SetVisibility(poll.VisibilityUnlisted).SaveX(context.Background())
+
+	var owned PollDTO
+	decode(t, serveID(t, h.GetPoll, creator, p.ID, nil), http.StatusOK, &owned)
+	if owned.LinkKey == "" || owned.LinkKey != *p.LinkKey {
+		t.Fatalf("creator got link key %q, want %q", owned.LinkKey, *p.LinkKey)
+	}
+
+	decode(t, serveID(t, h.GetPoll, jane, p.ID, nil), http.StatusNotFound, nil)
+	decode(t, serveID(t, h.JoinPoll, jane, p.ID, JoinPollRequest{Key: "wrong"}), http.StatusNotFound, nil)
+	decode(t, serveID(t, h.GetPoll, jane, p.ID, nil), http.StatusNotFound, nil)
+
+	var joined RedeemInviteResponse
+	decode(t, serveID(t, h.JoinPoll, jane, p.ID, JoinPollRequest{Key: owned.LinkKey}), http.StatusOK, &joined)
+	if joined.PollID != p.ID {
+		t.Errorf("joined poll %d, want %d", joined.PollID, p.ID)
+	}
+	var seen PollDTO
+	decode(t, serveID(t, h.GetPoll, jane, p.ID, nil), http.StatusOK, &seen)
+	if seen.LinkKey != "" {
+		t.Error("the link key was shown to someone other than the creator")
+	}
+
+	// Joining again is a no-op
+	decode(t, serveID(t, h.JoinPoll, jane, p.ID, JoinPollRequest{Key: owned.LinkKey}), http.StatusOK, nil)
+
+	// The key of an unlisted poll does not open it once it is private
+	client.Poll.UpdateOne(p).SetVisibility(poll.VisibilityPrivate).ExecX(context.Background())
+	sam := createUser(t, client, "sam")
+	decode(t, serveID(t, h.JoinPoll, sam, p.ID, JoinPollRequest{Key: owned.LinkKey}), http.StatusNotFound, nil)
+}
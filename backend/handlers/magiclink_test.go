@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"poll_app/ratelimit"
+)
+
+// TestRequestMagicLinkLimit checks that the per-address limit of the route
+// counts requests for unknown emails like those for accounts, so a 429
+// reveals nothing, and that the handler adds no limit of its own
+func TestRequestMagicLinkLimit(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	createUser(t, client, "jane")
+	limit := ratelimit.Limit{Events: 10, Per: 15 * time.Minute}
+	handle := h.LimitIP(limit, h.RequestMagicLink)
+
+	tests := []struct {
+		name  string
+		email string
+		addr  string
+	}{
+		{"registered", "jane@example.com", "192.0.2.1:1234"},
+		{"unknown", "nobody@example.com", "192.0.2.2:1234"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := func() *httptest.ResponseRecorder {
+				body, err := json.Marshal(MagicLinkRequest{Email: tt.email})
+				if err != nil {
+					t.Fatal(err)
+				}
+				req := httptest.NewRequest(http.MethodPost, "/api/auth/magic-link", bytes.NewReader(body))
+				req.RemoteAddr = tt.addr
+				rec := httptest.NewRecorder()
+				handle(rec, req, nil)
+				return rec
+			}
+			for i := 0; i < limit.Events; i++ {
+				decode(t, request(), http.StatusAccepted, nil)
+			}
+			rec := request()
+			decode(t, rec, http.StatusTooManyRequests, nil)
+			if rec.Header().Get("Retry-After") == "" {
+				t.Error("no Retry-After header")
+			}
+		})
+	}
+}
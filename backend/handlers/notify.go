@@ -0,0 +1,179 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/notification"
+	"poll_app/ent/user"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+)
+
+// Notification socket message types
+const (
+	// MessageSync carries the unread count when a socket connects
+	MessageSync         = "sync"
+	MessageNotification = "notification"
+	// MessageRead lists notifications marked read in another tab
+	MessageRead    = "read"
+	MessageReadAll = "read_all"
+)
+
+// NotificationMessage is pushed to each of a user's open notification sockets
+type NotificationMessage struct {
+	Type         string           `json:"type"`
+	Notification *NotificationDTO `json:"notification,omitempty"`
+	IDs          []int            `json:"ids,omitempty"`
+	UnreadCount  int              `json:"unread_count"`
+}
+
+const (
+	socketWriteWait  = 10 * time.Second
+	socketPongWait   = 60 * time.Second
+	socketPingPeriod = socketPongWait * 9 / 10
+)
+
+var allowedOrigins = map[string]bool{}
+
+// SetAllowedOrigins sets the browser origins allowed to open sockets. Requests
+// without an Origin header, which do not come from browsers, are always allowed.
+func SetAllowedOrigins(origins []string) {
+	allowedOrigins = make(map[string]bool, len(origins))
+	for _, o := range origins {
+		allowedOrigins[o] = true
+	}
+}
+
+var upgrader = websocket.Upgrader{
+	CheckOrigin: func(r *http.Request) bool {
+		origin := r.Header.Get("Origin")
+		return origin == "" || allowedOrigins[origin]
+	},
+}
+
+// notify pushes a notification created within tx, or outside a transaction if
+// tx is nil, to the user's open sockets
+func (h *Handler) notify(ctx context.Context, tx *ent.Tx, n *ent.Notification, userID int) {
+	h.publishUser(ctx, tx, userID, userEvent{Type: MessageNotification, NotificationID: n.ID})
+}
+
+func (h *Handler) unreadCount(ctx context.Context, userID int) (int, error) {
+	return h.client.Notification.Query().
+		Where(
+			notification.HasUserWith(user.ID(userID)),
+			notification.Read(false),
+		).
+		Count(ctx)
+}
+
+// NotificationSocket streams the user's notifications and read-state changes
+// over a WebSocket. Browsers cannot set headers on WebSocket requests, so the
+// JWT may also be passed as the token query parameter. The socket is closed
+// once the token is no longer valid.
+func (h *Handler) NotificationSocket(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if tokenString == "" {
+		tokenString = r.URL.Query().Get("token")
+	}
+	if tokenString == "" {
+		errorResponse(w, http.StatusUnauthorized, "Authorization header required")
+		return
+	}
+	u, _, msg := h.authenticate(r.Context(), tokenString)
+	if msg != "" {
+		errorResponse(w, http.StatusUnauthorized, msg)
+		return
+	}
+
+	conn, err := upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		// Upgrade has already written the error response
+		return
+	}
+	defer conn.Close()
+
+	msgs, cancel := h.feed.Subscribe(u.ID)
+	defer cancel()
+
+	// The client only sends control frames; reading handles pongs and notices disconnects
+	closed := make(chan struct{})
+	go func() {
+		defer close(closed)
+		conn.SetReadLimit(512)
+		conn.SetReadDeadline(time.Now().Add(socketPongWait))
+		conn.SetPongHandler(func(string) error {
+			return conn.SetReadDeadline(time.Now().Add(socketPongWait))
+		})
+		for {
+			if _, _, err := conn.ReadMessage(); err != nil {
+				return
+			}
+		}
+	}()
+
+	count, err := h.unreadCount(r.Context(), u.ID)
+	if err != nil {
+		return
+	}
+	if err := writeSocketJSON(conn, NotificationMessage{Type: MessageSync, UnreadCount: count}); err != nil {
+		return
+	}
+
+	ping := time.NewTicker(socketPingPeriod)
+	defer ping.Stop()
+	recheck := time.NewTicker(sessionCheckInterval)
+	defer recheck.Stop()
+
+	for {
+		select {
+		case <-closed:
+			return
+		case <-ping.C:
+			conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		case <-recheck.C:
+			// The client reconnects with a fresh token, or signs out if the
+			// session was revoked
+			if _, _, msg := h.authenticate(r.Context(), tokenString); msg != "" {
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.ClosePolicyViolation, msg),
+					time.Now().Add(socketWriteWait))
+				return
+			}
+		case m, ok := <-msgs:
+			if !ok {
+				// Fell too far behind or messages were lost; the client reconnects and resyncs
+				conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseTryAgainLater, "resync"),
+					time.Now().Add(socketWriteWait))
+				return
+			}
+			if err := writeSocketJSON(conn, m); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeSocketJSON(conn *websocket.Conn, v any) error {
+	conn.SetWriteDeadline(time.Now().Add(socketWriteWait))
+	return conn.WriteJSON(v)
+}
+
+func notificationToDTO(n *ent.Notification) NotificationDTO {
+	return NotificationDTO{
+		ID:        n.ID,
+		Message:   n.Message,
+		Type:      n.Type,
+		PollID:    n.PollID,
+		Read:      n.Read,
+		CreatedAt: n.CreatedAt,
+	}
+}
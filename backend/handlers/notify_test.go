@@ -0,0 +1,49 @@
+package handlers
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"poll_app/ent/session"
+	"poll_app/ent/user"
+
+	"github.com/gorilla/websocket"
+	"github.com/julienschmidt/httprouter"
+)
+
+// TestSocketClosesWithSession checks that revoking the session a notification
+// socket was opened with closes the socket
+func TestSocketClosesWithSession(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	u := createUser(t, client, "jane")
+	token := startTestSession(t, h, u)
+
+	router := httprouter.New()
+	router.GET("/ws", h.NotificationSocket)
+	srv := httptest.NewServer(router)
+	defer srv.Close()
+
+	conn, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(srv.URL, "http")+"/ws?token="+token, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	var sync NotificationMessage
+	if err := conn.ReadJSON(&sync); err != nil || sync.Type != MessageSync {
+		t.Fatalf("first message = %+v, %v; want a sync", sync, err)
+	}
+
+	client.Session.Update().
+		Where(session.HasUserWith(user.ID(u.ID))).
+		SetRevokedAt(time.Now()).
+		ExecX(context.Background())
+	conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	_, _, err = conn.ReadMessage()
+	if !websocket.IsCloseError(err, websocket.ClosePolicyViolation) {
+		t.Errorf("read after revoking the session: %v, want a policy violation close", err)
+	}
+}
@@ -0,0 +1,404 @@
+package handlers
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/externalidentity"
+	"poll_app/ent/user"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+	"github.com/julienschmidt/httprouter"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// oidcFlowTTL is how long the user has to log in at the provider
+	oidcFlowTTL = 10 * time.Minute
+	// oidcLoginCodeTTL is how long the frontend has to exchange its login code
+	oidcLoginCodeTTL = time.Minute
+	oidcCookieName   = "oidc_flow"
+)
+
+// OIDCConfig configures single sign-on through an OpenID Connect provider
+type OIDCConfig struct {
+	// Issuer is the provider's URL; its configuration is discovered from
+	// Issuer/.well-known/openid-configuration
+	Issuer       string
+	ClientID     string
+	ClientSecret string
+	// RedirectURL is where the provider sends the user back to, the
+	// /api/auth/oidc/callback endpoint of this server
+	RedirectURL string
+	// Name labels the login button, e.g. the company name
+	Name string
+	// AllowedDomains, if set, limits sign-in to emails at these domains
+	AllowedDomains []string
+}
+
+// oidcSSO holds the provider configuration, discovered on first use so that
+// the server starts even if the provider is unreachable
+type oidcSSO struct {
+	config OIDCConfig
+
+	mu       sync.Mutex
+	provider *oidc.Provider
+}
+
+var sso *oidcSSO
+
+// SetOIDC enables single sign-on with cfg
+func SetOIDC(cfg OIDCConfig) {
+	sso = &oidcSSO{config: cfg}
+}
+
+func (s *oidcSSO) discover(ctx context.Context) (*oidc.Provider, *oauth2.Config, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.provider == nil {
+		ctx, cancel := context.WithTimeout(ctx, 10*time.Second)
+		defer cancel()
+		provider, err := oidc.NewProvider(ctx, s.config.Issuer)
+		if err != nil {
+			return nil, nil, err
+		}
+		s.provider = provider
+	}
+
+	return s.provider, &oauth2.Config{
+		ClientID:     s.config.ClientID,
+		ClientSecret: s.config.ClientSecret,
+		RedirectURL:  s.config.RedirectURL,
+		Endpoint:     s.provider.Endpoint(),
+		Scopes:       []string{oidc.ScopeOpenID, "email", "profile"},
+	}, nil
+}
+
+func (s *oidcSSO) allowedEmail(email string) bool {
+	if len(s.config.AllowedDomains) == 0 {
+		return true
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+	domain := strings.ToLower(email[at+1:])
+	for _, d := range s.config.AllowedDomains {
+		if domain == strings.ToLower(d) {
+			return true
+		}
+	}
+	return false
+}
+
+type OIDCConfigResponse struct {
+	Enabled bool   `json:"enabled"`
+	Name    string `json:"name,omitempty"`
+}
+
+type OIDCExchangeRequest struct {
+	Code string `json:"code"`
+}
+
+// oidcClaims are the ID token claims used to find or create the user
+type oidcClaims struct {
+	Subject           string `json:"sub"`
+	Email             string `json:"email"`
+	EmailVerified     bool   `json:"email_verified"`
+	PreferredUsername string `json:"preferred_username"`
+	Name              string `json:"name"`
+}
+
+var errOIDCEmailNotAllowed = errors.New("email is not verified or not allowed")
+
+// errOIDCUnverifiedAccount means the email belongs to a user who never
+// confirmed it. Whoever signed up with it may not own it, so linking could
+// hand them the SSO account.
+var errOIDCUnverifiedAccount = errors.New("email belongs to an unverified account")
+
+// GetOIDCConfig tells the frontend whether to offer single sign-on
+func (h *Handler) GetOIDCConfig(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if sso == nil {
+		jsonResponse(w, http.StatusOK, OIDCConfigResponse{})
+		return
+	}
+	jsonResponse(w, http.StatusOK, OIDCConfigResponse{Enabled: true, Name: sso.config.Name})
+}
+
+// OIDCLogin sends the browser to the provider with a fresh state, nonce and
+// PKCE verifier, which a short-lived cookie keeps for the callback
+func (h *Handler) OIDCLogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if sso == nil {
+		errorResponse(w, http.StatusNotFound, "Single sign-on is not configured")
+		return
+	}
+
+	_, oauth, err := sso.discover(r.Context())
+	if err != nil {
+		log.Printf("oidc discovery failed: %v", err)
+		h.oidcFail(w, r, "unavailable")
+		return
+	}
+
+	state, _, err := newToken()
+	if err != nil {
+		h.oidcFail(w, r, "unavailable")
+		return
+	}
+	nonce, _, err := newToken()
+	if err != nil {
+		h.oidcFail(w, r, "unavailable")
+		return
+	}
+	verifier := oauth2.GenerateVerifier()
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcCookieName,
+		Value:    strings.Join([]string{state, nonce, verifier}, "."),
+		Path:     "/api/auth/oidc",
+		MaxAge:   int(oidcFlowTTL.Seconds()),
+		HttpOnly: true,
+		Secure:   strings.HasPrefix(sso.config.RedirectURL, "https://"),
+		// Lax so the cookie comes back on the provider's redirect
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, oauth.AuthCodeURL(state, oidc.Nonce(nonce), oauth2.S256ChallengeOption(verifier)), http.StatusFound)
+}
+
+// OIDCCallback completes the authorization code flow, finds or creates the
+// user and sends the browser to the frontend with a one-time login code
+func (h *Handler) OIDCCallback(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if sso == nil {
+		errorResponse(w, http.StatusNotFound, "Single sign-on is not configured")
+		return
+	}
+
+	cookie, err := r.Cookie(oidcCookieName)
+	// The cookie is single use
+	http.SetCookie(w, &http.Cookie{Name: oidcCookieName, Path: "/api/auth/oidc", MaxAge: -1})
+	if err != nil {
+		h.oidcFail(w, r, "expired")
+		return
+	}
+	parts := strings.Split(cookie.Value, ".")
+	if len(parts) != 3 || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(r.URL.Query().Get("state"))) != 1 {
+		h.oidcFail(w, r, "expired")
+		return
+	}
+	nonce, verifier := parts[1], parts[2]
+
+	// The user cancelled or the provider refused
+	if e := r.URL.Query().Get("error"); e != "" {
+		log.Printf("oidc provider returned %s: %s", e, r.URL.Query().Get("error_description"))
+		h.oidcFail(w, r, "denied")
+		return
+	}
+
+	provider, oauth, err := sso.discover(r.Context())
+	if err != nil {
+		log.Printf("oidc discovery failed: %v", err)
+		h.oidcFail(w, r, "unavailable")
+		return
+	}
+
+	token, err := oauth.Exchange(r.Context(), r.URL.Query().Get("code"), oauth2.VerifierOption(verifier))
+	if err != nil {
+		log.Printf("oidc code exchange failed: %v", err)
+		h.oidcFail(w, r, "failed")
+		return
+	}
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		h.oidcFail(w, r, "failed")
+		return
+	}
+	idToken, err := provider.Verifier(&oidc.Config{ClientID: sso.config.ClientID}).Verify(r.Context(), rawIDToken)
+	if err != nil {
+		log.Printf("oidc id token rejected: %v", err)
+		h.oidcFail(w, r, "failed")
+		return
+	}
+	if subtle.ConstantTimeCompare([]byte(idToken.Nonce), []byte(nonce)) != 1 {
+		h.oidcFail(w, r, "failed")
+		return
+	}
+
+	var claims oidcClaims
+	if err := idToken.Claims(&claims); err != nil {
+		h.oidcFail(w, r, "failed")
+		return
+	}
+	claims.Subject = idToken.Subject
+
+	u, err := h.oidcUser(r.Context(), idToken.Issuer, claims)
+	if err != nil {
+		if errors.Is(err, errOIDCEmailNotAllowed) {
+			h.oidcFail(w, r, "not_allowed")
+			return
+		}
+		if errors.Is(err, errOIDCUnverifiedAccount) {
+			h.oidcFail(w, r, "unverified")
+			return
+		}
+		log.Printf("oidc sign-in failed: %v", err)
+		h.oidcFail(w, r, "failed")
+		return
+	}
+
+	code, err := h.createAuthToken(r.Context(), u, authtoken.KindOidcLogin, oidcLoginCodeTTL)
+	if err != nil {
+		h.oidcFail(w, r, "failed")
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("%s/oidc/callback?code=%s", frontendURL, url.QueryEscape(code)), http.StatusFound)
+}
+
+// oidcUser returns the user linked to the provider account. An account
+// seen for the first time is linked to the user with its email if they
+// verified it, or a new user is created.
+func (h *Handler) oidcUser(ctx context.Context, issuer string, claims oidcClaims) (*ent.User, error) {
+	identity, err := h.client.ExternalIdentity.Query().
+		Where(externalidentity.Issuer(issuer), externalidentity.Subject(claims.Subject)).
+		WithUser().
+		Only(ctx)
+	if err == nil {
+		err = h.client.ExternalIdentity.UpdateOne(identity).
+			SetEmail(claims.Email).
+			SetLastLoginAt(time.Now()).
+			Exec(ctx)
+		return identity.Edges.User, err
+	}
+	if !ent.IsNotFound(err) {
+		return nil, err
+	}
+
+	// Linking by email trusts the provider to have verified it
+	if !claims.EmailVerified || !validEmail(claims.Email) || !sso.allowedEmail(claims.Email) {
+		return nil, errOIDCEmailNotAllowed
+	}
+
+	tx, err := h.client.Tx(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	u, err := tx.User.Query().Where(user.Email(claims.Email)).Only(ctx)
+	if ent.IsNotFound(err) {
+		u, err = createSSOUser(ctx, tx, claims)
+	} else if err == nil && !u.EmailVerified {
+		err = errOIDCUnverifiedAccount
+	}
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	err = tx.ExternalIdentity.Create().
+		SetIssuer(issuer).
+		SetSubject(claims.Subject).
+		SetEmail(claims.Email).
+		SetUser(u).
+		Exec(ctx)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return u, tx.Commit()
+}
+
+// createSSOUser provisions a user without a password, named after the
+// provider's username or the email address
+func createSSOUser(ctx context.Context, tx *ent.Tx, claims oidcClaims) (*ent.User, error) {
+	base := claims.PreferredUsername
+	if base == "" || strings.Contains(base, "@") {
+		base = claims.Email[:strings.LastIndex(claims.Email, "@")]
+	}
+
+	username := base
+	for i := 2; ; i++ {
+		taken, err := tx.User.Query().Where(user.Username(username)).Exist(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if !taken {
+			break
+		}
+		username = fmt.Sprintf("%s%d", base, i)
+	}
+
+	return tx.User.Create().
+		SetUsername(username).
+		SetEmail(claims.Email).
+		SetEmailVerified(true).
+		Save(ctx)
+}
+
+// oidcFail sends the browser back to the frontend's login page with reason
+func (h *Handler) oidcFail(w http.ResponseWriter, r *http.Request, reason string) {
+	http.Redirect(w, r, fmt.Sprintf("%s/login?sso_error=%s", frontendURL, reason), http.StatusFound)
+}
+
+// OIDCExchange trades the login code from OIDCCallback for tokens. Users
+// with two-factor authentication get a challenge, as with Login.
+func (h *Handler) OIDCExchange(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req OIDCExchangeRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		errorResponse(w, http.StatusBadRequest, "Code is required")
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	at, err := consumeAuthToken(r.Context(), tx, req.Code, authtoken.KindOidcLogin)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, errInvalidAuthToken) {
+			errorResponse(w, http.StatusUnauthorized, "Login has expired, please sign in again")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+	u := at.Edges.User
+
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	if u.TotpEnabled {
+		challenge, err := h.createAuthToken(r.Context(), u, authtoken.KindTwoFactor, twoFactorChallengeTTL)
+		if err != nil {
+			errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+			return
+		}
+		jsonResponse(w, http.StatusOK, TwoFactorChallenge{TwoFactorRequired: true, ChallengeToken: challenge})
+		return
+	}
+
+	resp, err := h.startSession(r.Context(), r, u)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, resp)
+}
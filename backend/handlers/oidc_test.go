@@ -0,0 +1,281 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/externalidentity"
+	"poll_app/ent/user"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// mockIdP is an OpenID provider that signs in whoever its claims describe
+type mockIdP struct {
+	srv *httptest.Server
+	key *rsa.PrivateKey
+
+	// claims go into the next ID token
+	claims map[string]any
+	// challenge and nonce are taken from the last authorization request
+	challenge string
+	nonce     string
+}
+
+func newMockIdP(t *testing.T) *mockIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &mockIdP{key: key}
+	mux := http.NewServeMux()
+	p.srv = httptest.NewServer(mux)
+	t.Cleanup(p.srv.Close)
+
+	b64 := base64.RawURLEncoding.EncodeToString
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{
+			"issuer":                                p.srv.URL,
+			"authorization_endpoint":                p.srv.URL + "/authorize",
+			"token_endpoint":                        p.srv.URL + "/token",
+			"jwks_uri":                              p.srv.URL + "/jwks",
+			"id_token_signing_alg_values_supported": []string{"RS256"},
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []any{map[string]any{
+			"kty": "RSA", "kid": "test", "alg": "RS256", "use": "sig",
+			"n": b64(key.N.Bytes()), "e": b64(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		// PKCE: the verifier must hash to the challenge of the authorization request
+		sum := sha256.Sum256([]byte(r.FormValue("code_verifier")))
+		if b64(sum[:]) != p.challenge {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "invalid_grant"})
+			return
+		}
+		claims := jwt.MapClaims{
+			"iss":   p.srv.URL,
+			"aud":   "poll-app",
+			"iat":   time.Now().Unix(),
+			"exp":   time.Now().Add(time.Hour).Unix(),
+			"nonce": p.nonce,
+		}
+		for k, v := range p.claims {
+			claims[k] = v
+		}
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+		token.Header["kid"] = "test"
+		signed, err := token.SignedString(key)
+		if err != nil {
+			t.Error(err)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access", "token_type": "Bearer", "id_token": signed})
+	})
+	return p
+}
+
+// newOIDCTest enables single sign-on with p and returns a handler
+func newOIDCTest(t *testing.T, p *mockIdP) (*Handler, *ent.Client) {
+	t.Helper()
+	SetOIDC(OIDCConfig{
+		Issuer:       p.srv.URL,
+		ClientID:     "poll-app",
+		ClientSecret: "secret",
+		RedirectURL:  "http://localhost:8080/api/auth/oidc/callback",
+	})
+	t.Cleanup(func() { sso = nil })
+	client := openTestClient(t, nil)
+	return NewHandler(client), client
+}
+
+// signInWithIdP runs OIDCLogin and the provider's redirect back to
+// OIDCCallback. tamper may change the provider or the callback's query
+// before the redirect. It returns where the callback sent the browser.
+func signInWithIdP(t *testing.T, h *Handler, p *mockIdP, tamper func(p *mockIdP, q url.Values)) *url.URL {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.OIDCLogin(rec, httptest.NewRequest(http.MethodGet, "/api/auth/oidc/login", nil), nil)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("login: status = %d, body %s", rec.Code, rec.Body)
+	}
+	auth, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p.challenge = auth.Query().Get("code_challenge")
+	p.nonce = auth.Query().Get("nonce")
+
+	q := url.Values{"code": {"authorization-code"}, "state": {auth.Query().Get("state")}}
+	if tamper != nil {
+		tamper(p, q)
+	}
+	req := httptest.NewRequest(http.MethodGet, "/api/auth/oidc/callback?"+q.Encode(), nil)
+	for _, c := range rec.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	rec = httptest.NewRecorder()
+	h.OIDCCallback(rec, req, nil)
+	if rec.Code != http.StatusFound {
+		t.Fatalf("callback: status = %d, body %s", rec.Code, rec.Body)
+	}
+	loc, err := url.Parse(rec.Header().Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return loc
+}
+
+// exchange trades the login code of a successful callback for tokens
+func exchange(t *testing.T, h *Handler, loc *url.URL) AuthResponse {
+	t.Helper()
+	code := loc.Query().Get("code")
+	if code == "" {
+		t.Fatalf("callback redirected to %s, want a login code", loc)
+	}
+	var resp AuthResponse
+	decode(t, serve(t, h.OIDCExchange, nil, OIDCExchangeRequest{Code: code}), http.StatusOK, &resp)
+	return resp
+}
+
+func TestOIDCCreatesUser(t *testing.T) {
+	p := newMockIdP(t)
+	h, client := newOIDCTest(t, p)
+	p.claims = map[string]any{"sub": "idp-1", "email": "jane@example.com", "email_verified": true, "preferred_username": "jane"}
+
+	loc := signInWithIdP(t, h, p, nil)
+	resp := exchange(t, h, loc)
+	if resp.User.Email != "jane@example.com" || resp.User.Username != "jane" || !resp.User.EmailVerified || resp.User.HasPassword {
+		t.Errorf("created user %+v", resp.User)
+	}
+
+	// The login code works once
+	code := loc.Query().Get("code")
+	decode(t, serve(t, h.OIDCExchange, nil, OIDCExchangeRequest{Code: code}), http.StatusUnauthorized, nil)
+
+	// The provider account stays linked when its email changes
+	p.claims = map[string]any{"sub": "idp-1", "email": "jane.doe@example.com", "email_verified": true}
+	if again := exchange(t, h, signInWithIdP(t, h, p, nil)); again.User.ID != resp.User.ID {
+		t.Errorf("second sign-in gave user %d, want %d", again.User.ID, resp.User.ID)
+	}
+	if n := client.ExternalIdentity.Query().CountX(context.Background()); n != 1 {
+		t.Errorf("%d external identities, want 1", n)
+	}
+}
+
+func TestOIDCLinksVerifiedUser(t *testing.T) {
+	p := newMockIdP(t)
+	h, client := newOIDCTest(t, p)
+	ctx := context.Background()
+	u := client.User.Create().
+		SetUsername("jane").
+		SetEmail("jane@example.com").
+		SetEmailVerified(true).
+		SetPassword("hash").
+		SaveX(ctx)
+	p.claims = map[string]any{"sub": "idp-1", "email": "jane@example.com", "email_verified": true}
+
+	resp := exchange(t, h, signInWithIdP(t, h, p, nil))
+	if resp.User.ID != u.ID {
+		t.Fatalf("signed in as user %d, want %d", resp.User.ID, u.ID)
+	}
+	linked := client.ExternalIdentity.Query().
+		Where(externalidentity.Subject("idp-1"), externalidentity.HasUserWith(user.ID(u.ID))).
+		ExistX(ctx)
+	if !linked {
+		t.Error("provider account was not linked")
+	}
+}
+
+func TestOIDCRefusesUnverifiedUser(t *testing.T) {
+	p := newMockIdP(t)
+	h, client := newOIDCTest(t, p)
+	ctx := context.Background()
+	// Someone signed up with the address but never confirmed it
+	u := client.User.Create().
+		SetUsername("squatter").
+		SetEmail("jane@example.com").
+		SetPassword("hash").
+		SaveX(ctx)
+	p.claims = map[string]any{"sub": "idp-1", "email": "jane@example.com", "email_verified": true}
+
+	loc := signInWithIdP(t, h, p, nil)
+	if got := loc.Query().Get("sso_error"); got != "unverified" {
+		t.Errorf("sso_error = %q, want unverified (redirect %s)", got, loc)
+	}
+	if n := client.ExternalIdentity.Query().CountX(ctx); n != 0 {
+		t.Errorf("%d external identities, want none", n)
+	}
+	if client.User.GetX(ctx, u.ID).EmailVerified {
+		t.Error("the unverified user's email was marked verified")
+	}
+}
+
+func TestOIDCCallbackRejects(t *testing.T) {
+	tests := []struct {
+		name   string
+		claims map[string]any
+		tamper func(p *mockIdP, q url.Values)
+		want   string
+	}{
+		{
+			name:   "state mismatch",
+			tamper: func(_ *mockIdP, q url.Values) { q.Set("state", "forged") },
+			want:   "expired",
+		},
+		{
+			name:   "nonce mismatch",
+			claims: map[string]any{"nonce": "replayed"},
+			want:   "failed",
+		},
+		{
+			// The code was issued for another verifier, as if intercepted
+			name:   "PKCE mismatch",
+			tamper: func(p *mockIdP, _ url.Values) { p.challenge = "intercepted" },
+			want:   "failed",
+		},
+		{
+			name:   "provider did not verify the email",
+			claims: map[string]any{"email_verified": false},
+			want:   "not_allowed",
+		},
+		{
+			name:   "provider error",
+			tamper: func(_ *mockIdP, q url.Values) { q.Set("error", "access_denied") },
+			want:   "denied",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newMockIdP(t)
+			h, client := newOIDCTest(t, p)
+			p.claims = map[string]any{"sub": "idp-1", "email": "jane@example.com", "email_verified": true}
+			for k, v := range tt.claims {
+				p.claims[k] = v
+			}
+			loc := signInWithIdP(t, h, p, tt.tamper)
+			if got := loc.Query().Get("sso_error"); got != tt.want {
+				t.Errorf("sso_error = %q, want %q (redirect %s)", got, tt.want, loc)
+			}
+			if n := client.User.Query().CountX(context.Background()); n != 0 {
+				t.Errorf("%d users created, want none", n)
+			}
+		})
+	}
+}
@@ -0,0 +1,266 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/url"
+	"strconv"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/poll"
+	"poll_app/ent/polloption"
+	"poll_app/ent/predicate"
+	"poll_app/ent/user"
+	"poll_app/ent/vote"
+
+	entsql "entgo.io/ent/dialect/sql"
+)
+
+// Sort keys for ListPolls
+const (
+	SortNewest    = "newest"
+	SortMostVotes = "most_votes"
+	SortUpdated   = "updated"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+type PollPageDTO struct {
+	Polls      []PollDTO `json:"polls"`
+	NextCursor string    `json:"next_cursor,omitempty"`
+}
+
+// pollCursor marks the last poll of a page. Only the key used by Sort is set.
+type pollCursor struct {
+	Sort  string    `json:"s"`
+	Time  time.Time `json:"t,omitempty"`
+	Votes int       `json:"v,omitempty"`
+	ID    int       `json:"i"`
+}
+
+func encodeCursor(c pollCursor) string {
+	b, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func decodeCursor(s string) (pollCursor, error) {
+	var c pollCursor
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return c, err
+	}
+	err = json.Unmarshal(b, &c)
+	return c, err
+}
+
+// pollListQuery is a parsed ListPolls request
+type pollListQuery struct {
+	Sort   string
+	Limit  int
+	Cursor *pollCursor
+	// Now decides which polls are closed, for the most_votes order
+	Now time.Time
+	// Where holds the filters; Order and the cursor are applied separately
+	Where []predicate.Poll
+}
+
+// parsePollListQuery reads the filters, sort key and page from the query string.
+// It returns an error message for invalid parameters.
+func parsePollListQuery(u *ent.User, q url.Values) (*pollListQuery, string) {
+	lq := &pollListQuery{Sort: SortNewest, Limit: defaultPageSize, Now: time.Now()}
+
+	if s := q.Get("sort"); s != "" {
+		switch s {
+		case SortNewest, SortMostVotes, SortUpdated:
+			lq.Sort = s
+		default:
+			return nil, "Unknown sort key"
+		}
+	}
+
+	if s := q.Get("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 {
+			return nil, "limit must be a positive integer"
+		}
+		lq.Limit = min(limit, maxPageSize)
+	}
+
+	if s := q.Get("cursor"); s != "" {
+		c, err := decodeCursor(s)
+		if err != nil || c.Sort != lq.Sort {
+			return nil, "Invalid cursor"
+		}
+		lq.Cursor = &c
+	}
+
+	if s := q.Get("creator"); s != "" {
+		creatorID := u.ID
+		if s != "me" {
+			id, err := strconv.Atoi(s)
+			if err != nil {
+				return nil, "creator must be a user ID or \"me\""
+			}
+			creatorID = id
+		}
+		lq.Where = append(lq.Where, poll.HasCreatorWith(user.ID(creatorID)))
+	}
+
+	if s := q.Get("voted"); s != "" {
+		voted, err := strconv.ParseBool(s)
+		if err != nil {
+			return nil, "voted must be true or false"
+		}
+		if voted {
+			lq.Where = append(lq.Where, votedBy(u.ID))
+		} else {
+			lq.Where = append(lq.Where, poll.Not(votedBy(u.ID)))
+		}
+	}
+
+	if s := q.Get("status"); s != "" {
+		now := time.Now()
+		notClosed := poll.Or(poll.ClosesAtIsNil(), poll.ClosesAtGT(now))
+		switch s {
+		case PollStatusOpen:
+			lq.Where = append(lq.Where, notClosed, poll.Or(poll.OpensAtIsNil(), poll.OpensAtLTE(now)))
+		case PollStatusScheduled:
+			lq.Where = append(lq.Where, notClosed, poll.OpensAtGT(now))
+		case PollStatusClosed:
+			lq.Where = append(lq.Where, poll.ClosesAtLTE(now))
+		default:
+			return nil, "Unknown status"
+		}
+	}
+
+	if s := q.Get("q"); s != "" {
+		lq.Where = append(lq.Where, poll.Or(poll.TitleContainsFold(s), poll.DescriptionContainsFold(s)))
+	}
+
+	return lq, ""
+}
+
+// apply adds the sort order and the position after the cursor to a poll query
+func (lq *pollListQuery) apply(q *ent.PollQuery) *ent.PollQuery {
+	switch lq.Sort {
+	case SortMostVotes:
+		q.Order(func(s *entsql.Selector) {
+			s.OrderExpr(entsql.ExprFunc(func(b *entsql.Builder) {
+				b.Join(sortVotes(s, lq.Now))
+				b.WriteString(" DESC")
+			}))
+		}, ent.Desc(poll.FieldID))
+		if c := lq.Cursor; c != nil {
+			q.Where(func(s *entsql.Selector) {
+				count := sortVotes(s, lq.Now)
+				s.Where(entsql.Or(
+					entsql.P(func(b *entsql.Builder) {
+						b.Join(count).WriteOp(entsql.OpLT).Arg(c.Votes)
+					}),
+					entsql.And(
+						entsql.P(func(b *entsql.Builder) {
+							b.Join(count).WriteOp(entsql.OpEQ).Arg(c.Votes)
+						}),
+						entsql.LT(s.C(poll.FieldID), c.ID),
+					),
+				))
+			})
+		}
+	case SortUpdated:
+		q.Order(ent.Desc(poll.FieldUpdatedAt), ent.Desc(poll.FieldID))
+		if c := lq.Cursor; c != nil {
+			q.Where(poll.Or(
+				poll.UpdatedAtLT(c.Time),
+				poll.And(poll.UpdatedAtEQ(c.Time), poll.IDLT(c.ID)),
+			))
+		}
+	default:
+		q.Order(ent.Desc(poll.FieldCreatedAt), ent.Desc(poll.FieldID))
+		if c := lq.Cursor; c != nil {
+			q.Where(poll.Or(
+				poll.CreatedAtLT(c.Time),
+				poll.And(poll.CreatedAtEQ(c.Time), poll.IDLT(c.ID)),
+			))
+		}
+	}
+	// One extra row tells whether there is a next page
+	return q.Limit(lq.Limit + 1)
+}
+
+// cursorAfter returns the cursor pointing past p
+func (lq *pollListQuery) cursorAfter(p *ent.Poll, counts voteCounts) string {
+	c := pollCursor{Sort: lq.Sort, ID: p.ID}
+	switch lq.Sort {
+	case SortMostVotes:
+		if resultsPublic(p, lq.Now) {
+			for _, opt := range p.Edges.Options {
+				c.Votes += counts.votes(opt.ID)
+			}
+		}
+	case SortUpdated:
+		c.Time = p.UpdatedAt
+	default:
+		c.Time = p.CreatedAt
+	}
+	return encodeCursor(c)
+}
+
+// resultsPublic reports whether p's results are shown to everyone at now.
+// It matches sortVotes.
+func resultsPublic(p *ent.Poll, now time.Time) bool {
+	switch p.ResultsVisibility {
+	case poll.ResultsVisibilityAlways:
+		return true
+	case poll.ResultsVisibilityAfterVote, poll.ResultsVisibilityAfterClose:
+		return p.ClosesAt != nil && !p.ClosesAt.After(now)
+	}
+	return false
+}
+
+// sortVotes is the vote count most_votes orders by. Polls whose results are
+// not public count as 0, so neither the order nor the cursor reveals their
+// counts to viewers who may not see them
+func sortVotes(s *entsql.Selector, now time.Time) entsql.Querier {
+	return entsql.ExprFunc(func(b *entsql.Builder) {
+		b.WriteString("CASE WHEN ")
+		b.Join(entsql.Or(
+			entsql.EQ(s.C(poll.FieldResultsVisibility), string(poll.ResultsVisibilityAlways)),
+			entsql.And(
+				entsql.In(s.C(poll.FieldResultsVisibility),
+					string(poll.ResultsVisibilityAfterVote), string(poll.ResultsVisibilityAfterClose)),
+				entsql.LTE(s.C(poll.FieldClosesAt), now),
+			),
+		))
+		b.WriteString(" THEN ")
+		b.Wrap(func(b *entsql.Builder) { b.Join(pollVoteCount(s)) })
+		b.WriteString(" ELSE 0 END")
+	})
+}
+
+// pollVoteCount selects the number of votes on the poll row of s, counting
+// first preferences only on ranked polls like countVotes does
+func pollVoteCount(s *entsql.Selector) *entsql.Selector {
+	v := entsql.Table(vote.Table)
+	o := entsql.Table(polloption.Table)
+	return entsql.Dialect(s.Dialect()).
+		Select(entsql.Count("*")).
+		From(v).
+		Join(o).On(v.C(vote.OptionColumn), o.C(polloption.FieldID)).
+		Where(entsql.And(
+			entsql.ColumnsEQ(o.C(polloption.PollColumn), s.C(poll.FieldID)),
+			entsql.Or(entsql.IsNull(v.C(vote.FieldRank)), entsql.EQ(v.C(vote.FieldRank), 1)),
+		))
+}
+
+// votedBy matches the polls userID has voted on. Anonymous votes do not
+// link to the user, so those polls are matched through their participants.
+func votedBy(userID int) predicate.Poll {
+	return poll.Or(
+		poll.HasOptionsWith(polloption.HasVotesWith(vote.HasUserWith(user.ID(userID)))),
+		poll.HasParticipantsWith(user.ID(userID)),
+	)
+}
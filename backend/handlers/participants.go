@@ -0,0 +1,64 @@
+package handlers
+
+import (
+	"context"
+
+	"poll_app/ent"
+	"poll_app/ent/poll"
+	"poll_app/ent/polloption"
+	"poll_app/ent/user"
+	"poll_app/ent/vote"
+)
+
+// Anonymous votes carry a voter hash the database cannot compute, so the
+// users who voted on an anonymous poll are also kept as its participants.
+// This lets queries tell who voted without hashing every poll.
+
+// addParticipant records that userID voted on the anonymous poll pollID
+func addParticipant(ctx context.Context, tx *ent.Tx, pollID, userID int) error {
+	exists, err := tx.User.Query().
+		Where(user.ID(userID), user.HasParticipatedPollsWith(poll.ID(pollID))).
+		Exist(ctx)
+	if err != nil || exists {
+		return err
+	}
+	// Linking from the user side leaves the poll's updated_at alone
+	return tx.User.UpdateOneID(userID).
+		AddParticipatedPollIDs(pollID).
+		Exec(ctx)
+}
+
+// pruneParticipants drops the participants of an anonymous poll who no longer
+// have a vote on it, after options were removed
+func pruneParticipants(ctx context.Context, tx *ent.Tx, pollID int) error {
+	ids, err := tx.User.Query().
+		Where(user.HasParticipatedPollsWith(poll.ID(pollID))).
+		IDs(ctx)
+	if err != nil || len(ids) == 0 {
+		return err
+	}
+	remaining, err := tx.Vote.Query().
+		Where(vote.VoterHashNotNil(), vote.HasOptionWith(polloption.HasPollWith(poll.ID(pollID)))).
+		Unique(true).
+		Select(vote.FieldVoterHash).
+		Strings(ctx)
+	if err != nil {
+		return err
+	}
+	voted := make(map[string]bool, len(remaining))
+	for _, hash := range remaining {
+		voted[hash] = true
+	}
+	var gone []int
+	for _, id := range ids {
+		if !voted[voterHash(pollID, id)] {
+			gone = append(gone, id)
+		}
+	}
+	if len(gone) == 0 {
+		return nil
+	}
+	return tx.Poll.UpdateOneID(pollID).
+		RemoveParticipantIDs(gone...).
+		Exec(ctx)
+}
@@ -0,0 +1,483 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/passkey"
+	"poll_app/ent/passkeyceremony"
+	"poll_app/ent/user"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/julienschmidt/httprouter"
+)
+
+// passkeyCeremonyTTL is how long a registration or login waits for the authenticator
+const passkeyCeremonyTTL = 5 * time.Minute
+
+var relyingParty *webauthn.WebAuthn
+
+// SetRelyingParty configures passkeys for the site at rpID (a domain such as
+// example.com) whose pages are served from origins. Until it is called the
+// passkey endpoints answer 503.
+func SetRelyingParty(rpID, displayName string, origins []string) error {
+	rp, err := webauthn.New(&webauthn.Config{
+		RPID:          rpID,
+		RPDisplayName: displayName,
+		RPOrigins:     origins,
+		Timeouts: webauthn.TimeoutsConfig{
+			Login:        webauthn.TimeoutConfig{Enforce: true, Timeout: passkeyCeremonyTTL},
+			Registration: webauthn.TimeoutConfig{Enforce: true, Timeout: passkeyCeremonyTTL},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	relyingParty = rp
+	return nil
+}
+
+var errInvalidCeremony = errors.New("invalid or expired passkey ceremony")
+
+type PasskeyDTO struct {
+	ID         int        `json:"id"`
+	Name       string     `json:"name"`
+	BackedUp   bool       `json:"backed_up"`
+	CreatedAt  time.Time  `json:"created_at"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+}
+
+// PasskeyCeremonyResponse holds the options to pass to
+// navigator.credentials.create or get, and the token to finish with
+type PasskeyCeremonyResponse struct {
+	CeremonyToken string `json:"ceremony_token"`
+	Options       any    `json:"options"`
+}
+
+type FinishPasskeyRequest struct {
+	CeremonyToken string `json:"ceremony_token"`
+	Name          string `json:"name"`
+	// Credential is the PublicKeyCredential from the browser, JSON encoded
+	Credential json.RawMessage `json:"credential"`
+	// Password, or Code on accounts without one, confirms a registration
+	// again when it finishes
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+// webAuthnUser adapts a user and their passkeys to webauthn.User
+type webAuthnUser struct {
+	u        *ent.User
+	passkeys []*ent.Passkey
+}
+
+func (w webAuthnUser) WebAuthnID() []byte          { return w.u.WebauthnID }
+func (w webAuthnUser) WebAuthnName() string        { return w.u.Email }
+func (w webAuthnUser) WebAuthnDisplayName() string { return w.u.Username }
+
+func (w webAuthnUser) WebAuthnCredentials() []webauthn.Credential {
+	creds := make([]webauthn.Credential, len(w.passkeys))
+	for i, p := range w.passkeys {
+		transports := make([]protocol.AuthenticatorTransport, len(p.Transports))
+		for j, t := range p.Transports {
+			transports[j] = protocol.AuthenticatorTransport(t)
+		}
+		creds[i] = webauthn.Credential{
+			ID:              p.CredentialID,
+			PublicKey:       p.PublicKey,
+			AttestationType: p.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: p.BackupEligible,
+				BackupState:    p.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    p.Aaguid,
+				SignCount: p.SignCount,
+			},
+		}
+	}
+	return creds
+}
+
+func (h *Handler) loadWebAuthnUser(ctx context.Context, u *ent.User) (webAuthnUser, error) {
+	passkeys, err := h.client.Passkey.Query().Where(passkey.HasUserWith(user.ID(u.ID))).All(ctx)
+	return webAuthnUser{u: u, passkeys: passkeys}, err
+}
+
+// startCeremony stores the state of a WebAuthn ceremony and returns the
+// token that finishes it. u is nil for logins.
+func (h *Handler) startCeremony(ctx context.Context, kind passkeyceremony.Kind, session *webauthn.SessionData, u *ent.User) (string, error) {
+	// Drop abandoned ceremonies so they do not pile up
+	_, _ = h.client.PasskeyCeremony.Delete().Where(passkeyceremony.ExpiresAtLT(time.Now())).Exec(ctx)
+
+	token, tokenHash, err := newToken()
+	if err != nil {
+		return "", err
+	}
+	create := h.client.PasskeyCeremony.Create().
+		SetKind(kind).
+		SetTokenHash(tokenHash).
+		SetSession(*session).
+		SetExpiresAt(time.Now().Add(passkeyCeremonyTTL))
+	if u != nil {
+		create.SetUser(u)
+	}
+	if err := create.Exec(ctx); err != nil {
+		return "", err
+	}
+	return token, nil
+}
+
+// finishCeremony deletes the ceremony of token and returns its state. It
+// fails with errInvalidCeremony if the ceremony is unknown, finished or
+// expired, or belongs to another user than u.
+func (h *Handler) finishCeremony(ctx context.Context, kind passkeyceremony.Kind, token string, u *ent.User) (webauthn.SessionData, error) {
+	c, err := h.client.PasskeyCeremony.Query().
+		Where(
+			passkeyceremony.TokenHash(hashToken(token)),
+			passkeyceremony.KindEQ(kind),
+			passkeyceremony.ExpiresAtGT(time.Now()),
+		).
+		WithUser().
+		Only(ctx)
+	if ent.IsNotFound(err) {
+		return webauthn.SessionData{}, errInvalidCeremony
+	}
+	if err != nil {
+		return webauthn.SessionData{}, err
+	}
+	if u != nil && (c.Edges.User == nil || c.Edges.User.ID != u.ID) {
+		return webauthn.SessionData{}, errInvalidCeremony
+	}
+
+	// Deleted rather than marked, so a ceremony can only finish once
+	n, err := h.client.PasskeyCeremony.Delete().Where(passkeyceremony.ID(c.ID)).Exec(ctx)
+	if err != nil {
+		return webauthn.SessionData{}, err
+	}
+	if n == 0 {
+		return webauthn.SessionData{}, errInvalidCeremony
+	}
+	return c.Session, nil
+}
+
+// BeginPasskeyRegistration starts adding a passkey to the current user's
+// account after checking the current password, or a code on accounts without
+// one
+func (h *Handler) BeginPasskeyRegistration(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	if relyingParty == nil {
+		errorResponse(w, http.StatusServiceUnavailable, "Passkeys are not configured")
+		return
+	}
+
+	var req ReauthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !h.reauthenticate(w, r, u, req, "Failed to start passkey registration") {
+		return
+	}
+
+	// The user handle is random rather than the user ID, as WebAuthn advises
+	if len(u.WebauthnID) == 0 {
+		handle := make([]byte, 32)
+		if _, err := rand.Read(handle); err != nil {
+			errorResponse(w, http.StatusInternalServerError, "Failed to start passkey registration")
+			return
+		}
+		updated, err := h.client.User.UpdateOne(u).SetWebauthnID(handle).Save(r.Context())
+		if err != nil {
+			errorResponse(w, http.StatusInternalServerError, "Failed to start passkey registration")
+			return
+		}
+		u = updated
+	}
+
+	wu, err := h.loadWebAuthnUser(r.Context(), u)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start passkey registration")
+		return
+	}
+
+	exclusions := make([]protocol.CredentialDescriptor, len(wu.passkeys))
+	for i, c := range wu.WebAuthnCredentials() {
+		exclusions[i] = c.Descriptor()
+	}
+
+	// Discoverable credentials with user verification, so a passkey alone
+	// is enough to log in
+	creation, session, err := relyingParty.BeginRegistration(wu,
+		webauthn.WithExclusions(exclusions),
+		webauthn.WithAuthenticatorSelection(protocol.AuthenticatorSelection{
+			RequireResidentKey: protocol.ResidentKeyRequired(),
+			ResidentKey:        protocol.ResidentKeyRequirementRequired,
+			UserVerification:   protocol.VerificationRequired,
+		}),
+	)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start passkey registration")
+		return
+	}
+
+	token, err := h.startCeremony(r.Context(), passkeyceremony.KindRegistration, session, u)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start passkey registration")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, PasskeyCeremonyResponse{CeremonyToken: token, Options: creation})
+}
+
+// FinishPasskeyRegistration checks the password or code again, since a
+// passkey signs in without either, then verifies the new credential and saves
+// it
+func (h *Handler) FinishPasskeyRegistration(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	if relyingParty == nil {
+		errorResponse(w, http.StatusServiceUnavailable, "Passkeys are not configured")
+		return
+	}
+
+	var req FinishPasskeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CeremonyToken == "" || len(req.Credential) == 0 {
+		errorResponse(w, http.StatusBadRequest, "Ceremony token and credential are required")
+		return
+	}
+	// Before the ceremony is used up, so a mistyped password can be retried
+	if !h.reauthenticate(w, r, u, ReauthRequest{Password: req.Password, Code: req.Code}, "Failed to register passkey") {
+		return
+	}
+
+	session, err := h.finishCeremony(r.Context(), passkeyceremony.KindRegistration, req.CeremonyToken, u)
+	if err != nil {
+		if errors.Is(err, errInvalidCeremony) {
+			errorResponse(w, http.StatusBadRequest, "Passkey registration has expired, please try again")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to register passkey")
+		return
+	}
+
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(req.Credential)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid passkey response")
+		return
+	}
+
+	wu, err := h.loadWebAuthnUser(r.Context(), u)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to register passkey")
+		return
+	}
+	cred, err := relyingParty.CreateCredential(wu, session, parsed)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Passkey could not be verified")
+		return
+	}
+
+	transports := make([]string, len(cred.Transport))
+	for i, t := range cred.Transport {
+		transports[i] = string(t)
+	}
+	create := h.client.Passkey.Create().
+		SetCredentialID(cred.ID).
+		SetPublicKey(cred.PublicKey).
+		SetAttestationType(cred.AttestationType).
+		SetAaguid(cred.Authenticator.AAGUID).
+		SetTransports(transports).
+		SetSignCount(cred.Authenticator.SignCount).
+		SetBackupEligible(cred.Flags.BackupEligible).
+		SetBackupState(cred.Flags.BackupState).
+		SetUser(u)
+	if req.Name != "" {
+		create.SetName(req.Name)
+	}
+	p, err := create.Save(r.Context())
+	if err != nil {
+		if ent.IsConstraintError(err) {
+			errorResponse(w, http.StatusConflict, "Passkey is already registered")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to register passkey")
+		return
+	}
+
+	jsonResponse(w, http.StatusCreated, passkeyToDTO(p))
+}
+
+// BeginPasskeyLogin starts a login with a discoverable passkey. The browser
+// lets the user pick one, so no username is needed.
+func (h *Handler) BeginPasskeyLogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if relyingParty == nil {
+		errorResponse(w, http.StatusServiceUnavailable, "Passkeys are not configured")
+		return
+	}
+
+	assertion, session, err := relyingParty.BeginDiscoverableLogin(webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start passkey login")
+		return
+	}
+
+	token, err := h.startCeremony(r.Context(), passkeyceremony.KindLogin, session, nil)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start passkey login")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, PasskeyCeremonyResponse{CeremonyToken: token, Options: assertion})
+}
+
+// FinishPasskeyLogin verifies the passkey's signature and starts a session.
+// A passkey with user verification is two factors, so TOTP is not asked for.
+func (h *Handler) FinishPasskeyLogin(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	if relyingParty == nil {
+		errorResponse(w, http.StatusServiceUnavailable, "Passkeys are not configured")
+		return
+	}
+
+	var req FinishPasskeyRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.CeremonyToken == "" || len(req.Credential) == 0 {
+		errorResponse(w, http.StatusBadRequest, "Ceremony token and credential are required")
+		return
+	}
+
+	session, err := h.finishCeremony(r.Context(), passkeyceremony.KindLogin, req.CeremonyToken, nil)
+	if err != nil {
+		if errors.Is(err, errInvalidCeremony) {
+			errorResponse(w, http.StatusUnauthorized, "Passkey login has expired, please try again")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(req.Credential)
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid passkey response")
+		return
+	}
+
+	var used *ent.Passkey
+	findUser := func(rawID, userHandle []byte) (webauthn.User, error) {
+		p, err := h.client.Passkey.Query().
+			Where(passkey.CredentialID(rawID)).
+			WithUser().
+			Only(r.Context())
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.Equal(p.Edges.User.WebauthnID, userHandle) {
+			return nil, errors.New("user handle does not match the passkey")
+		}
+		used = p
+		return h.loadWebAuthnUser(r.Context(), p.Edges.User)
+	}
+
+	_, cred, err := relyingParty.ValidatePasskeyLogin(findUser, session, parsed)
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, "Passkey login failed")
+		return
+	}
+	// A counter that went backwards means the key may have been copied
+	if cred.Authenticator.CloneWarning {
+		errorResponse(w, http.StatusUnauthorized, "Passkey login failed")
+		return
+	}
+
+	err = h.client.Passkey.UpdateOne(used).
+		SetSignCount(cred.Authenticator.SignCount).
+		SetBackupState(cred.Flags.BackupState).
+		SetLastUsedAt(time.Now()).
+		Exec(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to log in")
+		return
+	}
+
+	resp, err := h.startSession(r.Context(), r, used.Edges.User)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, resp)
+}
+
+// ListPasskeys returns the current user's passkeys
+func (h *Handler) ListPasskeys(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	passkeys, err := h.client.Passkey.Query().
+		Where(passkey.HasUserWith(user.ID(u.ID))).
+		Order(ent.Asc(passkey.FieldCreatedAt)).
+		All(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch passkeys")
+		return
+	}
+
+	result := make([]PasskeyDTO, len(passkeys))
+	for i, p := range passkeys {
+		result[i] = passkeyToDTO(p)
+	}
+
+	jsonResponse(w, http.StatusOK, result)
+}
+
+// DeletePasskey removes one of the current user's passkeys after checking the
+// current password, or a code on accounts without one
+func (h *Handler) DeletePasskey(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid passkey ID")
+		return
+	}
+	var req ReauthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !h.reauthenticate(w, r, u, req, "Failed to delete passkey") {
+		return
+	}
+
+	n, err := h.client.Passkey.Delete().
+		Where(passkey.ID(id), passkey.HasUserWith(user.ID(u.ID))).
+		Exec(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to delete passkey")
+		return
+	}
+	if n == 0 {
+		errorResponse(w, http.StatusNotFound, "Passkey not found")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func passkeyToDTO(p *ent.Passkey) PasskeyDTO {
+	return PasskeyDTO{
+		ID:         p.ID,
+		Name:       p.Name,
+		BackedUp:   p.BackupState,
+		CreatedAt:  p.CreatedAt,
+		LastUsedAt: p.LastUsedAt,
+	}
+}
@@ -0,0 +1,311 @@
+package handlers
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"poll_app/ent"
+
+	"github.com/fxamacker/cbor/v2"
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	testRPID   = "localhost"
+	testOrigin = "http://localhost:3000"
+)
+
+var b64url = base64.RawURLEncoding
+
+// ceremony is the part of a PasskeyCeremonyResponse an authenticator reads
+type ceremony struct {
+	CeremonyToken string `json:"ceremony_token"`
+	Options       struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+			User      struct {
+				ID string `json:"id"`
+			} `json:"user"`
+		} `json:"publicKey"`
+	} `json:"options"`
+}
+
+// softAuthenticator is a P-256 passkey that signs in software
+type softAuthenticator struct {
+	key    *ecdsa.PrivateKey
+	credID []byte
+	handle []byte // user handle stored at registration
+	count  uint32
+}
+
+func newSoftAuthenticator(t *testing.T, credID string) *softAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &softAuthenticator{key: key, credID: []byte(credID)}
+}
+
+// clientData is the clientDataJSON a browser at origin would send
+func clientData(typ, challenge, origin string) []byte {
+	b, _ := json.Marshal(map[string]string{"type": typ, "challenge": challenge, "origin": origin})
+	return b
+}
+
+// create answers a registration ceremony with a "none" attestation
+func (a *softAuthenticator) create(t *testing.T, c ceremony) map[string]any {
+	t.Helper()
+	handle, err := b64url.DecodeString(c.Options.PublicKey.User.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a.handle = handle
+
+	publicKey, err := cbor.Marshal(map[int]any{
+		1: 2, 3: -7, -1: 1, // EC2 key, ES256, P-256
+		-2: a.key.X.FillBytes(make([]byte, 32)),
+		-3: a.key.Y.FillBytes(make([]byte, 32)),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rpHash := sha256.Sum256([]byte(testRPID))
+	authData := append([]byte{}, rpHash[:]...)
+	authData = append(authData, 0x01|0x04|0x40) // user present, verified, attested credential
+	authData = binary.BigEndian.AppendUint32(authData, 0)
+	authData = append(authData, make([]byte, 16)...) // AAGUID
+	authData = binary.BigEndian.AppendUint16(authData, uint16(len(a.credID)))
+	authData = append(authData, a.credID...)
+	authData = append(authData, publicKey...)
+	attestation, err := cbor.Marshal(map[string]any{"fmt": "none", "attStmt": map[string]any{}, "authData": authData})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]any{
+		"id":    b64url.EncodeToString(a.credID),
+		"rawId": b64url.EncodeToString(a.credID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    b64url.EncodeToString(clientData("webauthn.create", c.Options.PublicKey.Challenge, testOrigin)),
+			"attestationObject": b64url.EncodeToString(attestation),
+		},
+	}
+}
+
+// get answers a login ceremony from origin, claiming handle as the user
+func (a *softAuthenticator) get(t *testing.T, c ceremony, handle []byte, origin string) map[string]any {
+	t.Helper()
+	a.count++
+	rpHash := sha256.Sum256([]byte(testRPID))
+	authData := append([]byte{}, rpHash[:]...)
+	authData = append(authData, 0x01|0x04)
+	authData = binary.BigEndian.AppendUint32(authData, a.count)
+	data := clientData("webauthn.get", c.Options.PublicKey.Challenge, origin)
+	dataHash := sha256.Sum256(data)
+	digest := sha256.Sum256(append(append([]byte{}, authData...), dataHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return map[string]any{
+		"id":    b64url.EncodeToString(a.credID),
+		"rawId": b64url.EncodeToString(a.credID),
+		"type":  "public-key",
+		"response": map[string]any{
+			"clientDataJSON":    b64url.EncodeToString(data),
+			"authenticatorData": b64url.EncodeToString(authData),
+			"signature":         b64url.EncodeToString(sig),
+			"userHandle":        b64url.EncodeToString(handle),
+		},
+	}
+}
+
+// newPasskeyTest sets up the relying party and a handler
+func newPasskeyTest(t *testing.T) (*Handler, *ent.Client) {
+	t.Helper()
+	if err := SetRelyingParty(testRPID, "PollApp", []string{testOrigin}); err != nil {
+		t.Fatal(err)
+	}
+	client := openTestClient(t, nil)
+	return NewHandler(client), client
+}
+
+// reload fetches u again, as AuthMiddleware does on every request
+func reload(t *testing.T, client *ent.Client, u *ent.User) *ent.User {
+	t.Helper()
+	u, err := client.User.Get(context.Background(), u.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u
+}
+
+// registerPasskey runs a whole registration ceremony for u with a
+func registerPasskey(t *testing.T, h *Handler, client *ent.Client, u *ent.User, a *softAuthenticator) {
+	t.Helper()
+	var c ceremony
+	decode(t, serve(t, h.BeginPasskeyRegistration, reload(t, client, u), ReauthRequest{Password: "password"}), http.StatusOK, &c)
+	rec := serve(t, h.FinishPasskeyRegistration, reload(t, client, u), map[string]any{
+		"ceremony_token": c.CeremonyToken,
+		"name":           "Laptop",
+		"credential":     a.create(t, c),
+		"password":       "password",
+	})
+	decode(t, rec, http.StatusCreated, nil)
+}
+
+func TestPasskeyRegistration(t *testing.T) {
+	h, client := newPasskeyTest(t)
+	u := createUser(t, client, "jane")
+	a := newSoftAuthenticator(t, "credential-one-0123456789")
+
+	var c ceremony
+	decode(t, serve(t, h.BeginPasskeyRegistration, reload(t, client, u), ReauthRequest{Password: "password"}), http.StatusOK, &c)
+	finish := map[string]any{
+		"ceremony_token": c.CeremonyToken,
+		"name":           "Laptop",
+		"credential":     a.create(t, c),
+		"password":       "password",
+	}
+	var dto PasskeyDTO
+	decode(t, serve(t, h.FinishPasskeyRegistration, reload(t, client, u), finish), http.StatusCreated, &dto)
+	if dto.Name != "Laptop" {
+		t.Errorf("name = %q, want Laptop", dto.Name)
+	}
+
+	// A ceremony can be finished once
+	decode(t, serve(t, h.FinishPasskeyRegistration, reload(t, client, u), finish), http.StatusBadRequest, nil)
+
+	// Another user cannot finish someone else's ceremony
+	decode(t, serve(t, h.BeginPasskeyRegistration, reload(t, client, u), ReauthRequest{Password: "password"}), http.StatusOK, &c)
+	other := createUser(t, client, "sam")
+	rec := serve(t, h.FinishPasskeyRegistration, other, map[string]any{
+		"ceremony_token": c.CeremonyToken,
+		"credential":     newSoftAuthenticator(t, "credential-two-0123456789").create(t, c),
+		"password":       "password",
+	})
+	decode(t, rec, http.StatusBadRequest, nil)
+
+	// The same credential cannot be registered twice
+	decode(t, serve(t, h.BeginPasskeyRegistration, reload(t, client, u), ReauthRequest{Password: "password"}), http.StatusOK, &c)
+	rec = serve(t, h.FinishPasskeyRegistration, reload(t, client, u), map[string]any{
+		"ceremony_token": c.CeremonyToken,
+		"credential":     a.create(t, c),
+		"password":       "password",
+	})
+	decode(t, rec, http.StatusConflict, nil)
+}
+
+// TestPasskeyReauth checks that adding or removing a passkey needs the
+// password, not just a session
+func TestPasskeyReauth(t *testing.T) {
+	h, client := newPasskeyTest(t)
+	u := createUser(t, client, "jane")
+	a := newSoftAuthenticator(t, "credential-one-0123456789")
+
+	decode(t, serve(t, h.BeginPasskeyRegistration, reload(t, client, u), ReauthRequest{}), http.StatusBadRequest, nil)
+
+	var c ceremony
+	decode(t, serve(t, h.BeginPasskeyRegistration, reload(t, client, u), ReauthRequest{Password: "password"}), http.StatusOK, &c)
+	finish := map[string]any{
+		"ceremony_token": c.CeremonyToken,
+		"credential":     a.create(t, c),
+		"password":       "guess",
+	}
+	decode(t, serve(t, h.FinishPasskeyRegistration, reload(t, client, u), finish), http.StatusBadRequest, nil)
+	if n := client.Passkey.Query().CountX(context.Background()); n != 0 {
+		t.Fatalf("registered %d passkeys with a wrong password", n)
+	}
+	finish["password"] = "password"
+	var dto PasskeyDTO
+	decode(t, serve(t, h.FinishPasskeyRegistration, reload(t, client, u), finish), http.StatusCreated, &dto)
+
+	del := func(req ReauthRequest) *httptest.ResponseRecorder {
+		return serveParams(t, h.DeletePasskey, reload(t, client, u), httprouter.Params{{Key: "id", Value: strconv.Itoa(dto.ID)}}, req)
+	}
+	decode(t, del(ReauthRequest{Password: "guess"}), http.StatusBadRequest, nil)
+	if rec := del(ReauthRequest{Password: "password"}); rec.Code != http.StatusNoContent {
+		t.Errorf("delete with the password: status = %d, want 204", rec.Code)
+	}
+}
+
+func TestPasskeyLogin(t *testing.T) {
+	h, client := newPasskeyTest(t)
+	u := createUser(t, client, "jane")
+	a := newSoftAuthenticator(t, "credential-one-0123456789")
+	registerPasskey(t, h, client, u, a)
+
+	begin := func() ceremony {
+		var c ceremony
+		decode(t, serve(t, h.BeginPasskeyLogin, nil, nil), http.StatusOK, &c)
+		return c
+	}
+	finish := func(c ceremony, credential map[string]any) int {
+		return serve(t, h.FinishPasskeyLogin, nil, map[string]any{
+			"ceremony_token": c.CeremonyToken,
+			"credential":     credential,
+		}).Code
+	}
+
+	c := begin()
+	var resp AuthResponse
+	rec := serve(t, h.FinishPasskeyLogin, nil, map[string]any{
+		"ceremony_token": c.CeremonyToken,
+		"credential":     a.get(t, c, a.handle, testOrigin),
+	})
+	decode(t, rec, http.StatusOK, &resp)
+	if resp.Token == "" || resp.User.ID != u.ID {
+		t.Fatalf("login returned token %q for user %d, want user %d", resp.Token, resp.User.ID, u.ID)
+	}
+	if code := finish(c, a.get(t, c, a.handle, testOrigin)); code != http.StatusUnauthorized {
+		t.Errorf("reused ceremony: status = %d, want 401", code)
+	}
+
+	tests := []struct {
+		name       string
+		credential func(c ceremony) map[string]any
+	}{
+		{"other origin", func(c ceremony) map[string]any {
+			return a.get(t, c, a.handle, "https://evil.example")
+		}},
+		{"other user handle", func(c ceremony) map[string]any {
+			return a.get(t, c, []byte("someone-else"), testOrigin)
+		}},
+		{"unknown credential", func(c ceremony) map[string]any {
+			stranger := newSoftAuthenticator(t, "credential-two-0123456789")
+			return stranger.get(t, c, a.handle, testOrigin)
+		}},
+		{"counter went back", func(c ceremony) map[string]any {
+			a.count = 0
+			defer func() { a.count = 100 }()
+			return a.get(t, c, a.handle, testOrigin)
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := begin()
+			if code := finish(c, tt.credential(c)); code != http.StatusUnauthorized {
+				t.Errorf("status = %d, want 401", code)
+			}
+		})
+	}
+
+	// The key still works after the failures
+	c = begin()
+	if code := finish(c, a.get(t, c, a.handle, testOrigin)); code != http.StatusOK {
+		t.Errorf("status = %d, want 200", code)
+	}
+}
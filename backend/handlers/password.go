@@ -0,0 +1,148 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/session"
+	"poll_app/ent/user"
+	"poll_app/mail"
+
+	"github.com/julienschmidt/httprouter"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	passwordResetTTL = time.Hour
+	// passwordResetInterval is the minimum time between reset emails to one user
+	passwordResetInterval = time.Minute
+)
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+// ForgotPassword emails a password reset link. The response is the same
+// whether or not the email belongs to an account.
+func (h *Handler) ForgotPassword(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req ForgotPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Email == "" {
+		errorResponse(w, http.StatusBadRequest, "Email is required")
+		return
+	}
+
+	accepted := map[string]string{"message": "If the email belongs to an account, a reset link has been sent"}
+
+	u, err := h.client.User.Query().Where(user.Email(req.Email)).Only(r.Context())
+	if err != nil {
+		jsonResponse(w, http.StatusAccepted, accepted)
+		return
+	}
+
+	sent, err := h.authTokenSentSince(r.Context(), u, authtoken.KindPasswordReset, time.Now().Add(-passwordResetInterval))
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to send reset link")
+		return
+	}
+	if sent {
+		jsonResponse(w, http.StatusAccepted, accepted)
+		return
+	}
+
+	if err := h.sendPasswordResetLink(r.Context(), u); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to send reset link")
+		return
+	}
+
+	jsonResponse(w, http.StatusAccepted, accepted)
+}
+
+// sendPasswordResetLink emails u a link to choose a new password
+func (h *Handler) sendPasswordResetLink(ctx context.Context, u *ent.User) error {
+	token, err := h.createAuthToken(ctx, u, authtoken.KindPasswordReset, passwordResetTTL)
+	if err != nil {
+		return err
+	}
+
+	sendMail(mail.Message{
+		To:      u.Email,
+		Subject: "Reset your PollApp password",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen this link within an hour to choose a new password:\n\n%s/reset-password?token=%s\n\nIf you did not ask to reset your password, you can ignore this email.\n",
+			u.Username, frontendURL, url.QueryEscape(token)),
+	})
+	return nil
+}
+
+// ResetPassword sets a new password with a reset token and signs the user
+// out of every session
+func (h *Handler) ResetPassword(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req ResetPasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Token == "" || req.Password == "" {
+		errorResponse(w, http.StatusBadRequest, "Token and password are required")
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+
+	at, err := consumeAuthToken(r.Context(), tx, req.Token, authtoken.KindPasswordReset)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, errInvalidAuthToken) {
+			errorResponse(w, http.StatusBadRequest, "Reset link is invalid or has expired")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+	u := at.Edges.User
+
+	if err := tx.User.UpdateOne(u).SetPassword(string(hashedPassword)).Exec(r.Context()); err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	// Whoever knew the old password is signed out
+	err = tx.Session.Update().
+		Where(session.HasUserWith(user.ID(u.ID)), session.RevokedAtIsNil()).
+		SetRevokedAt(time.Now()).
+		Exec(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to reset password")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, map[string]string{"message": "Password has been reset"})
+}
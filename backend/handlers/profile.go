@@ -0,0 +1,201 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/session"
+	"poll_app/ent/user"
+
+	"github.com/julienschmidt/httprouter"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type UpdateProfileRequest struct {
+	Username *string `json:"username"`
+	Email    *string `json:"email"`
+	// Password, or Code on accounts without one, confirms an email change
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+	// Code confirms setting a first password on an account with two-factor
+	// authentication
+	Code string `json:"code"`
+}
+
+// UpdateProfile changes the current user's username or email. Changing the
+// email needs the password, since whoever controls the address can reset it,
+// and the new address has to be verified again.
+func (h *Handler) UpdateProfile(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	var req UpdateProfileRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.Username != nil && *req.Username == "" {
+		errorResponse(w, http.StatusBadRequest, "Username cannot be empty")
+		return
+	}
+	if req.Email != nil && !validEmail(*req.Email) {
+		errorResponse(w, http.StatusBadRequest, "Invalid email address")
+		return
+	}
+	emailChanged := req.Email != nil && *req.Email != u.Email
+	if emailChanged {
+		if !h.reauthenticate(w, r, u, ReauthRequest{Password: req.Password, Code: req.Code}, "Failed to update profile") {
+			return
+		}
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+
+	update := tx.User.UpdateOne(u)
+	if req.Username != nil {
+		update.SetUsername(*req.Username)
+	}
+	if emailChanged {
+		update.SetEmail(*req.Email).SetEmailVerified(false)
+
+		// Reset, sign-in and verification links already sent to the old
+		// address stop working
+		_, err := tx.AuthToken.Update().
+			Where(
+				authtoken.HasUserWith(user.ID(u.ID)),
+				authtoken.KindIn(authtoken.KindPasswordReset, authtoken.KindMagicLink, authtoken.KindEmailVerification),
+				authtoken.UsedAtIsNil(),
+			).
+			SetUsedAt(time.Now()).
+			Save(r.Context())
+		if err != nil {
+			tx.Rollback()
+			errorResponse(w, http.StatusInternalServerError, "Failed to update profile")
+			return
+		}
+	}
+
+	u, err = update.Save(r.Context())
+	if err != nil {
+		tx.Rollback()
+		if ent.IsConstraintError(err) {
+			errorResponse(w, http.StatusConflict, "Username or email already exists")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to update profile")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	if emailChanged {
+		if err := h.sendVerificationEmail(r.Context(), u); err != nil {
+			log.Printf("failed to send verification email: %v", err)
+		}
+	}
+
+	jsonResponse(w, http.StatusOK, currentUserToDTO(u))
+}
+
+// ChangePassword sets a new password after checking the current one. Every
+// other session is signed out, and reset and sign-in links already sent stop
+// working. Accounts without a password confirm their first one with a code, or
+// without two-factor authentication are emailed a link to set it, so an access
+// token alone cannot add a password.
+func (h *Handler) ChangePassword(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+	current := r.Context().Value(sessionContextKey).(*ent.Session)
+
+	var req ChangePasswordRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if u.Password == "" && !u.TotpEnabled {
+		h.emailPasswordLink(w, r, u)
+		return
+	}
+	if (u.Password != "" && req.CurrentPassword == "") || req.NewPassword == "" {
+		errorResponse(w, http.StatusBadRequest, "Current and new password are required")
+		return
+	}
+
+	if !h.reauthenticate(w, r, u, ReauthRequest{Password: req.CurrentPassword, Code: req.Code}, "Failed to change password") {
+		return
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.NewPassword), bcrypt.DefaultCost)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to hash password")
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	if err := tx.User.UpdateOne(u).SetPassword(string(hashedPassword)).Exec(r.Context()); err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to change password")
+		return
+	}
+	_, err = tx.AuthToken.Update().
+		Where(
+			authtoken.HasUserWith(user.ID(u.ID)),
+			authtoken.KindIn(authtoken.KindPasswordReset, authtoken.KindMagicLink),
+			authtoken.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to change password")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	if err := h.revokeSessions(r.Context(), session.HasUserWith(user.ID(u.ID)), session.IDNEQ(current.ID)); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to sign out other sessions")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, map[string]string{"message": "Password changed"})
+}
+
+// emailPasswordLink sends u a reset link to set a first password with, at
+// most once per passwordResetInterval
+func (h *Handler) emailPasswordLink(w http.ResponseWriter, r *http.Request, u *ent.User) {
+	accepted := map[string]string{"message": "We sent a link to your email to set your password"}
+
+	sent, err := h.authTokenSentSince(r.Context(), u, authtoken.KindPasswordReset, time.Now().Add(-passwordResetInterval))
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to send link")
+		return
+	}
+	if !sent {
+		if err := h.sendPasswordResetLink(r.Context(), u); err != nil {
+			errorResponse(w, http.StatusInternalServerError, "Failed to send link")
+			return
+		}
+	}
+	jsonResponse(w, http.StatusAccepted, accepted)
+}
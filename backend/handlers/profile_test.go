@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/totp"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// serveSession calls handle as u signed in with a new session, as the auth
+// middleware would
+func serveSession(t *testing.T, client *ent.Client, handle httprouter.Handle, u *ent.User, body any) *httptest.ResponseRecorder {
+	t.Helper()
+	s := client.Session.Create().
+		SetUser(u).
+		SetIP("192.0.2.1").
+		SetExpiresAt(time.Now().Add(time.Hour)).
+		SaveX(context.Background())
+	var b bytes.Buffer
+	if err := json.NewEncoder(&b).Encode(body); err != nil {
+		t.Fatal(err)
+	}
+	req := httptest.NewRequest(http.MethodPost, "/", &b)
+	req = req.WithContext(context.WithValue(withUser(req.Context(), u), sessionContextKey, s))
+	rec := httptest.NewRecorder()
+	handle(rec, req, nil)
+	return rec
+}
+
+func TestUpdateProfileEmail(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	jane := createUser(t, client, "jane")
+	sso, secret := enableTOTP(t, client, createUser(t, client, "sso"))
+	sso = client.User.UpdateOne(sso).ClearPassword().SaveX(context.Background())
+	passkey := client.User.UpdateOne(createUser(t, client, "passkey")).ClearPassword().SaveX(context.Background())
+	email := func(s string) *string { return &s }
+
+	tests := []struct {
+		name   string
+		u      *ent.User
+		req    UpdateProfileRequest
+		status int
+		errMsg string
+	}{
+		{"username needs no password", jane, UpdateProfileRequest{Username: email("janet")}, http.StatusOK, ""},
+		{"same email needs no password", jane, UpdateProfileRequest{Email: email("jane@example.com")}, http.StatusOK, ""},
+		{"no password", jane, UpdateProfileRequest{Email: email("j@example.com")}, http.StatusBadRequest, "Password is incorrect"},
+		{"wrong password", jane, UpdateProfileRequest{Email: email("j@example.com"), Password: "guess"}, http.StatusBadRequest, "Password is incorrect"},
+		{"password", jane, UpdateProfileRequest{Email: email("j@example.com"), Password: "password"}, http.StatusOK, ""},
+		{"no code", sso, UpdateProfileRequest{Email: email("s@example.com")}, http.StatusBadRequest, "Code from your authenticator app is required"},
+		{"wrong code", sso, UpdateProfileRequest{Email: email("s@example.com"), Code: "000000"}, http.StatusBadRequest, "Invalid code"},
+		{"code", sso, UpdateProfileRequest{Email: email("s@example.com"), Code: totpCode(t, secret, totp.Step(time.Now()))}, http.StatusOK, ""},
+		{"no password or authenticator", passkey, UpdateProfileRequest{Email: email("p@example.com")}, http.StatusBadRequest, "Set a password or turn on two-factor authentication to confirm this change"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := reload(t, client, tt.u)
+			rec := serve(t, h.UpdateProfile, before, tt.req)
+			after := reload(t, client, tt.u)
+			if tt.status == http.StatusOK {
+				decode(t, rec, http.StatusOK, nil)
+				if tt.req.Email != nil && after.Email != *tt.req.Email {
+					t.Errorf("email = %q, want %q", after.Email, *tt.req.Email)
+				}
+				return
+			}
+			var body map[string]string
+			decode(t, rec, tt.status, &body)
+			if body["error"] != tt.errMsg {
+				t.Errorf("error = %q, want %q", body["error"], tt.errMsg)
+			}
+			if after.Email != before.Email {
+				t.Errorf("email changed to %q", after.Email)
+			}
+		})
+	}
+}
+
+// TestChangePasswordVoidsLinks checks that reset and sign-in links sent before
+// a password change stop working
+func TestChangePasswordVoidsLinks(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	jane := createUser(t, client, "jane")
+	ctx := context.Background()
+	for _, kind := range []authtoken.Kind{authtoken.KindPasswordReset, authtoken.KindMagicLink, authtoken.KindEmailVerification} {
+		if _, err := h.createAuthToken(ctx, jane, kind, time.Hour); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	req := ChangePasswordRequest{CurrentPassword: "password", NewPassword: "correct horse"}
+	decode(t, serveSession(t, client, h.ChangePassword, jane, req), http.StatusOK, nil)
+
+	tokens := client.AuthToken.Query().AllX(ctx)
+	for _, at := range tokens {
+		// Verifying the address does not depend on the password
+		voided := at.Kind != authtoken.KindEmailVerification
+		if (at.UsedAt != nil) != voided {
+			t.Errorf("%s token: used = %v, want %v", at.Kind, at.UsedAt != nil, voided)
+		}
+	}
+	if len(tokens) != 3 {
+		t.Errorf("found %d tokens, want 3", len(tokens))
+	}
+}
+
+// TestChangePasswordFirstPassword checks that an access token alone cannot
+// add a password to an account without one
+func TestChangePasswordFirstPassword(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	ctx := context.Background()
+	withTOTP, secret := enableTOTP(t, client, createUser(t, client, "sso"))
+	withTOTP = client.User.UpdateOne(withTOTP).ClearPassword().SaveX(ctx)
+	withoutTOTP := client.User.UpdateOne(createUser(t, client, "passkey")).ClearPassword().SaveX(ctx)
+	jane := createUser(t, client, "jane")
+
+	tests := []struct {
+		name   string
+		u      *ent.User
+		req    ChangePasswordRequest
+		status int
+		errMsg string
+	}{
+		{"no code", withTOTP, ChangePasswordRequest{NewPassword: "new password"}, http.StatusBadRequest, "Code from your authenticator app is required"},
+		{"wrong code", withTOTP, ChangePasswordRequest{NewPassword: "new password", Code: "000000"}, http.StatusBadRequest, "Invalid code"},
+		{"wrong current password", jane, ChangePasswordRequest{CurrentPassword: "guess", NewPassword: "new password"}, http.StatusBadRequest, "Password is incorrect"},
+		{"no authenticator", withoutTOTP, ChangePasswordRequest{NewPassword: "new password"}, http.StatusAccepted, ""},
+		{"code", withTOTP, ChangePasswordRequest{NewPassword: "new password", Code: totpCode(t, secret, totp.Step(time.Now()))}, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			before := reload(t, client, tt.u)
+			rec := serveSession(t, client, h.ChangePassword, before, tt.req)
+			var body map[string]string
+			decode(t, rec, tt.status, &body)
+			if body["error"] != tt.errMsg {
+				t.Errorf("error = %q, want %q", body["error"], tt.errMsg)
+			}
+			changed := reload(t, client, tt.u).Password != before.Password
+			if changed != (tt.status == http.StatusOK) {
+				t.Errorf("password changed = %v", changed)
+			}
+		})
+	}
+
+	// The account without an authenticator was emailed a link instead, and
+	// asking again within a minute sends no other
+	decode(t, serveSession(t, client, h.ChangePassword, withoutTOTP, ChangePasswordRequest{}), http.StatusAccepted, nil)
+	links := client.AuthToken.Query().Where(authtoken.KindEQ(authtoken.KindPasswordReset)).AllX(ctx)
+	if len(links) != 1 || links[0].Email != withoutTOTP.Email || links[0].UsedAt != nil {
+		t.Errorf("found reset links %+v, want one unused for %s", links, withoutTOTP.Email)
+	}
+}
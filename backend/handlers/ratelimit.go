@@ -0,0 +1,149 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/auditlog"
+	"poll_app/ratelimit"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+var limitStore ratelimit.Store = ratelimit.NewMemoryStore()
+
+// SetRateLimitStore sets where rate limits and lockouts are kept. The default
+// in-memory store limits each instance separately; nil turns limits off.
+func SetRateLimitStore(s ratelimit.Store) {
+	limitStore = s
+}
+
+// loginLockout locks an email out of password login after repeated failures
+var loginLockout = ratelimit.Lockout{
+	Threshold: 5,
+	Window:    15 * time.Minute,
+	Base:      time.Minute,
+	Max:       time.Hour,
+}
+
+// LimitIP rate limits next per client address
+func (h *Handler) LimitIP(limit ratelimit.Limit, next httprouter.Handle) httprouter.Handle {
+	return h.rateLimit(limit, func(r *http.Request) string { return "ip:" + throttleIP(r) }, next)
+}
+
+// LimitEmail rate limits next per account, named by the email field of the
+// JSON request body
+func (h *Handler) LimitEmail(limit ratelimit.Limit, next httprouter.Handle) httprouter.Handle {
+	return h.rateLimit(limit, func(r *http.Request) string {
+		body, err := io.ReadAll(io.LimitReader(r.Body, 1<<20))
+		if err != nil {
+			return ""
+		}
+		// Leave the body for next
+		r.Body = io.NopCloser(bytes.NewReader(body))
+		var req struct {
+			Email string `json:"email"`
+		}
+		if json.Unmarshal(body, &req) != nil || req.Email == "" {
+			return ""
+		}
+		return "email:" + normalizeEmail(req.Email)
+	}, next)
+}
+
+func (h *Handler) rateLimit(limit ratelimit.Limit, key func(*http.Request) string, next httprouter.Handle) httprouter.Handle {
+	return func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+		if limitStore == nil {
+			next(w, r, ps)
+			return
+		}
+		k := key(r)
+		if k == "" {
+			next(w, r, ps)
+			return
+		}
+
+		ok, wait, err := limit.Allow(r.Context(), limitStore, r.URL.Path+" "+k)
+		if err != nil {
+			// A broken store should not take logins down with it
+			log.Printf("rate limit failed: %v", err)
+		} else if !ok {
+			setRetryAfter(w, wait)
+			errorResponse(w, http.StatusTooManyRequests, "Too many requests; try again later")
+			return
+		}
+		next(w, r, ps)
+	}
+}
+
+// setRetryAfter tells the client to wait d before trying again
+func setRetryAfter(w http.ResponseWriter, d time.Duration) {
+	w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(d.Seconds()))))
+}
+
+// normalizeEmail makes differently written forms of an address share limits
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// loginLockedFor returns how long email is still locked out of password login
+func loginLockedFor(ctx context.Context, email string) time.Duration {
+	if limitStore == nil {
+		return 0
+	}
+	left, err := loginLockout.Locked(ctx, limitStore, "lockout:"+normalizeEmail(email))
+	if err != nil {
+		log.Printf("lockout check failed: %v", err)
+	}
+	return left
+}
+
+// loginFailed records a failed password login for email, which u is the
+// account of if it exists, and audits the lockout it may start
+func (h *Handler) loginFailed(r *http.Request, email string, u *ent.User) {
+	if limitStore == nil {
+		return
+	}
+	email = normalizeEmail(email)
+	locked, err := loginLockout.Fail(r.Context(), limitStore, "lockout:"+email)
+	if err != nil {
+		log.Printf("lockout update failed: %v", err)
+		return
+	}
+	if locked == 0 {
+		return
+	}
+
+	log.Printf("login for %s locked for %s", email, locked)
+	create := h.client.AuditLog.Create().
+		SetAction(auditlog.ActionLoginLockout).
+		SetEmail(email).
+		SetIP(throttleIP(r)).
+		SetDetail(fmt.Sprintf("locked for %s after %d failed logins", locked, loginLockout.Threshold))
+	if u != nil {
+		create.SetUser(u)
+	}
+	if err := create.Exec(r.Context()); err != nil {
+		log.Printf("failed to write audit log: %v", err)
+	}
+}
+
+// loginSucceeded clears the failed logins of email
+func loginSucceeded(ctx context.Context, email string) {
+	if limitStore == nil {
+		return
+	}
+	if err := loginLockout.Succeed(ctx, limitStore, "lockout:"+normalizeEmail(email)); err != nil {
+		log.Printf("lockout update failed: %v", err)
+	}
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestSetRetryAfter(t *testing.T) {
+	// Retry-After is in whole seconds, rounded up so clients never retry early
+	tests := []struct {
+		wait time.Duration
+		want string
+	}{
+		{0, "0"},
+		{time.Millisecond, "1"},
+		{500 * time.Millisecond, "1"},
+		{time.Second, "1"},
+		{1200 * time.Millisecond, "2"},
+		{15 * time.Minute, "900"},
+	}
+	for _, tt := range tests {
+		rec := httptest.NewRecorder()
+		setRetryAfter(rec, tt.wait)
+		if got := rec.Header().Get("Retry-After"); got != tt.want {
+			t.Errorf("setRetryAfter(%v) = %q, want %q", tt.wait, got, tt.want)
+		}
+	}
+}
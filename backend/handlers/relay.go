@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+
+	"poll_app/ent"
+	"poll_app/events"
+)
+
+// userEvent is the relayed form of a NotificationMessage. Each instance builds
+// the message itself for the sockets connected to it.
+type userEvent struct {
+	Type           string `json:"type"`
+	NotificationID int    `json:"notification_id,omitempty"`
+	IDs            []int  `json:"ids,omitempty"`
+}
+
+// StartEventRelay makes live events reach clients connected to any backend
+// instance by sending them through Postgres LISTEN/NOTIFY. Without it events
+// only reach clients of the instance that published them. It must be called
+// before the handler serves requests.
+func (h *Handler) StartEventRelay(ctx context.Context, dsn string) {
+	h.relayed = true
+	go func() {
+		if err := events.Listen(ctx, dsn, h.deliver, h.resync); err != nil && ctx.Err() == nil {
+			log.Printf("event relay stopped: %v", err)
+		}
+	}()
+}
+
+// publishPoll announces a change to a poll. Within tx, the event is sent when
+// tx commits; tx may be nil for changes made outside a transaction.
+func (h *Handler) publishPoll(ctx context.Context, tx *ent.Tx, pollID int, kind string) {
+	h.broadcast(ctx, tx, events.Message{PollID: pollID, Kind: kind})
+}
+
+// publishUser sends e to the user's notification sockets like publishPoll
+func (h *Handler) publishUser(ctx context.Context, tx *ent.Tx, userID int, e userEvent) {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return
+	}
+	h.broadcast(ctx, tx, events.Message{UserID: userID, Data: data})
+}
+
+func (h *Handler) broadcast(ctx context.Context, tx *ent.Tx, m events.Message) {
+	if !h.relayed {
+		if tx == nil {
+			h.deliver(m)
+			return
+		}
+		tx.OnCommit(func(next ent.Committer) ent.Committer {
+			return ent.CommitFunc(func(ctx context.Context, tx *ent.Tx) error {
+				if err := next.Commit(ctx, tx); err != nil {
+					return err
+				}
+				h.deliver(m)
+				return nil
+			})
+		})
+		return
+	}
+
+	var ex events.Execer = h.client
+	if tx != nil {
+		ex = tx
+	}
+	if err := events.Notify(ctx, ex, m); err != nil {
+		log.Printf("failed to publish event: %v", err)
+	}
+}
+
+// deliver passes a published message to the subscribers on this instance
+func (h *Handler) deliver(m events.Message) {
+	if m.UserID == 0 {
+		h.hub.Publish(m.PollID, m.Kind)
+		return
+	}
+
+	// Only instances holding one of the user's sockets need to build the message
+	if !h.feed.HasSubscribers(m.UserID) {
+		return
+	}
+	var e userEvent
+	if err := json.Unmarshal(m.Data, &e); err != nil {
+		return
+	}
+	ctx := context.Background()
+	msg := NotificationMessage{Type: e.Type, IDs: e.IDs}
+	if e.NotificationID != 0 {
+		n, err := h.client.Notification.Get(ctx, e.NotificationID)
+		if err != nil {
+			return
+		}
+		dto := notificationToDTO(n)
+		msg.Notification = &dto
+	}
+	count, err := h.unreadCount(ctx, m.UserID)
+	if err != nil {
+		return
+	}
+	msg.UnreadCount = count
+	h.feed.Publish(m.UserID, msg)
+}
+
+// resync brings subscribers up to date after the relay missed messages. Poll
+// streams refetch their poll and notification sockets are dropped, so their
+// clients reconnect and sync.
+func (h *Handler) resync() {
+	h.hub.PublishAll(events.KindUpdated)
+	h.feed.CloseAll()
+}
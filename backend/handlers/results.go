@@ -0,0 +1,262 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"poll_app/ent"
+	"poll_app/ent/poll"
+	"poll_app/ent/polloption"
+	"poll_app/ent/vote"
+	"poll_app/tally"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// Result counting methods
+const (
+	MethodPlurality = "plurality"
+	MethodIRV       = "irv"
+	MethodSchulze   = "schulze"
+	MethodScore     = "score"
+)
+
+type ResultsDTO struct {
+	PollID       int         `json:"poll_id"`
+	Method       string      `json:"method"`
+	Options      []OptionDTO `json:"options"`
+	TotalBallots int         `json:"total_ballots"`
+	Rounds       []RoundDTO  `json:"rounds,omitempty"`
+	Schulze      *SchulzeDTO `json:"schulze,omitempty"`
+	Winners      []int       `json:"winners"`
+}
+
+type RoundDTO struct {
+	Round      int             `json:"round"`
+	Tallies    []RoundTallyDTO `json:"tallies"`
+	Eliminated []int           `json:"eliminated,omitempty"`
+	Exhausted  int             `json:"exhausted"`
+}
+
+type RoundTallyDTO struct {
+	OptionID int `json:"option_id"`
+	Votes    int `json:"votes"`
+}
+
+// SchulzeDTO matrices are indexed in the order of OptionIDs
+type SchulzeDTO struct {
+	OptionIDs       []int   `json:"option_ids"`
+	Preferences     [][]int `json:"preferences"`
+	Strengths       [][]int `json:"strengths"`
+	Ranking         [][]int `json:"ranking"`
+	CondorcetWinner *int    `json:"condorcet_winner,omitempty"`
+}
+
+// GetResults returns the counted outcome of a poll. Ranked polls default to
+// instant-runoff and can also be counted with Schulze, score polls are won by
+// the highest average rating, and every poll can be counted by plurality.
+func (h *Handler) GetResults(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid poll ID")
+		return
+	}
+
+	p, err := h.client.Poll.Query().
+		Where(poll.ID(id)).
+		WithCreator().
+		WithOptions(func(q *ent.PollOptionQuery) {
+			// Tie-breaking depends on option order, so keep it stable
+			q.Order(ent.Asc(polloption.FieldID))
+		}).
+		Only(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Poll not found")
+		return
+	}
+
+	voted, err := h.hasVoted(r.Context(), p, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch ballots")
+		return
+	}
+	if resultsHidden(p, u.ID, voted) {
+		errorResponse(w, http.StatusForbidden, "Results are hidden for this poll")
+		return
+	}
+
+	method := r.URL.Query().Get("method")
+	if method == "" {
+		switch p.BallotType {
+		case poll.BallotTypeRanked:
+			method = MethodIRV
+		case poll.BallotTypeScore:
+			method = MethodScore
+		default:
+			method = MethodPlurality
+		}
+	}
+
+	ballots, err := h.loadBallots(r.Context(), p.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch ballots")
+		return
+	}
+
+	counts, err := h.countVotes(r.Context(), p)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch vote counts")
+		return
+	}
+
+	// The viewer passed resultsHidden above
+	options := optionsToDTO(p, counts, false)
+	results := ResultsDTO{
+		PollID:       p.ID,
+		Method:       method,
+		Options:      options,
+		TotalBallots: len(ballots),
+		Winners:      []int{},
+	}
+
+	optionIDs := make([]int, len(p.Edges.Options))
+	for i, opt := range p.Edges.Options {
+		optionIDs[i] = opt.ID
+	}
+
+	switch method {
+	case MethodPlurality:
+		results.Winners = pluralityWinners(options)
+	case MethodIRV:
+		if p.BallotType != poll.BallotTypeRanked {
+			errorResponse(w, http.StatusBadRequest, "Instant-runoff results are only available for ranked polls")
+			return
+		}
+		irv := tally.InstantRunoff(optionIDs, ballots)
+		for i, round := range irv.Rounds {
+			results.Rounds = append(results.Rounds, roundToDTO(i+1, optionIDs, round))
+		}
+		if irv.Winner != 0 {
+			results.Winners = []int{irv.Winner}
+		}
+	case MethodSchulze:
+		if p.BallotType != poll.BallotTypeRanked {
+			errorResponse(w, http.StatusBadRequest, "Schulze results are only available for ranked polls")
+			return
+		}
+		schulze := tally.Schulze(optionIDs, ballots)
+		results.Schulze = &SchulzeDTO{
+			OptionIDs:   schulze.Options,
+			Preferences: schulze.Preferences,
+			Strengths:   schulze.Strengths,
+			Ranking:     schulze.Ranking,
+		}
+		if schulze.CondorcetWinner != 0 {
+			results.Schulze.CondorcetWinner = &schulze.CondorcetWinner
+		}
+		if len(ballots) > 0 && len(schulze.Ranking) > 0 {
+			results.Winners = schulze.Ranking[0]
+		}
+	case MethodScore:
+		if p.BallotType != poll.BallotTypeScore {
+			errorResponse(w, http.StatusBadRequest, "Score results are only available for score polls")
+			return
+		}
+		results.Winners = highestAverageWinners(options)
+	default:
+		errorResponse(w, http.StatusBadRequest, "Unknown results method")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, results)
+}
+
+// loadBallots groups a poll's votes into one ballot per voter, ordered by rank
+func (h *Handler) loadBallots(ctx context.Context, pollID int) ([]tally.Ballot, error) {
+	votes, err := h.client.Vote.Query().
+		Where(vote.HasOptionWith(polloption.HasPollWith(poll.ID(pollID)))).
+		WithUser().
+		WithOption().
+		Order(ent.Asc(vote.FieldRank), ent.Asc(vote.FieldID)).
+		All(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Anonymous votes carry a voter hash in place of the user
+	var voters []string
+	byVoter := make(map[string]tally.Ballot)
+	for _, v := range votes {
+		var voterKey string
+		if v.VoterHash != nil {
+			voterKey = "anon:" + *v.VoterHash
+		} else if v.Edges.User != nil {
+			voterKey = fmt.Sprintf("user:%d", v.Edges.User.ID)
+		} else {
+			continue
+		}
+		if _, ok := byVoter[voterKey]; !ok {
+			voters = append(voters, voterKey)
+		}
+		byVoter[voterKey] = append(byVoter[voterKey], v.Edges.Option.ID)
+	}
+
+	sort.Strings(voters)
+	ballots := make([]tally.Ballot, len(voters))
+	for i, voterID := range voters {
+		ballots[i] = byVoter[voterID]
+	}
+	return ballots, nil
+}
+
+func pluralityWinners(options []OptionDTO) []int {
+	most := 0
+	winners := []int{}
+	for _, opt := range options {
+		switch {
+		case opt.VoteCount > most:
+			most = opt.VoteCount
+			winners = []int{opt.ID}
+		case opt.VoteCount == most && most > 0:
+			winners = append(winners, opt.ID)
+		}
+	}
+	return winners
+}
+
+func highestAverageWinners(options []OptionDTO) []int {
+	var best float64
+	winners := []int{}
+	for _, opt := range options {
+		if opt.AverageScore == nil {
+			continue
+		}
+		switch avg := *opt.AverageScore; {
+		case len(winners) == 0 || avg > best:
+			best = avg
+			winners = []int{opt.ID}
+		case avg == best:
+			winners = append(winners, opt.ID)
+		}
+	}
+	return winners
+}
+
+func roundToDTO(number int, optionIDs []int, round tally.Round) RoundDTO {
+	dto := RoundDTO{
+		Round:      number,
+		Eliminated: round.Eliminated,
+		Exhausted:  round.Exhausted,
+	}
+	for _, id := range optionIDs {
+		if votes, ok := round.Tallies[id]; ok {
+			dto.Tallies = append(dto.Tallies, RoundTallyDTO{OptionID: id, Votes: votes})
+		}
+	}
+	return dto
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/poll"
+)
+
+// TestResultsVisibility checks every results visibility policy against each
+// endpoint that reveals counts, for the creator, a voter and someone who has
+// not voted, while the poll is open and after it closed
+func TestResultsVisibility(t *testing.T) {
+	// visible lists the viewers who see the results
+	tests := []struct {
+		policy  poll.ResultsVisibility
+		closed  bool
+		visible []string
+	}{
+		{poll.ResultsVisibilityAlways, false, []string{"creator", "voter", "other"}},
+		{poll.ResultsVisibilityAlways, true, []string{"creator", "voter", "other"}},
+		{poll.ResultsVisibilityAfterVote, false, []string{"creator", "voter"}},
+		{poll.ResultsVisibilityAfterVote, true, []string{"creator", "voter", "other"}},
+		{poll.ResultsVisibilityAfterClose, false, []string{"creator"}},
+		{poll.ResultsVisibilityAfterClose, true, []string{"creator", "voter", "other"}},
+		{poll.ResultsVisibilityCreatorOnly, false, []string{"creator"}},
+		{poll.ResultsVisibilityCreatorOnly, true, []string{"creator"}},
+	}
+	for _, tt := range tests {
+		state := "open"
+		if tt.closed {
+			state = "closed"
+		}
+		t.Run(string(tt.policy)+"/"+state, func(t *testing.T) {
+			client := openTestClient(t, nil)
+			h := NewHandler(client)
+			creator := createUser(t, client, "creator")
+			voter := createUser(t, client, "jane")
+			other := createUser(t, client, "sam")
+			p, options := createPoll(t, client, creator, poll.BallotTypeSingle, 1, 1)
+			p = client.Poll.UpdateOne(p).SetResultsVisibility(tt.policy).SaveX(context.Background())
+
+			// Vote: the voter sees the results right away unless only closing or
+			// the creator can reveal them
+			var voted PollDTO
+			decode(t, serveID(t, h.Vote, voter, p.ID, VoteRequest{OptionID: options[0]}), http.StatusOK, &voted)
+			wantHidden := tt.policy == poll.ResultsVisibilityAfterClose || tt.policy == poll.ResultsVisibilityCreatorOnly
+			checkOptions(t, "Vote", voted, wantHidden, options[0])
+
+			if tt.closed {
+				client.Poll.UpdateOne(p).SetClosesAt(time.Now().Add(-time.Minute)).ExecX(context.Background())
+			}
+
+			visible := make(map[string]bool)
+			for _, name := range tt.visible {
+				visible[name] = true
+			}
+			for name, u := range map[string]*ent.User{"creator": creator, "voter": voter, "other": other} {
+				t.Run(name, func(t *testing.T) {
+					hidden := !visible[name]
+
+					var got PollDTO
+					decode(t, serveID(t, h.GetPoll, u, p.ID, nil), http.StatusOK, &got)
+					checkOptions(t, "GetPoll", got, hidden, options[0])
+
+					var page PollPageDTO
+					decode(t, serve(t, h.ListPolls, u, nil), http.StatusOK, &page)
+					if len(page.Polls) != 1 {
+						t.Fatalf("ListPolls returned %d polls, want 1", len(page.Polls))
+					}
+					checkOptions(t, "ListPolls", page.Polls[0], hidden, options[0])
+
+					rec := serveID(t, h.GetVoters, u, options[0], nil)
+					if hidden {
+						decode(t, rec, http.StatusForbidden, nil)
+					} else {
+						var voters []UserDTO
+						decode(t, rec, http.StatusOK, &voters)
+						if len(voters) != 1 || voters[0].ID != voter.ID {
+							t.Errorf("GetVoters = %+v, want the voter", voters)
+						}
+					}
+
+					rec = serveID(t, h.GetResults, u, p.ID, nil)
+					if hidden {
+						decode(t, rec, http.StatusForbidden, nil)
+						return
+					}
+					var results ResultsDTO
+					decode(t, rec, http.StatusOK, &results)
+					if results.Options[0].VoteCount != 1 || results.TotalBallots != 1 {
+						t.Errorf("GetResults counted %d votes in %d ballots, want 1 in 1", results.Options[0].VoteCount, results.TotalBallots)
+					}
+					if !reflect.DeepEqual(results.Winners, []int{options[0]}) {
+						t.Errorf("GetResults winners = %v, want [%d]", results.Winners, options[0])
+					}
+				})
+			}
+		})
+	}
+}
+
+// checkOptions checks that dto reports the single vote on votedID, or that its
+// counts are withheld if hidden
+func checkOptions(t *testing.T, endpoint string, dto PollDTO, hidden bool, votedID int) {
+	t.Helper()
+	if dto.ResultsHidden != hidden {
+		t.Errorf("%s: results_hidden = %v, want %v", endpoint, dto.ResultsHidden, hidden)
+	}
+	for _, opt := range dto.Options {
+		want := 0
+		if opt.ID == votedID && !hidden {
+			want = 1
+		}
+		if opt.VoteCount != want {
+			t.Errorf("%s: option %d has %d votes, want %d", endpoint, opt.ID, opt.VoteCount, want)
+		}
+	}
+}
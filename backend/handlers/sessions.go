@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/session"
+	"poll_app/ent/user"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const sessionContextKey contextKey = "session"
+
+// lastSeenInterval limits how often requests refresh a session's last_seen_at
+const lastSeenInterval = time.Minute
+
+type SessionDTO struct {
+	ID         int       `json:"id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastSeenAt time.Time `json:"last_seen_at"`
+	// Current marks the session making the request
+	Current bool `json:"current"`
+}
+
+// trustProxy is whether requests come through a proxy that appends the
+// client's address to X-Forwarded-For
+var trustProxy bool
+
+// SetTrustProxy sets whether to take client addresses from X-Forwarded-For.
+// Without a proxy in front, clients could set the header to anything.
+func SetTrustProxy(trust bool) {
+	trustProxy = trust
+}
+
+// clientIP returns the address of the client. Behind Render's proxy that is
+// the first X-Forwarded-For entry, which clients can forge, so it is only
+// fit for display.
+func clientIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" && trustProxy {
+		ip, _, _ := strings.Cut(fwd, ",")
+		return strings.TrimSpace(ip)
+	}
+	return remoteHost(r)
+}
+
+// throttleIP returns the address to throttle the client by: the last
+// X-Forwarded-For entry, which Render's proxy appends, so clients cannot
+// choose it
+func throttleIP(r *http.Request) string {
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" && trustProxy {
+		return strings.TrimSpace(fwd[strings.LastIndex(fwd, ",")+1:])
+	}
+	return remoteHost(r)
+}
+
+// remoteHost returns the address the request's connection came from
+func remoteHost(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// ListSessions returns the current user's active sessions, most recently used first
+func (h *Handler) ListSessions(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+	current := r.Context().Value(sessionContextKey).(*ent.Session)
+
+	sessions, err := h.client.Session.Query().
+		Where(
+			session.HasUserWith(user.ID(u.ID)),
+			session.RevokedAtIsNil(),
+			session.ExpiresAtGT(time.Now()),
+		).
+		Order(ent.Desc(session.FieldLastSeenAt)).
+		All(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch sessions")
+		return
+	}
+
+	dtos := make([]SessionDTO, len(sessions))
+	for i, s := range sessions {
+		dtos[i] = SessionDTO{
+			ID:         s.ID,
+			UserAgent:  s.UserAgent,
+			IP:         s.IP,
+			CreatedAt:  s.CreatedAt,
+			LastSeenAt: s.LastSeenAt,
+			Current:    s.ID == current.ID,
+		}
+	}
+	jsonResponse(w, http.StatusOK, dtos)
+}
+
+// RevokeSession signs one of the current user's sessions out
+func (h *Handler) RevokeSession(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid session ID")
+		return
+	}
+
+	exists, err := h.client.Session.Query().
+		Where(session.ID(id), session.HasUserWith(user.ID(u.ID)), session.RevokedAtIsNil()).
+		Exist(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+	if !exists {
+		errorResponse(w, http.StatusNotFound, "Session not found")
+		return
+	}
+
+	if err := h.revokeSessions(r.Context(), session.ID(id)); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to revoke session")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
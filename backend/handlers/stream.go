@@ -0,0 +1,132 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"poll_app/events"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// heartbeatInterval keeps idle streams from being cut by proxies
+const heartbeatInterval = 15 * time.Second
+
+// sessionCheckInterval is how often streams and sockets check the token they
+// were opened with again, so they end soon after it expires or its session is
+// revoked
+var sessionCheckInterval = 30 * time.Second
+
+// StreamPoll pushes the poll as GetPoll returns it over Server-Sent Events
+// whenever it changes. EventSource cannot set headers, so the JWT may also be
+// passed as the token query parameter. A client reconnecting with the
+// Last-Event-ID of the latest change is not sent the poll again. The stream
+// ends once the token is no longer valid.
+func (h *Handler) StreamPoll(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	tokenString := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if tokenString == "" {
+		tokenString = r.URL.Query().Get("token")
+	}
+	if tokenString == "" {
+		errorResponse(w, http.StatusUnauthorized, "Authorization header required")
+		return
+	}
+	u, _, msg := h.authenticate(r.Context(), tokenString)
+	if msg != "" {
+		errorResponse(w, http.StatusUnauthorized, msg)
+		return
+	}
+	ctx := withUser(r.Context(), u)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid poll ID")
+		return
+	}
+
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		errorResponse(w, http.StatusInternalServerError, "Streaming unsupported")
+		return
+	}
+
+	// Subscribe before the first snapshot so no change slips in between
+	changes, lastID, cancel := h.hub.Subscribe(id)
+	defer cancel()
+
+	dto, err := h.getPollDTO(ctx, id, u.ID)
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Poll not found")
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+
+	resumed := lastID != 0 && r.Header.Get("Last-Event-ID") == strconv.FormatUint(lastID, 10)
+	if !resumed {
+		if err := writeEvent(w, lastID, "poll", dto); err != nil {
+			return
+		}
+	}
+	flusher.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	recheck := time.NewTicker(sessionCheckInterval)
+	defer recheck.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		case <-recheck.C:
+			// EventSource reconnects with the same token and gives up on the
+			// 401, after which the client connects again with a fresh one
+			if _, _, msg := h.authenticate(r.Context(), tokenString); msg != "" {
+				return
+			}
+		case e := <-changes:
+			if e.Kind == events.KindDeleted {
+				writeEvent(w, e.ID, "deleted", struct{}{})
+				flusher.Flush()
+				return
+			}
+			if err := h.streamSnapshot(ctx, w, e, u.ID); err != nil {
+				return
+			}
+		}
+		flusher.Flush()
+	}
+}
+
+// streamSnapshot sends the poll as the user sees it after e. Losing access to
+// the poll ends the stream.
+func (h *Handler) streamSnapshot(ctx context.Context, w http.ResponseWriter, e events.Event, userID int) error {
+	dto, err := h.getPollDTO(ctx, e.PollID, userID)
+	if err != nil {
+		return err
+	}
+	return writeEvent(w, e.ID, "poll", dto)
+}
+
+func writeEvent(w http.ResponseWriter, id uint64, event string, data any) error {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", id, event, b)
+	return err
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/poll"
+	"poll_app/ent/session"
+	"poll_app/ent/user"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// startTestSession signs u in and returns the access token, checking sessions
+// again every few milliseconds until the test ends
+func startTestSession(t *testing.T, h *Handler, u *ent.User) string {
+	t.Helper()
+	interval := sessionCheckInterval
+	sessionCheckInterval = 10 * time.Millisecond
+	t.Cleanup(func() { sessionCheckInterval = interval })
+	resp, err := h.startSession(context.Background(), httptest.NewRequest(http.MethodPost, "/", nil), u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return resp.Token
+}
+
+// TestStreamEndsWithSession checks that revoking the session a poll stream
+// was opened with ends the stream
+func TestStreamEndsWithSession(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	u := createUser(t, client, "jane")
+	p, _ := createPoll(t, client, u, poll.BallotTypeSingle, 1, 1)
+	token := startTestSession(t, h, u)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	req := httptest.NewRequestWithContext(ctx, http.MethodGet, "/?token="+token, nil)
+	rec := httptest.NewRecorder()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		h.StreamPoll(rec, req, httprouter.Params{{Key: "id", Value: strconv.Itoa(p.ID)}})
+	}()
+
+	select {
+	case <-done:
+		t.Fatalf("stream ended while the session was valid: %d %s", rec.Code, rec.Body)
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	client.Session.Update().
+		Where(session.HasUserWith(user.ID(u.ID))).
+		SetRevokedAt(time.Now()).
+		ExecX(context.Background())
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		cancel()
+		<-done
+		t.Fatal("stream still open after its session was revoked")
+	}
+	if !strings.Contains(rec.Body.String(), "event: poll") {
+		t.Errorf("stream did not send the poll: %s", rec.Body)
+	}
+}
@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/team"
+	"poll_app/ent/user"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type CreateTeamRequest struct {
+	Name string `json:"name"`
+}
+
+type AddTeamMemberRequest struct {
+	User string `json:"user"` // username or email
+}
+
+type TeamDTO struct {
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Owner     UserDTO   `json:"owner"`
+	Members   []UserDTO `json:"members"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// findUser looks a user up by username or email
+func (h *Handler) findUser(ctx context.Context, name string) (*ent.User, error) {
+	name = strings.TrimSpace(name)
+	return h.client.User.Query().
+		Where(user.Or(user.Username(name), user.Email(name))).
+		Only(ctx)
+}
+
+func (h *Handler) CreateTeam(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	var req CreateTeamRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if strings.TrimSpace(req.Name) == "" {
+		errorResponse(w, http.StatusBadRequest, "Team name is required")
+		return
+	}
+
+	// The owner is always a member of their own team
+	t, err := h.client.Team.Create().
+		SetName(strings.TrimSpace(req.Name)).
+		SetOwner(u).
+		AddMembers(u).
+		Save(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to create team")
+		return
+	}
+
+	t, err = h.loadTeam(r.Context(), t.ID)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch team")
+		return
+	}
+	jsonResponse(w, http.StatusCreated, teamToDTO(t))
+}
+
+func (h *Handler) ListTeams(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	teams, err := h.client.Team.Query().
+		Where(team.HasMembersWith(user.ID(u.ID))).
+		WithOwner().
+		WithMembers().
+		Order(ent.Asc(team.FieldName)).
+		All(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to fetch teams")
+		return
+	}
+
+	dtos := make([]TeamDTO, len(teams))
+	for i, t := range teams {
+		dtos[i] = teamToDTO(t)
+	}
+	jsonResponse(w, http.StatusOK, dtos)
+}
+
+func (h *Handler) AddTeamMember(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+
+	t, err := h.loadTeam(r.Context(), id)
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Team not found")
+		return
+	}
+	if t.Edges.Owner.ID != u.ID {
+		errorResponse(w, http.StatusForbidden, "Only the team owner can add members")
+		return
+	}
+
+	var req AddTeamMemberRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	member, err := h.findUser(r.Context(), req.User)
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "User not found")
+		return
+	}
+
+	if _, err := h.client.Team.UpdateOne(t).AddMembers(member).Save(r.Context()); err != nil {
+		if ent.IsConstraintError(err) {
+			errorResponse(w, http.StatusConflict, "User is already a member")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to add member")
+		return
+	}
+
+	t, _ = h.loadTeam(r.Context(), id)
+	jsonResponse(w, http.StatusOK, teamToDTO(t))
+}
+
+// RemoveTeamMember lets the owner remove a member, or a member leave the team
+func (h *Handler) RemoveTeamMember(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	id, err := strconv.Atoi(ps.ByName("id"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid team ID")
+		return
+	}
+	memberID, err := strconv.Atoi(ps.ByName("userId"))
+	if err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid user ID")
+		return
+	}
+
+	t, err := h.loadTeam(r.Context(), id)
+	if err != nil {
+		errorResponse(w, http.StatusNotFound, "Team not found")
+		return
+	}
+	if t.Edges.Owner.ID != u.ID && memberID != u.ID {
+		errorResponse(w, http.StatusForbidden, "Only the team owner can remove other members")
+		return
+	}
+	if memberID == t.Edges.Owner.ID {
+		errorResponse(w, http.StatusBadRequest, "The team owner cannot leave the team")
+		return
+	}
+
+	isMember := false
+	for _, m := range t.Edges.Members {
+		if m.ID == memberID {
+			isMember = true
+			break
+		}
+	}
+	if !isMember {
+		errorResponse(w, http.StatusNotFound, "User is not a member of this team")
+		return
+	}
+
+	if _, err := h.client.Team.UpdateOne(t).RemoveMemberIDs(memberID).Save(r.Context()); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to remove member")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *Handler) loadTeam(ctx context.Context, id int) (*ent.Team, error) {
+	return h.client.Team.Query().
+		Where(team.ID(id)).
+		WithOwner().
+		WithMembers(func(q *ent.UserQuery) {
+			q.Order(ent.Asc(user.FieldUsername))
+		}).
+		Only(ctx)
+}
+
+func teamToDTO(t *ent.Team) TeamDTO {
+	members := make([]UserDTO, len(t.Edges.Members))
+	for i, m := range t.Edges.Members {
+		members[i] = UserDTO{ID: m.ID, Username: m.Username, Email: m.Email}
+	}
+	return TeamDTO{
+		ID:   t.ID,
+		Name: t.Name,
+		Owner: UserDTO{
+			ID:       t.Edges.Owner.ID,
+			Username: t.Edges.Owner.Username,
+			Email:    t.Edges.Owner.Email,
+		},
+		Members:   members,
+		CreatedAt: t.CreatedAt,
+	}
+}
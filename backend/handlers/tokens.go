@@ -0,0 +1,218 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/predicate"
+	"poll_app/ent/refreshtoken"
+	"poll_app/ent/session"
+	"poll_app/ent/user"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	accessTokenTTL  = 15 * time.Minute
+	refreshTokenTTL = 30 * 24 * time.Hour
+)
+
+type RefreshRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+// newToken returns a random URL-safe token and the hash stored for it
+func newToken() (string, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return token, hashToken(token), nil
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// startSession records a login from r and issues its first tokens
+func (h *Handler) startSession(ctx context.Context, r *http.Request, u *ent.User) (AuthResponse, error) {
+	now := time.Now()
+
+	// Drop the user's expired sessions so they do not pile up
+	expired := session.And(session.HasUserWith(user.ID(u.ID)), session.ExpiresAtLT(now))
+	_, _ = h.client.RefreshToken.Delete().Where(refreshtoken.HasSessionWith(expired)).Exec(ctx)
+	_, _ = h.client.Session.Delete().Where(expired).Exec(ctx)
+
+	s, err := h.client.Session.Create().
+		SetUser(u).
+		SetUserAgent(r.UserAgent()).
+		SetIP(clientIP(r)).
+		SetExpiresAt(now.Add(refreshTokenTTL)).
+		Save(ctx)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	return issueTokens(ctx, h.client, u, s)
+}
+
+// issueTokens signs an access token for the session and stores a refresh token for it with c
+func issueTokens(ctx context.Context, c *ent.Client, u *ent.User, s *ent.Session) (AuthResponse, error) {
+	token, err := generateToken(u.ID, s.ID)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	refresh, refreshHash, err := newToken()
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	err = c.RefreshToken.Create().
+		SetTokenHash(refreshHash).
+		SetExpiresAt(s.ExpiresAt).
+		SetSession(s).
+		Exec(ctx)
+	if err != nil {
+		return AuthResponse{}, err
+	}
+	return AuthResponse{
+		Token:        token,
+		RefreshToken: refresh,
+		User:         currentUserToDTO(u),
+	}, nil
+}
+
+// RefreshTokens exchanges a refresh token for a new access and refresh token.
+// Each refresh token can be used once; presenting a used one means it was
+// stolen, so its session is revoked.
+func (h *Handler) RefreshTokens(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		errorResponse(w, http.StatusBadRequest, "Refresh token is required")
+		return
+	}
+
+	rt, err := h.client.RefreshToken.Query().
+		Where(refreshtoken.TokenHash(hashToken(req.RefreshToken))).
+		WithSession(func(q *ent.SessionQuery) { q.WithUser() }).
+		Only(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, "Invalid refresh token")
+		return
+	}
+	s := rt.Edges.Session
+
+	now := time.Now()
+	switch {
+	case s.RevokedAt != nil:
+		errorResponse(w, http.StatusUnauthorized, "Session has been revoked")
+		return
+	case rt.UsedAt != nil:
+		h.revokeSessions(r.Context(), session.ID(s.ID))
+		errorResponse(w, http.StatusUnauthorized, "Refresh token reuse detected")
+		return
+	case !rt.ExpiresAt.After(now):
+		errorResponse(w, http.StatusUnauthorized, "Refresh token has expired")
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+
+	// Mark the token used only if nothing else did, so a concurrent reuse cannot slip through
+	n, err := tx.RefreshToken.Update().
+		Where(refreshtoken.ID(rt.ID), refreshtoken.UsedAtIsNil()).
+		SetUsedAt(now).
+		Save(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to refresh token")
+		return
+	}
+	if n == 0 {
+		tx.Rollback()
+		h.revokeSessions(r.Context(), session.ID(s.ID))
+		errorResponse(w, http.StatusUnauthorized, "Refresh token reuse detected")
+		return
+	}
+
+	s, err = tx.Session.UpdateOne(s).
+		SetExpiresAt(now.Add(refreshTokenTTL)).
+		SetLastSeenAt(now).
+		Save(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to refresh token")
+		return
+	}
+
+	resp, err := issueTokens(r.Context(), tx.Client(), rt.Edges.Session.Edges.User, s)
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, resp)
+}
+
+// Logout ends the session of the refresh token
+func (h *Handler) Logout(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req RefreshRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.RefreshToken == "" {
+		errorResponse(w, http.StatusBadRequest, "Refresh token is required")
+		return
+	}
+
+	err := h.revokeSessions(r.Context(),
+		session.HasRefreshTokensWith(refreshtoken.TokenHash(hashToken(req.RefreshToken))))
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	// An unknown token gets the same response; the client is logged out either way
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// LogoutAll revokes every session of the current user
+func (h *Handler) LogoutAll(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	if err := h.revokeSessions(r.Context(), session.HasUserWith(user.ID(u.ID))); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to log out")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// JWKS publishes the public keys that verify access tokens, for other services
+func (h *Handler) JWKS(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	jsonResponse(w, http.StatusOK, tokenKeys.JWKS())
+}
+
+// revokeSessions ends the matching sessions. Their refresh tokens stop
+// working, and so do their access tokens.
+func (h *Handler) revokeSessions(ctx context.Context, ps ...predicate.Session) error {
+	return h.client.Session.Update().
+		Where(append(ps, session.RevokedAtIsNil())...).
+		SetRevokedAt(time.Now()).
+		Exec(ctx)
+}
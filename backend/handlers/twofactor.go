@@ -0,0 +1,462 @@
+package handlers
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/ent/recoverycode"
+	"poll_app/ent/user"
+	"poll_app/totp"
+
+	"github.com/julienschmidt/httprouter"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	totpIssuer = "PollApp"
+	// twoFactorChallengeTTL is how long a password login waits for its second factor
+	twoFactorChallengeTTL = 5 * time.Minute
+	// A challenge is dropped after maxChallengeAttempts wrong codes, and a
+	// user with maxTwoFactorFailures wrong codes within twoFactorWindow has
+	// to wait, since a new challenge is only a password away
+	maxChallengeAttempts = 5
+	maxTwoFactorFailures = 10
+	twoFactorWindow      = 15 * time.Minute
+	recoveryCodeCount    = 10
+)
+
+var recoveryEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"two_factor_required"`
+	ChallengeToken    string `json:"challenge_token"`
+}
+
+type TwoFactorLoginRequest struct {
+	ChallengeToken string `json:"challenge_token"`
+	// One of Code, from the authenticator app, or RecoveryCode
+	Code         string `json:"code"`
+	RecoveryCode string `json:"recovery_code"`
+}
+
+type TwoFactorSetupResponse struct {
+	Secret     string `json:"secret"`
+	OTPAuthURI string `json:"otpauth_uri"`
+}
+
+type EnableTwoFactorRequest struct {
+	Code string `json:"code"`
+	// Password confirms the change, as for SetupTwoFactor
+	Password string `json:"password"`
+}
+
+// ReauthRequest confirms a two-factor change with the current password, or
+// with a current authenticator code on accounts without a password
+type ReauthRequest struct {
+	Password string `json:"password"`
+	Code     string `json:"code"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// checkPassword reports whether password is u's password
+func checkPassword(u *ent.User, password string) bool {
+	return bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password)) == nil
+}
+
+// reauthenticate checks req against u before a change to u's account and
+// answers the request with an error if it does not match, returning false.
+// Wrong passwords and codes count toward the lockout of failed logins, so a
+// stolen session cannot guess without limit; failMsg answers other failures.
+func (h *Handler) reauthenticate(w http.ResponseWriter, r *http.Request, u *ent.User, req ReauthRequest, failMsg string) bool {
+	if left := loginLockedFor(r.Context(), u.Email); left > 0 {
+		setRetryAfter(w, left)
+		errorResponse(w, http.StatusTooManyRequests, "Too many failed attempts; try again later")
+		return false
+	}
+	msg, wrong, err := h.checkReauth(r.Context(), u, req)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, failMsg)
+		return false
+	}
+	if msg != "" {
+		if wrong {
+			h.loginFailed(r, u.Email, u)
+		}
+		// Not 401, which would mean the access token was rejected
+		errorResponse(w, http.StatusBadRequest, msg)
+		return false
+	}
+	loginSucceeded(r.Context(), u.Email)
+	return true
+}
+
+// checkReauth returns an error message if req does not match u, and whether
+// it was a wrong password or code. Accounts that only sign in with SSO or
+// passkeys have no password, so they confirm with a code, which is then used
+// up.
+func (h *Handler) checkReauth(ctx context.Context, u *ent.User, req ReauthRequest) (msg string, wrong bool, err error) {
+	if u.Password != "" {
+		if !checkPassword(u, req.Password) {
+			return "Password is incorrect", true, nil
+		}
+		return "", false, nil
+	}
+	if !u.TotpEnabled {
+		return "Set a password or turn on two-factor authentication to confirm this change", false, nil
+	}
+	if req.Code == "" {
+		return "Code from your authenticator app is required", false, nil
+	}
+	ok, err := h.useTOTPCode(ctx, u, req.Code)
+	if err != nil || ok {
+		return "", false, err
+	}
+	return "Invalid code", true, nil
+}
+
+// LoginTwoFactor completes a login started with a password by checking a
+// TOTP or recovery code against the challenge token Login returned
+func (h *Handler) LoginTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req TwoFactorLoginRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if req.ChallengeToken == "" || (req.Code == "" && req.RecoveryCode == "") {
+		errorResponse(w, http.StatusBadRequest, "Challenge token and code are required")
+		return
+	}
+
+	challenge, err := h.client.AuthToken.Query().
+		Where(
+			authtoken.TokenHash(hashToken(req.ChallengeToken)),
+			authtoken.KindEQ(authtoken.KindTwoFactor),
+			authtoken.UsedAtIsNil(),
+			authtoken.ExpiresAtGT(time.Now()),
+		).
+		WithUser().
+		Only(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusUnauthorized, "Login has expired, please sign in again")
+		return
+	}
+	u := challenge.Edges.User
+
+	failures, err := h.twoFactorFailures(r.Context(), u)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to check code")
+		return
+	}
+	if failures >= maxTwoFactorFailures {
+		setRetryAfter(w, twoFactorWindow)
+		errorResponse(w, http.StatusTooManyRequests, "Too many incorrect codes, try again later")
+		return
+	}
+
+	var ok bool
+	if req.Code != "" {
+		ok, err = h.useTOTPCode(r.Context(), u, req.Code)
+	} else {
+		ok, err = h.useRecoveryCode(r.Context(), u, req.RecoveryCode)
+	}
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to check code")
+		return
+	}
+
+	if !ok {
+		update := h.client.AuthToken.UpdateOne(challenge).AddAttempts(1)
+		if challenge.Attempts+1 >= maxChallengeAttempts {
+			update.SetUsedAt(time.Now())
+		}
+		if err := update.Exec(r.Context()); err != nil {
+			errorResponse(w, http.StatusInternalServerError, "Failed to check code")
+			return
+		}
+		errorResponse(w, http.StatusUnauthorized, "Invalid code")
+		return
+	}
+
+	// Claimed atomically so a challenge cannot start two sessions
+	n, err := h.client.AuthToken.Update().
+		Where(authtoken.ID(challenge.ID), authtoken.UsedAtIsNil()).
+		SetUsedAt(time.Now()).
+		Save(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+	if n == 0 {
+		errorResponse(w, http.StatusUnauthorized, "Login has expired, please sign in again")
+		return
+	}
+
+	resp, err := h.startSession(r.Context(), r, u)
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to generate token")
+		return
+	}
+	loginSucceeded(r.Context(), u.Email)
+
+	jsonResponse(w, http.StatusOK, resp)
+}
+
+// twoFactorFailures counts u's wrong second factor codes within twoFactorWindow
+func (h *Handler) twoFactorFailures(ctx context.Context, u *ent.User) (int, error) {
+	challenges, err := h.client.AuthToken.Query().
+		Where(
+			authtoken.HasUserWith(user.ID(u.ID)),
+			authtoken.KindEQ(authtoken.KindTwoFactor),
+			authtoken.CreatedAtGT(time.Now().Add(-twoFactorWindow)),
+		).
+		All(ctx)
+	if err != nil {
+		return 0, err
+	}
+	failures := 0
+	for _, c := range challenges {
+		failures += c.Attempts
+	}
+	return failures, nil
+}
+
+// useTOTPCode checks code against u's authenticator. Each code is accepted
+// once: its time step must be later than the last one used.
+func (h *Handler) useTOTPCode(ctx context.Context, u *ent.User, code string) (bool, error) {
+	if !u.TotpEnabled || u.TotpSecret == nil {
+		return false, nil
+	}
+	step, ok := totp.Validate(*u.TotpSecret, code, time.Now())
+	if !ok {
+		return false, nil
+	}
+	n, err := h.client.User.Update().
+		Where(user.ID(u.ID), user.TotpLastStepLT(step)).
+		SetTotpLastStep(step).
+		Save(ctx)
+	return n == 1, err
+}
+
+// useRecoveryCode marks one of u's unused recovery codes used
+func (h *Handler) useRecoveryCode(ctx context.Context, u *ent.User, code string) (bool, error) {
+	n, err := h.client.RecoveryCode.Update().
+		Where(
+			recoverycode.HasUserWith(user.ID(u.ID)),
+			recoverycode.CodeHash(hashToken(normalizeRecoveryCode(code))),
+			recoverycode.UsedAtIsNil(),
+		).
+		SetUsedAt(time.Now()).
+		Save(ctx)
+	return n == 1, err
+}
+
+func normalizeRecoveryCode(code string) string {
+	code = strings.ToLower(code)
+	code = strings.ReplaceAll(code, "-", "")
+	return strings.ReplaceAll(code, " ", "")
+}
+
+// replaceRecoveryCodes deletes u's recovery codes and stores new ones within
+// tx. It returns the codes, which are shown once and never stored.
+func replaceRecoveryCodes(ctx context.Context, tx *ent.Tx, u *ent.User) ([]string, error) {
+	if _, err := tx.RecoveryCode.Delete().Where(recoverycode.HasUserWith(user.ID(u.ID))).Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	builders := make([]*ent.RecoveryCodeCreate, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 6) // ten base32 characters
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		code := recoveryEncoding.EncodeToString(b)
+		codes[i] = code[:5] + "-" + code[5:]
+		builders[i] = tx.RecoveryCode.Create().SetCodeHash(hashToken(code)).SetUser(u)
+	}
+	if err := tx.RecoveryCode.CreateBulk(builders...).Exec(ctx); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// SetupTwoFactor starts TOTP enrollment with a new secret after checking the
+// current password. Two-factor authentication is not on until
+// EnableTwoFactor confirms a code.
+func (h *Handler) SetupTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	var req ReauthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if u.TotpEnabled {
+		errorResponse(w, http.StatusConflict, "Two-factor authentication is already enabled")
+		return
+	}
+	if !h.reauthenticate(w, r, u, req, "Failed to start two-factor setup") {
+		return
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start two-factor setup")
+		return
+	}
+	if err := h.client.User.UpdateOne(u).SetTotpSecret(secret).Exec(r.Context()); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start two-factor setup")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, TwoFactorSetupResponse{
+		Secret:     secret,
+		OTPAuthURI: totp.URI(totpIssuer, u.Email, secret),
+	})
+}
+
+// EnableTwoFactor turns on two-factor authentication once the user confirms
+// their password again and proves their authenticator works, and returns
+// their recovery codes
+func (h *Handler) EnableTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	var req EnableTwoFactorRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Code == "" {
+		errorResponse(w, http.StatusBadRequest, "Code is required")
+		return
+	}
+	if u.TotpEnabled {
+		errorResponse(w, http.StatusConflict, "Two-factor authentication is already enabled")
+		return
+	}
+	if u.TotpSecret == nil {
+		errorResponse(w, http.StatusBadRequest, "Start two-factor setup first")
+		return
+	}
+	if !h.reauthenticate(w, r, u, ReauthRequest{Password: req.Password}, "Failed to enable two-factor authentication") {
+		return
+	}
+
+	step, ok := totp.Validate(*u.TotpSecret, req.Code, time.Now())
+	if !ok {
+		errorResponse(w, http.StatusBadRequest, "Invalid code")
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	if err := tx.User.UpdateOne(u).SetTotpEnabled(true).SetTotpLastStep(step).Exec(r.Context()); err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to enable two-factor authentication")
+		return
+	}
+	codes, err := replaceRecoveryCodes(r.Context(), tx, u)
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to enable two-factor authentication")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
+
+// DisableTwoFactor turns off two-factor authentication after checking the
+// current password, or a code on accounts without one
+func (h *Handler) DisableTwoFactor(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	var req ReauthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !u.TotpEnabled {
+		errorResponse(w, http.StatusBadRequest, "Two-factor authentication is not enabled")
+		return
+	}
+	if !h.reauthenticate(w, r, u, req, "Failed to disable two-factor authentication") {
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	err = tx.User.UpdateOne(u).
+		ClearTotpSecret().
+		SetTotpEnabled(false).
+		SetTotpLastStep(0).
+		Exec(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to disable two-factor authentication")
+		return
+	}
+	if _, err := tx.RecoveryCode.Delete().Where(recoverycode.HasUserWith(user.ID(u.ID))).Exec(r.Context()); err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to disable two-factor authentication")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes after checking
+// the current password, or a code on accounts without one
+func (h *Handler) RegenerateRecoveryCodes(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	var req ReauthRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		errorResponse(w, http.StatusBadRequest, "Invalid request body")
+		return
+	}
+	if !u.TotpEnabled {
+		errorResponse(w, http.StatusBadRequest, "Two-factor authentication is not enabled")
+		return
+	}
+	if !h.reauthenticate(w, r, u, req, "Failed to generate recovery codes") {
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+	codes, err := replaceRecoveryCodes(r.Context(), tx, u)
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to generate recovery codes")
+		return
+	}
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, RecoveryCodesResponse{RecoveryCodes: codes})
+}
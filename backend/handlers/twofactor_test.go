@@ -0,0 +1,131 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/totp"
+)
+
+// enableTOTP turns on two-factor authentication for u, returning its secret
+func enableTOTP(t *testing.T, client *ent.Client, u *ent.User) (*ent.User, string) {
+	t.Helper()
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err = client.User.UpdateOne(u).SetTotpEnabled(true).SetTotpSecret(secret).Save(withUser(context.Background(), u))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return u, secret
+}
+
+// totpCode returns the code for secret at step
+func totpCode(t *testing.T, secret string, step int64) string {
+	t.Helper()
+	code, err := totp.Code(secret, step)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+// TestUseTOTPCodeOnce checks that each time step's code is accepted once, and
+// never after a later one was used
+func TestUseTOTPCodeOnce(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	u, secret := enableTOTP(t, client, createUser(t, client, "jane"))
+	ctx := withUser(context.Background(), u)
+	now := totp.Step(time.Now())
+
+	tests := []struct {
+		name   string
+		offset int64
+		want   bool
+	}{
+		{"previous step", -1, true},
+		{"same code again", -1, false},
+		{"current step", 0, true},
+		{"replayed current step", 0, false},
+		{"earlier step after a later one", -1, false},
+		{"next step", 1, true},
+		{"replayed next step", 1, false},
+	}
+	for _, tt := range tests {
+		ok, err := h.useTOTPCode(ctx, u, totpCode(t, secret, now+tt.offset))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != tt.want {
+			t.Errorf("%s: accepted = %v, want %v", tt.name, ok, tt.want)
+		}
+	}
+}
+
+// TestReauthLockout checks that wrong passwords given to confirm a change
+// lock the account like failed logins, and that a right one before the lock
+// starts the count again
+func TestReauthLockout(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	u, _ := enableTOTP(t, client, createUser(t, client, "jane"))
+
+	confirm := func(password string, status int) {
+		t.Helper()
+		rec := serve(t, h.RegenerateRecoveryCodes, u, ReauthRequest{Password: password})
+		decode(t, rec, status, nil)
+		if status == http.StatusTooManyRequests && rec.Header().Get("Retry-After") == "" {
+			t.Error("no Retry-After on 429")
+		}
+	}
+	for range loginLockout.Threshold - 1 {
+		confirm("guess", http.StatusBadRequest)
+	}
+	confirm("password", http.StatusOK)
+	for range loginLockout.Threshold {
+		confirm("guess", http.StatusBadRequest)
+	}
+	confirm("password", http.StatusTooManyRequests)
+
+	rec := serve(t, h.Login, nil, LoginRequest{Email: u.Email, Password: "password"})
+	decode(t, rec, http.StatusTooManyRequests, nil)
+}
+
+// TestTwoFactorSetupReauth checks that a session alone can neither replace
+// the TOTP secret nor turn two-factor authentication on
+func TestTwoFactorSetupReauth(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	u := createUser(t, client, "jane")
+
+	var body map[string]string
+	decode(t, serve(t, h.SetupTwoFactor, u, ReauthRequest{}), http.StatusBadRequest, &body)
+	if body["error"] != "Password is incorrect" {
+		t.Errorf("setup without a password: error = %q", body["error"])
+	}
+	if u = reload(t, client, u); u.TotpSecret != nil {
+		t.Fatal("setup without a password stored a secret")
+	}
+
+	var setup TwoFactorSetupResponse
+	decode(t, serve(t, h.SetupTwoFactor, u, ReauthRequest{Password: "password"}), http.StatusOK, &setup)
+	u = reload(t, client, u)
+	code := totpCode(t, setup.Secret, totp.Step(time.Now()))
+
+	decode(t, serve(t, h.EnableTwoFactor, u, EnableTwoFactorRequest{Code: code, Password: "guess"}), http.StatusBadRequest, &body)
+	if body["error"] != "Password is incorrect" {
+		t.Errorf("enable with a wrong password: error = %q", body["error"])
+	}
+	if reload(t, client, u).TotpEnabled {
+		t.Fatal("enabled with a wrong password")
+	}
+	decode(t, serve(t, h.EnableTwoFactor, u, EnableTwoFactorRequest{Code: code, Password: "password"}), http.StatusOK, nil)
+	if !reload(t, client, u).TotpEnabled {
+		t.Error("not enabled with the password and a code")
+	}
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	netmail "net/mail"
+	"net/url"
+	"time"
+
+	"poll_app/ent"
+	"poll_app/ent/authtoken"
+	"poll_app/mail"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+const (
+	emailVerificationTTL = 48 * time.Hour
+	// emailVerificationInterval is the minimum time between verification emails to one user
+	emailVerificationInterval = time.Minute
+)
+
+var requireEmailVerification = false
+
+// SetRequireEmailVerification sets whether users must verify their email
+// address before creating polls
+func SetRequireEmailVerification(require bool) {
+	requireEmailVerification = require
+}
+
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// validEmail reports whether s is a bare email address such as a@b.example
+func validEmail(s string) bool {
+	addr, err := netmail.ParseAddress(s)
+	return err == nil && addr.Address == s
+}
+
+// sendVerificationEmail emails u a link that confirms their address
+func (h *Handler) sendVerificationEmail(ctx context.Context, u *ent.User) error {
+	token, err := h.createAuthToken(ctx, u, authtoken.KindEmailVerification, emailVerificationTTL)
+	if err != nil {
+		return err
+	}
+	sendMail(mail.Message{
+		To:      u.Email,
+		Subject: "Confirm your PollApp email address",
+		Body: fmt.Sprintf("Hi %s,\n\nOpen this link within two days to confirm your email address:\n\n%s/verify-email?token=%s\n\nIf you did not sign up for PollApp, you can ignore this email.\n",
+			u.Username, frontendURL, url.QueryEscape(token)),
+	})
+	return nil
+}
+
+// VerifyEmail marks the email address of a verification token's user as verified
+func (h *Handler) VerifyEmail(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	var req VerifyEmailRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Token == "" {
+		errorResponse(w, http.StatusBadRequest, "Token is required")
+		return
+	}
+
+	tx, err := h.client.Tx(r.Context())
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to start transaction")
+		return
+	}
+
+	at, err := consumeAuthToken(r.Context(), tx, req.Token, authtoken.KindEmailVerification)
+	if err != nil {
+		tx.Rollback()
+		if errors.Is(err, errInvalidAuthToken) {
+			errorResponse(w, http.StatusBadRequest, "Verification link is invalid or has expired")
+			return
+		}
+		errorResponse(w, http.StatusInternalServerError, "Failed to verify email")
+		return
+	}
+
+	u, err := tx.User.UpdateOne(at.Edges.User).SetEmailVerified(true).Save(r.Context())
+	if err != nil {
+		tx.Rollback()
+		errorResponse(w, http.StatusInternalServerError, "Failed to verify email")
+		return
+	}
+
+	if err := tx.Commit(); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to commit transaction")
+		return
+	}
+
+	jsonResponse(w, http.StatusOK, currentUserToDTO(u))
+}
+
+// ResendVerificationEmail sends the current user a new verification link,
+// at most once per emailVerificationInterval
+func (h *Handler) ResendVerificationEmail(w http.ResponseWriter, r *http.Request, _ httprouter.Params) {
+	u := r.Context().Value(userContextKey).(*ent.User)
+
+	if u.EmailVerified {
+		errorResponse(w, http.StatusConflict, "Email is already verified")
+		return
+	}
+
+	sent, err := h.authTokenSentSince(r.Context(), u, authtoken.KindEmailVerification, time.Now().Add(-emailVerificationInterval))
+	if err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to send verification email")
+		return
+	}
+	if sent {
+		setRetryAfter(w, emailVerificationInterval)
+		errorResponse(w, http.StatusTooManyRequests, "A verification email was sent recently; try again in a minute")
+		return
+	}
+
+	if err := h.sendVerificationEmail(r.Context(), u); err != nil {
+		errorResponse(w, http.StatusInternalServerError, "Failed to send verification email")
+		return
+	}
+
+	jsonResponse(w, http.StatusAccepted, map[string]string{"message": "Verification email sent"})
+}
@@ -0,0 +1,275 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"sync"
+	"testing"
+
+	"poll_app/ent"
+	"poll_app/ent/notification"
+	"poll_app/ent/poll"
+	"poll_app/ent/privacy"
+	"poll_app/ent/user"
+	"poll_app/ent/vote"
+)
+
+// createPoll stores a poll with options A, B, C and D, returning their IDs
+func createPoll(t *testing.T, client *ent.Client, creator *ent.User, ballotType poll.BallotType, minChoices, maxChoices int) (*ent.Poll, []int) {
+	t.Helper()
+	ctx := context.Background()
+	p := client.Poll.Create().
+		SetTitle("Lunch").
+		SetBallotType(ballotType).
+		SetMinChoices(minChoices).
+		SetMaxChoices(maxChoices).
+		SetCreator(creator).
+		SaveX(ctx)
+	var ids []int
+	for _, text := range []string{"A", "B", "C", "D"} {
+		ids = append(ids, client.PollOption.Create().SetText(text).SetPoll(p).SaveX(ctx).ID)
+	}
+	return p, ids
+}
+
+// votedOptions returns the sorted IDs of the options u has votes on
+func votedOptions(t *testing.T, client *ent.Client, u *ent.User) []int {
+	t.Helper()
+	ctx := privacy.DecisionContext(context.Background(), privacy.Allow)
+	ids, err := client.Vote.Query().
+		Where(vote.HasUserWith(user.ID(u.ID))).
+		QueryOption().
+		IDs(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Ints(ids)
+	return ids
+}
+
+func TestVote(t *testing.T) {
+	tests := []struct {
+		name       string
+		ballotType poll.BallotType
+		min, max   int
+		// option indexes voted for, in order
+		first, second []int
+		// the response to the second vote, and the error if not 200
+		status int
+		errMsg string
+	}{
+		{
+			name:       "single choice",
+			ballotType: poll.BallotTypeSingle, min: 1, max: 1,
+			first: []int{0}, second: []int{2},
+			status: http.StatusOK,
+		},
+		{
+			name:       "single choice with two options",
+			ballotType: poll.BallotTypeSingle, min: 1, max: 1,
+			first: []int{0}, second: []int{1, 2},
+			status: http.StatusBadRequest, errMsg: "This poll allows exactly one choice",
+		},
+		{
+			name:       "multiple choice",
+			ballotType: poll.BallotTypeMultiple, min: 1, max: 3,
+			first: []int{0, 1}, second: []int{1, 2, 3},
+			status: http.StatusOK,
+		},
+		{
+			name:       "multiple choice below min",
+			ballotType: poll.BallotTypeMultiple, min: 2, max: 3,
+			first: []int{0, 1}, second: []int{3},
+			status: http.StatusBadRequest, errMsg: "Select between 2 and 3 options",
+		},
+		{
+			name:       "multiple choice above max",
+			ballotType: poll.BallotTypeMultiple, min: 1, max: 2,
+			first: []int{0}, second: []int{0, 1, 2},
+			status: http.StatusBadRequest, errMsg: "Select between 1 and 2 options",
+		},
+		{
+			name:       "multiple choice with an exact count",
+			ballotType: poll.BallotTypeMultiple, min: 2, max: 2,
+			first: []int{0, 1}, second: []int{2},
+			status: http.StatusBadRequest, errMsg: "Select exactly 2 options",
+		},
+		{
+			name:       "same option twice",
+			ballotType: poll.BallotTypeMultiple, min: 1, max: 4,
+			first: []int{0}, second: []int{1, 1},
+			status: http.StatusBadRequest, errMsg: "Each option can only be selected once",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := openTestClient(t, nil)
+			h := NewHandler(client)
+			creator := createUser(t, client, "creator")
+			voter := createUser(t, client, "jane")
+			p, options := createPoll(t, client, creator, tt.ballotType, tt.min, tt.max)
+			pick := func(indexes []int) []int {
+				var ids []int
+				for _, i := range indexes {
+					ids = append(ids, options[i])
+				}
+				return ids
+			}
+
+			var dto PollDTO
+			decode(t, serveID(t, h.Vote, voter, p.ID, VoteRequest{OptionIDs: pick(tt.first)}), http.StatusOK, &dto)
+			first := pick(tt.first)
+			sort.Ints(first)
+			if got := votedOptions(t, client, voter); !reflect.DeepEqual(got, first) {
+				t.Fatalf("after first vote: voted for %v, want %v", got, first)
+			}
+
+			rec := serveID(t, h.Vote, voter, p.ID, VoteRequest{OptionIDs: pick(tt.second)})
+			want := first
+			if tt.status == http.StatusOK {
+				decode(t, rec, http.StatusOK, &dto)
+				want = pick(tt.second)
+				sort.Ints(want)
+				got := append([]int(nil), dto.UserVotedOptionIDs...)
+				sort.Ints(got)
+				if !reflect.DeepEqual(got, want) {
+					t.Errorf("response selection = %v, want %v", got, want)
+				}
+			} else {
+				var body map[string]string
+				decode(t, rec, tt.status, &body)
+				if body["error"] != tt.errMsg {
+					t.Errorf("error = %q, want %q", body["error"], tt.errMsg)
+				}
+			}
+			// A replaced selection leaves no votes behind; a rejected one keeps the old
+			if got := votedOptions(t, client, voter); !reflect.DeepEqual(got, want) {
+				t.Errorf("voted for %v, want %v", got, want)
+			}
+		})
+	}
+}
+
+// TestVoteKeepsUpdatedAt checks that voting does not mark the poll as edited
+func TestVoteKeepsUpdatedAt(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	creator := createUser(t, client, "creator")
+	voter := createUser(t, client, "jane")
+	p, options := createPoll(t, client, creator, poll.BallotTypeSingle, 1, 1)
+
+	for _, id := range options[:2] {
+		var dto PollDTO
+		decode(t, serveID(t, h.Vote, voter, p.ID, VoteRequest{OptionID: id}), http.StatusOK, &dto)
+		if !dto.UpdatedAt.Equal(p.UpdatedAt) {
+			t.Errorf("updated_at = %v, want %v", dto.UpdatedAt, p.UpdatedAt)
+		}
+		if dto.PollEditedAfterVote {
+			t.Error("poll_edited_after_vote is set")
+		}
+	}
+}
+
+// TestVoteDuringEdit races a vote for an option against an edit that removes
+// it: the vote must be checked against the poll as edited or be removed with
+// the option, and must not restore the updated_at from before the edit
+func TestVoteDuringEdit(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	creator := createUser(t, client, "creator")
+
+	for i := range 100 {
+		voter := createUser(t, client, fmt.Sprintf("voter%d", i))
+		p, options := createPoll(t, client, creator, poll.BallotTypeSingle, 1, 1)
+		edit := UpdatePollRequest{Title: "Dinner", Options: []OptionUpdate{
+			{ID: options[0], Text: "A"}, {ID: options[1], Text: "B"}, {ID: options[3], Text: "D"},
+		}}
+
+		var wg sync.WaitGroup
+		var voted, edited *httptest.ResponseRecorder
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			voted = serveID(t, h.Vote, voter, p.ID, VoteRequest{OptionID: options[2]})
+		}()
+		go func() {
+			defer wg.Done()
+			edited = serveID(t, h.UpdatePoll, creator, p.ID, edit)
+		}()
+		wg.Wait()
+
+		if voted.Code != http.StatusOK && voted.Code != http.StatusBadRequest {
+			t.Fatalf("Vote returned %d: %s", voted.Code, voted.Body)
+		}
+		var dto PollDTO
+		decode(t, edited, http.StatusOK, &dto)
+		got := client.Poll.GetX(privacy.DecisionContext(context.Background(), privacy.Allow), p.ID)
+		if !got.UpdatedAt.Equal(dto.UpdatedAt) {
+			t.Errorf("updated_at = %v after the edit set %v", got.UpdatedAt, dto.UpdatedAt)
+		}
+		if ids := votedOptions(t, client, voter); len(ids) != 0 {
+			t.Errorf("voter has votes on %v after their option was removed", ids)
+		}
+	}
+}
+
+// TestRankedVoteChange checks that reordering a ranked ballot counts as a
+// changed vote
+func TestRankedVoteChange(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	creator := createUser(t, client, "creator")
+	voter := createUser(t, client, "jane")
+	p, options := createPoll(t, client, creator, poll.BallotTypeRanked, 1, 4)
+
+	ctx := privacy.DecisionContext(context.Background(), privacy.Allow)
+	for _, tt := range []struct {
+		ranking []int
+		changed int // vote_changed notifications so far
+	}{
+		{[]int{options[0], options[1]}, 0},
+		{[]int{options[0], options[1]}, 0},
+		{[]int{options[1], options[0]}, 1},
+	} {
+		decode(t, serveID(t, h.Vote, voter, p.ID, VoteRequest{OptionIDs: tt.ranking}), http.StatusOK, nil)
+		n := client.Notification.Query().Where(notification.Type("vote_changed")).CountX(ctx)
+		if n != tt.changed {
+			t.Errorf("after ranking %v: %d vote_changed notifications, want %d", tt.ranking, n, tt.changed)
+		}
+	}
+}
+
+// TestRemoveRankedOption checks that ballots which ranked a removed option
+// first count for their next choice
+func TestRemoveRankedOption(t *testing.T) {
+	client := openTestClient(t, nil)
+	h := NewHandler(client)
+	creator := createUser(t, client, "creator")
+	voter := createUser(t, client, "jane")
+	p, options := createPoll(t, client, creator, poll.BallotTypeRanked, 1, 4)
+	decode(t, serveID(t, h.Vote, voter, p.ID, VoteRequest{OptionIDs: []int{options[2], options[0], options[1]}}), http.StatusOK, nil)
+
+	edit := UpdatePollRequest{Title: "Lunch", Options: []OptionUpdate{
+		{ID: options[0], Text: "A"}, {ID: options[1], Text: "B"}, {ID: options[3], Text: "D"},
+	}}
+	decode(t, serveID(t, h.UpdatePoll, creator, p.ID, edit), http.StatusOK, nil)
+
+	var dto PollDTO
+	decode(t, serveID(t, h.GetPoll, voter, p.ID, nil), http.StatusOK, &dto)
+	if want := []int{options[0], options[1]}; !reflect.DeepEqual(dto.UserVotedOptionIDs, want) {
+		t.Errorf("ranking = %v, want %v", dto.UserVotedOptionIDs, want)
+	}
+	for _, opt := range dto.Options {
+		want := 0
+		if opt.ID == options[0] {
+			want = 1
+		}
+		if opt.VoteCount != want {
+			t.Errorf("option %d has %d first preferences, want %d", opt.ID, opt.VoteCount, want)
+		}
+	}
+}
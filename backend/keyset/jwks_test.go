@@ -0,0 +1,67 @@
+package keyset
+
+import (
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/base64"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+)
+
+// Public keys from the examples in RFC 7638 section 3.1 and RFC 8037
+// appendix A
+const (
+	rfcRSAModulus   = "0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw"
+	rfcEd25519X     = "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"
+	rfcRSAThumb     = "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs"
+	rfcEd25519Thumb = "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"
+)
+
+func decodeB64(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func rfcKeys(t *testing.T) (rsaKey, edKey *Key) {
+	t.Helper()
+	rsaKey = &Key{Alg: AlgRS256, Public: &rsa.PublicKey{N: new(big.Int).SetBytes(decodeB64(t, rfcRSAModulus)), E: 65537}}
+	edKey = &Key{Alg: AlgEdDSA, Public: ed25519.PublicKey(decodeB64(t, rfcEd25519X))}
+	return rsaKey, edKey
+}
+
+func TestThumbprint(t *testing.T) {
+	rsaKey, edKey := rfcKeys(t)
+	if got := thumbprint(rsaKey); got != rfcRSAThumb {
+		t.Errorf("RSA thumbprint = %s, want %s", got, rfcRSAThumb)
+	}
+	if got := thumbprint(edKey); got != rfcEd25519Thumb {
+		t.Errorf("Ed25519 thumbprint = %s, want %s", got, rfcEd25519Thumb)
+	}
+}
+
+func TestJWKS(t *testing.T) {
+	rsaKey, edKey := rfcKeys(t)
+	rsaKey.ID, edKey.ID = "b-retired", "c-published"
+	// A key that will sign later is published ahead of the rotation
+	edKey.NotBefore = time.Now().Add(time.Hour)
+	signer := newEd25519(t, "a-signing", time.Time{})
+	s, err := New([]*Key{edKey, signer, rsaKey})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := JWKS{Keys: []JWK{
+		{Kty: "OKP", Kid: "a-signing", Alg: AlgEdDSA, Use: "sig", Crv: "Ed25519", X: b64(signer.Public.(ed25519.PublicKey))},
+		{Kty: "RSA", Kid: "b-retired", Alg: AlgRS256, Use: "sig", N: rfcRSAModulus, E: "AQAB"},
+		{Kty: "OKP", Kid: "c-published", Alg: AlgEdDSA, Use: "sig", Crv: "Ed25519", X: rfcEd25519X},
+	}}
+	if got := s.JWKS(); !reflect.DeepEqual(got, want) {
+		t.Errorf("JWKS() = %+v, want %+v", got, want)
+	}
+}
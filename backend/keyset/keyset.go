@@ -0,0 +1,186 @@
+// Package keyset holds the asymmetric keys that sign and verify access
+// tokens. Several keys can be active at once so the signing key can be
+// rotated while tokens signed with the previous one stay valid.
+package keyset
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// Supported signing algorithms
+const (
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+)
+
+// Key is a verification key, and a signing key if Private is set.
+type Key struct {
+	ID  string
+	Alg string
+	// NotBefore is when the key starts signing tokens. Until then it is
+	// only published, so verifiers can pick it up ahead of the rotation.
+	NotBefore time.Time
+	Private   crypto.Signer
+	Public    crypto.PublicKey
+}
+
+func (k *Key) method() jwt.SigningMethod {
+	if k.Alg == AlgRS256 {
+		return jwt.SigningMethodRS256
+	}
+	return jwt.SigningMethodEdDSA
+}
+
+// Set is a collection of keys that can be replaced while in use.
+type Set struct {
+	mu   sync.RWMutex
+	keys map[string]*Key
+}
+
+// New returns a set of keys. At least one of them must be able to sign now.
+func New(keys []*Key) (*Set, error) {
+	s := &Set{}
+	if err := s.Replace(keys); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Ephemeral returns a set with a freshly generated Ed25519 key. Tokens it
+// signs stop verifying when the process exits.
+func Ephemeral() *Set {
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		panic(err)
+	}
+	k := &Key{Alg: AlgEdDSA, Private: priv, Public: pub}
+	k.ID = thumbprint(k)
+	return &Set{keys: map[string]*Key{k.ID: k}}
+}
+
+// Replace swaps in a new collection of keys. It fails unless one of them can
+// sign now, since keys that only sign later would leave Sign with none.
+func (s *Set) Replace(keys []*Key) error {
+	m := make(map[string]*Key, len(keys))
+	now := time.Now()
+	canSign := false
+	for _, k := range keys {
+		if _, dup := m[k.ID]; dup {
+			return fmt.Errorf("keyset: duplicate key ID %q", k.ID)
+		}
+		m[k.ID] = k
+		canSign = canSign || (k.Private != nil && !k.NotBefore.After(now))
+	}
+	if !canSign {
+		return errors.New("keyset: no private key to sign with now")
+	}
+
+	s.mu.Lock()
+	s.keys = m
+	s.mu.Unlock()
+	return nil
+}
+
+// signing returns the private key that started signing most recently
+func (s *Set) signing(now time.Time) (*Key, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	var current *Key
+	for _, k := range s.keys {
+		if k.Private == nil || k.NotBefore.After(now) {
+			continue
+		}
+		if current == nil || k.NotBefore.After(current.NotBefore) ||
+			(k.NotBefore.Equal(current.NotBefore) && k.ID > current.ID) {
+			current = k
+		}
+	}
+	if current == nil {
+		return nil, errors.New("keyset: no key is active yet")
+	}
+	return current, nil
+}
+
+// Sign returns a token for claims signed with the current key, which it
+// names in the kid header
+func (s *Set) Sign(claims jwt.Claims) (string, error) {
+	k, err := s.signing(time.Now())
+	if err != nil {
+		return "", err
+	}
+	token := jwt.NewWithClaims(k.method(), claims)
+	token.Header["kid"] = k.ID
+	return token.SignedString(k.Private)
+}
+
+// Keyfunc resolves the key a token names for jwt.Parse. The token must use
+// the algorithm of that key, so a key can never verify a token signed with
+// another method.
+func (s *Set) Keyfunc(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	s.mu.RLock()
+	k, ok := s.keys[kid]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("keyset: unknown key ID %q", kid)
+	}
+	if token.Method.Alg() != k.Alg {
+		return nil, fmt.Errorf("keyset: key %q does not sign with %s", kid, token.Method.Alg())
+	}
+	return k.Public, nil
+}
+
+// Methods lists the algorithms tokens may be signed with
+func Methods() []string {
+	return []string{AlgRS256, AlgEdDSA}
+}
+
+// Watch replaces the keys with the result of load every interval until ctx
+// is done. Failed loads are logged and leave the current keys in place.
+func (s *Set) Watch(ctx context.Context, interval time.Duration, load func() ([]*Key, error)) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			keys, err := load()
+			if err == nil {
+				err = s.Replace(keys)
+			}
+			if err != nil {
+				log.Printf("failed to reload signing keys: %v", err)
+			}
+		}
+	}
+}
+
+func checkKey(k *Key) error {
+	switch pub := k.Public.(type) {
+	case *rsa.PublicKey:
+		if pub.N.BitLen() < 2048 {
+			return fmt.Errorf("keyset: RSA key %q is shorter than 2048 bits", k.ID)
+		}
+		k.Alg = AlgRS256
+	case ed25519.PublicKey:
+		k.Alg = AlgEdDSA
+	default:
+		return fmt.Errorf("keyset: key %q has unsupported type %T", k.ID, k.Public)
+	}
+	return nil
+}
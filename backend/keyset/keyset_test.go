@@ -0,0 +1,207 @@
+package keyset
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+func newEd25519(t *testing.T, id string, notBefore time.Time) *Key {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Key{ID: id, Alg: AlgEdDSA, NotBefore: notBefore, Private: priv, Public: pub}
+}
+
+func newRSA(t *testing.T, id string, bits int) *Key {
+	t.Helper()
+	priv, err := rsa.GenerateKey(rand.Reader, bits)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &Key{ID: id, Alg: AlgRS256, Private: priv, Public: priv.Public()}
+}
+
+// verifyOnly returns k without its private key
+func verifyOnly(k *Key) *Key {
+	return &Key{ID: k.ID, Alg: k.Alg, NotBefore: k.NotBefore, Public: k.Public}
+}
+
+func TestSigningKey(t *testing.T) {
+	now := time.Now()
+	old := newEd25519(t, "old", now.Add(-48*time.Hour))
+	current := newEd25519(t, "current", now.Add(-time.Hour))
+	next := newEd25519(t, "next", now.Add(time.Hour))
+	tied := newEd25519(t, "tied", current.NotBefore)
+
+	tests := []struct {
+		name string
+		keys []*Key
+		at   time.Time
+		want string // empty if no key may sign
+	}{
+		{"one key", []*Key{old}, now, "old"},
+		{"newest active key signs", []*Key{old, current, next}, now, "current"},
+		{"scheduled key takes over", []*Key{old, current, next}, next.NotBefore, "next"},
+		{"before every key", []*Key{current, next}, old.NotBefore, ""},
+		{"ties go to the greater ID", []*Key{current, tied}, now, "tied"},
+		{"keys without a private part only verify", []*Key{old, verifyOnly(current)}, now, "old"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, err := New(tt.keys)
+			if err != nil {
+				t.Fatal(err)
+			}
+			k, err := s.signing(tt.at)
+			if tt.want == "" {
+				if err == nil {
+					t.Fatalf("signing with %q, want an error", k.ID)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if k.ID != tt.want {
+				t.Errorf("signing with %q, want %q", k.ID, tt.want)
+			}
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	k := newEd25519(t, "a", time.Time{})
+	if _, err := New([]*Key{verifyOnly(k)}); err == nil {
+		t.Error("accepted a set without a private key")
+	}
+	if _, err := New([]*Key{k, newEd25519(t, "a", time.Time{})}); err == nil {
+		t.Error("accepted a duplicate key ID")
+	}
+	// Every private key only signs later, so Sign would have none
+	later := newEd25519(t, "later", time.Now().Add(time.Hour))
+	if _, err := New([]*Key{verifyOnly(k), later}); err == nil {
+		t.Error("accepted a set whose private keys are not active yet")
+	}
+
+	s, err := New([]*Key{k})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Replace([]*Key{later}); err == nil {
+		t.Error("replaced the keys with ones not active yet")
+	}
+	if _, err := s.Sign(jwt.RegisteredClaims{Subject: "1"}); err != nil {
+		t.Errorf("a failed Replace left the set unable to sign: %v", err)
+	}
+}
+
+// TestRotation checks that tokens signed before a rotation keep verifying
+// while the new key signs
+func TestRotation(t *testing.T) {
+	old := newEd25519(t, "old", time.Time{})
+	s, err := New([]*Key{old})
+	if err != nil {
+		t.Fatal(err)
+	}
+	before, err := s.Sign(jwt.RegisteredClaims{Subject: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	next := newRSA(t, "next", 2048)
+	next.NotBefore = time.Now().Add(-time.Second)
+	if err := s.Replace([]*Key{verifyOnly(old), next}); err != nil {
+		t.Fatal(err)
+	}
+	after, err := s.Sign(jwt.RegisteredClaims{Subject: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for token, kid := range map[string]string{before: "old", after: "next"} {
+		parsed, err := jwt.Parse(token, s.Keyfunc, jwt.WithValidMethods(Methods()))
+		if err != nil {
+			t.Fatalf("token signed by %q: %v", kid, err)
+		}
+		if parsed.Header["kid"] != kid {
+			t.Errorf("kid = %v, want %q", parsed.Header["kid"], kid)
+		}
+	}
+}
+
+func TestKeyfunc(t *testing.T) {
+	ed := newEd25519(t, "ed", time.Time{})
+	rs := newRSA(t, "rs", 2048)
+	s, err := New([]*Key{ed, rs})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		method jwt.SigningMethod
+		kid    any
+		ok     bool
+	}{
+		{"EdDSA key", jwt.SigningMethodEdDSA, "ed", true},
+		{"RSA key", jwt.SigningMethodRS256, "rs", true},
+		{"unknown kid", jwt.SigningMethodEdDSA, "other", false},
+		{"no kid", jwt.SigningMethodEdDSA, nil, false},
+		{"kid that is not a string", jwt.SigningMethodEdDSA, 1, false},
+		{"RS256 with an EdDSA key", jwt.SigningMethodRS256, "ed", false},
+		{"EdDSA with an RSA key", jwt.SigningMethodEdDSA, "rs", false},
+		{"RS512 with an RSA key", jwt.SigningMethodRS512, "rs", false},
+		{"HS256 with an RSA key", jwt.SigningMethodHS256, "rs", false},
+		{"none", jwt.SigningMethodNone, "ed", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token := jwt.New(tt.method)
+			if tt.kid != nil {
+				token.Header["kid"] = tt.kid
+			}
+			key, err := s.Keyfunc(token)
+			if tt.ok && err != nil {
+				t.Fatal(err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("resolved key %T", key)
+			}
+		})
+	}
+
+	// An HMAC token keyed with the public key, which anyone can forge, must
+	// not verify
+	pub, err := x509.MarshalPKIXPublicKey(rs.Public)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{Subject: "1"})
+	forged.Header["kid"] = "rs"
+	signed, err := forged.SignedString(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: pub}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jwt.Parse(signed, s.Keyfunc, jwt.WithValidMethods(Methods())); err == nil {
+		t.Error("verified an HS256 token")
+	}
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.RegisteredClaims{Subject: "1"})
+	unsigned.Header["kid"] = "ed"
+	signed, err = unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := jwt.Parse(signed, s.Keyfunc); err == nil {
+		t.Error("verified an unsigned token")
+	}
+}
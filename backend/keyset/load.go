@@ -0,0 +1,115 @@
+package keyset
+
+import (
+	"crypto"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// PEM headers that configure a key
+const (
+	headerKeyID     = "Kid"
+	headerNotBefore = "Not-Before" // RFC 3339
+)
+
+// Load reads the keys in the .pem files of dir and in pemData. Either may be
+// empty.
+func Load(dir string, pemData []byte) ([]*Key, error) {
+	keys, err := ParsePEM(pemData, "")
+	if err != nil {
+		return nil, err
+	}
+	if dir == "" {
+		return keys, nil
+	}
+
+	paths, err := filepath.Glob(filepath.Join(dir, "*.pem"))
+	if err != nil {
+		return nil, err
+	}
+	for _, path := range paths {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		fileKeys, err := ParsePEM(data, strings.TrimSuffix(filepath.Base(path), ".pem"))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		keys = append(keys, fileKeys...)
+	}
+	return keys, nil
+}
+
+// ParsePEM reads PKCS#8 or PKCS#1 private keys and PKIX public keys, which
+// only verify. A block's Kid header sets its key ID, which otherwise is
+// defaultID or, for several blocks or none given, the key's RFC 7638
+// thumbprint. A Not-Before header schedules when a private key starts signing.
+func ParsePEM(data []byte, defaultID string) ([]*Key, error) {
+	var blocks []*pem.Block
+	for {
+		var b *pem.Block
+		b, data = pem.Decode(data)
+		if b == nil {
+			break
+		}
+		blocks = append(blocks, b)
+	}
+
+	keys := make([]*Key, 0, len(blocks))
+	for _, b := range blocks {
+		k := &Key{}
+		switch b.Type {
+		case "PRIVATE KEY":
+			priv, err := x509.ParsePKCS8PrivateKey(b.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			signer, ok := priv.(crypto.Signer)
+			if !ok {
+				return nil, fmt.Errorf("keyset: unsupported private key type %T", priv)
+			}
+			k.Private, k.Public = signer, signer.Public()
+		case "RSA PRIVATE KEY":
+			priv, err := x509.ParsePKCS1PrivateKey(b.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			k.Private, k.Public = priv, priv.Public()
+		case "PUBLIC KEY":
+			pub, err := x509.ParsePKIXPublicKey(b.Bytes)
+			if err != nil {
+				return nil, err
+			}
+			k.Public = pub
+		default:
+			return nil, fmt.Errorf("keyset: unexpected PEM block %q", b.Type)
+		}
+
+		if err := checkKey(k); err != nil {
+			return nil, err
+		}
+		if nb := b.Headers[headerNotBefore]; nb != "" {
+			t, err := time.Parse(time.RFC3339, nb)
+			if err != nil {
+				return nil, fmt.Errorf("keyset: invalid %s header: %w", headerNotBefore, err)
+			}
+			k.NotBefore = t
+		}
+		switch {
+		case b.Headers[headerKeyID] != "":
+			k.ID = b.Headers[headerKeyID]
+		case defaultID != "" && len(blocks) == 1:
+			k.ID = defaultID
+		default:
+			k.ID = thumbprint(k)
+		}
+		keys = append(keys, k)
+	}
+	return keys, nil
+}
@@ -0,0 +1,171 @@
+package keyset
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func encodePEM(t *testing.T, typ string, der []byte, headers map[string]string) []byte {
+	t.Helper()
+	return pem.EncodeToMemory(&pem.Block{Type: typ, Headers: headers, Bytes: der})
+}
+
+func pkcs8(t *testing.T, priv any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func pkix(t *testing.T, pub any) []byte {
+	t.Helper()
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return der
+}
+
+func TestParsePEM(t *testing.T) {
+	_, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	shortRSA, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edThumbprint := thumbprint(&Key{Public: edPriv.Public()})
+	rsaThumbprint := thumbprint(&Key{Public: rsaPriv.Public()})
+
+	edPKCS8 := encodePEM(t, "PRIVATE KEY", pkcs8(t, edPriv), nil)
+	rsaPKCS8 := encodePEM(t, "PRIVATE KEY", pkcs8(t, rsaPriv), nil)
+	rsaPKCS1 := encodePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv), nil)
+	edPublic := encodePEM(t, "PUBLIC KEY", pkix(t, edPriv.Public()), nil)
+	rsaPublic := encodePEM(t, "PUBLIC KEY", pkix(t, rsaPriv.Public()), nil)
+
+	// want describes a parsed key
+	type want struct {
+		id, alg   string
+		private   bool
+		notBefore time.Time
+	}
+	tests := []struct {
+		name      string
+		data      []byte
+		defaultID string
+		want      []want
+		wantErr   bool
+	}{
+		{name: "empty", data: nil},
+		{name: "Ed25519 PKCS#8", data: edPKCS8, want: []want{{edThumbprint, AlgEdDSA, true, time.Time{}}}},
+		{name: "RSA PKCS#8", data: rsaPKCS8, want: []want{{rsaThumbprint, AlgRS256, true, time.Time{}}}},
+		{name: "RSA PKCS#1", data: rsaPKCS1, want: []want{{rsaThumbprint, AlgRS256, true, time.Time{}}}},
+		{name: "Ed25519 public key", data: edPublic, want: []want{{edThumbprint, AlgEdDSA, false, time.Time{}}}},
+		{name: "RSA public key", data: rsaPublic, want: []want{{rsaThumbprint, AlgRS256, false, time.Time{}}}},
+		{name: "default ID", data: edPKCS8, defaultID: "2026-11", want: []want{{"2026-11", AlgEdDSA, true, time.Time{}}}},
+		{
+			name:      "default ID is not shared by several keys",
+			data:      append(append([]byte(nil), edPKCS8...), rsaPublic...),
+			defaultID: "2026-11",
+			want:      []want{{edThumbprint, AlgEdDSA, true, time.Time{}}, {rsaThumbprint, AlgRS256, false, time.Time{}}},
+		},
+		{
+			name:      "headers",
+			data:      encodePEM(t, "PRIVATE KEY", pkcs8(t, edPriv), map[string]string{"Kid": "next", "Not-Before": "2026-11-01T00:00:00Z"}),
+			defaultID: "ignored",
+			want:      []want{{"next", AlgEdDSA, true, time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)}},
+		},
+		{name: "invalid Not-Before", data: encodePEM(t, "PRIVATE KEY", pkcs8(t, edPriv), map[string]string{"Not-Before": "tomorrow"}), wantErr: true},
+		{name: "RSA key under 2048 bits", data: encodePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(shortRSA), nil), wantErr: true},
+		{name: "unsupported key type", data: encodePEM(t, "PRIVATE KEY", pkcs8(t, ecPriv), nil), wantErr: true},
+		{name: "unexpected block", data: encodePEM(t, "CERTIFICATE", []byte("cert"), nil), wantErr: true},
+		{name: "corrupt PKCS#8", data: encodePEM(t, "PRIVATE KEY", []byte("garbage"), nil), wantErr: true},
+		{name: "corrupt PKCS#1", data: encodePEM(t, "RSA PRIVATE KEY", []byte("garbage"), nil), wantErr: true},
+		{name: "corrupt public key", data: encodePEM(t, "PUBLIC KEY", []byte("garbage"), nil), wantErr: true},
+		{name: "PKCS#8 in a PKCS#1 block", data: encodePEM(t, "RSA PRIVATE KEY", pkcs8(t, rsaPriv), nil), wantErr: true},
+		{name: "not PEM", data: []byte("not a key"), want: nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keys, err := ParsePEM(tt.data, tt.defaultID)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parsed %d keys, want an error", len(keys))
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(keys) != len(tt.want) {
+				t.Fatalf("parsed %d keys, want %d", len(keys), len(tt.want))
+			}
+			for i, k := range keys {
+				got := want{k.ID, k.Alg, k.Private != nil, k.NotBefore}
+				if got != tt.want[i] {
+					t.Errorf("key %d = %+v, want %+v", i, got, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsaPriv, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	files := map[string][]byte{
+		"current.pem": encodePEM(t, "PRIVATE KEY", pkcs8(t, priv), nil),
+		"notes.txt":   []byte("not a key"),
+	}
+	for name, data := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), data, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	keys, err := Load(dir, encodePEM(t, "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsaPriv), map[string]string{"Kid": "env"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var ids []string
+	for _, k := range keys {
+		ids = append(ids, k.ID)
+	}
+	if len(ids) != 2 || ids[0] != "env" || ids[1] != "current" {
+		t.Errorf("loaded keys %v, want [env current]", ids)
+	}
+
+	if err := os.WriteFile(filepath.Join(dir, "broken.pem"), encodePEM(t, "PRIVATE KEY", []byte("garbage"), nil), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Load(dir, nil); err == nil {
+		t.Error("loaded a directory with a broken key")
+	}
+}
@@ -0,0 +1,29 @@
+package mail
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// LogMailer is for local development. It writes each message to a .eml file
+// in Dir, or to the log if Dir is empty, instead of sending it.
+type LogMailer struct {
+	Dir string
+}
+
+func (l *LogMailer) Send(ctx context.Context, m Message) error {
+	msg := format("poll_app@localhost", m)
+	if l.Dir == "" {
+		log.Printf("mail to %s:\n%s", m.To, msg)
+		return nil
+	}
+	if err := os.MkdirAll(l.Dir, 0o755); err != nil {
+		return err
+	}
+	name := fmt.Sprintf("%s.eml", time.Now().Format("20060102-150405.000000000"))
+	return os.WriteFile(filepath.Join(l.Dir, name), msg, 0o644)
+}
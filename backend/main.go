@@ -5,10 +5,17 @@ import (
 	"database/sql"
 	"log"
 	"net/http"
+	"net/url"
 	"os"
+	"strings"
+	"time"
 
 	"poll_app/ent"
+	_ "poll_app/ent/runtime"
 	"poll_app/handlers"
+	"poll_app/keyset"
+	"poll_app/mail"
+	"poll_app/ratelimit"
 
 	"entgo.io/ent/dialect"
 	entsql "entgo.io/ent/dialect/sql"
@@ -17,6 +24,16 @@ import (
 	"github.com/rs/cors"
 )
 
+// publicSecrets are the example secrets from this repository, which must not
+// be used in production
+var publicSecrets = map[string]bool{
+	"":                                       true,
+	"your-secret-key-change-in-production":   true,
+	"your-super-secret-key-change-this":      true,
+	"your-voter-secret-change-this":          true,
+	"your-voter-secret-change-in-production": true,
+}
+
 func getEnv(key, fallback string) string {
 	if value, ok := os.LookupEnv(key); ok {
 		return value
@@ -26,13 +43,104 @@ func getEnv(key, fallback string) string {
 
 func main() {
 	// Get configuration from environment
+	// In production, insecure development defaults stop the server from starting
+	production := getEnv("APP_ENV", "development") == "production"
 	port := getEnv("PORT", "8080")
 	databaseURL := getEnv("DATABASE_URL", "postgres://localhost/poll_app?sslmode=disable")
 	frontendURL := getEnv("FRONTEND_URL", "http://localhost:3000")
 	jwtSecret := getEnv("JWT_SECRET", "your-secret-key-change-in-production")
+	voterHashSecret := getEnv("VOTER_HASH_SECRET", "")
+	jwtKeysDir := getEnv("JWT_KEYS_DIR", "")
+	jwtKeys := []byte(getEnv("JWT_KEYS", ""))
+	smtpAddr := getEnv("SMTP_ADDR", "")
+	mailFrom := getEnv("MAIL_FROM", "PollApp <no-reply@localhost>")
+
+	allowedOrigins := []string{frontendURL, "http://localhost:3000", "http://localhost:5173"}
+
+	// Load the access token signing keys
+	keys, err := keyset.Load(jwtKeysDir, jwtKeys)
+	if err != nil {
+		log.Fatalf("failed loading signing keys: %v", err)
+	}
+	if len(keys) > 0 {
+		ks, err := keyset.New(keys)
+		if err != nil {
+			log.Fatalf("failed loading signing keys: %v", err)
+		}
+		handlers.SetKeySet(ks)
+		if jwtKeysDir != "" {
+			// Pick up keys added for rotation and drop retired ones
+			go ks.Watch(context.Background(), time.Minute, func() ([]*keyset.Key, error) {
+				return keyset.Load(jwtKeysDir, jwtKeys)
+			})
+		}
+	} else {
+		// A temporary key logs everyone out on every deploy and splits replicas
+		if production {
+			log.Fatalf("JWT_KEYS or JWT_KEYS_DIR must be set in production")
+		}
+		log.Printf("warning: JWT_KEYS_DIR and JWT_KEYS are unset; using a temporary signing key, so tokens will not survive a restart or work across replicas")
+	}
 
-	// Set JWT secret for handlers
-	handlers.SetJWTSecret(jwtSecret)
+	// Whoever knows the key can tell who cast anonymous votes
+	if publicSecrets[voterHashSecret] {
+		if production {
+			log.Fatalf("VOTER_HASH_SECRET must be set to a secret value in production")
+		}
+		log.Printf("warning: VOTER_HASH_SECRET is unset or an example value; anonymous votes can be linked to their voters")
+		if voterHashSecret == "" {
+			voterHashSecret = jwtSecret
+		}
+	}
+	handlers.SetVoterHashSecret(voterHashSecret)
+	handlers.SetAllowedOrigins(allowedOrigins)
+	handlers.SetFrontendURL(frontendURL)
+	handlers.SetRequireEmailVerification(getEnv("REQUIRE_EMAIL_VERIFICATION", "") == "true")
+	handlers.SetTrustProxy(getEnv("TRUST_PROXY", "") == "true")
+
+	// Passkeys are bound to the frontend's domain unless configured otherwise
+	rpID := getEnv("WEBAUTHN_RP_ID", "")
+	if rpID == "" {
+		if u, err := url.Parse(frontendURL); err == nil {
+			rpID = u.Hostname()
+		}
+	}
+	rpOrigins := strings.Split(getEnv("WEBAUTHN_ORIGINS", frontendURL), ",")
+	if err := handlers.SetRelyingParty(rpID, "PollApp", rpOrigins); err != nil {
+		log.Printf("warning: passkeys are disabled: %v", err)
+	}
+
+	// Single sign-on is enabled by configuring an OpenID Connect provider
+	if issuer := getEnv("OIDC_ISSUER", ""); issuer != "" {
+		var domains []string
+		if d := getEnv("OIDC_ALLOWED_DOMAINS", ""); d != "" {
+			domains = strings.Split(d, ",")
+		}
+		handlers.SetOIDC(handlers.OIDCConfig{
+			Issuer:         issuer,
+			ClientID:       getEnv("OIDC_CLIENT_ID", ""),
+			ClientSecret:   getEnv("OIDC_CLIENT_SECRET", ""),
+			RedirectURL:    getEnv("OIDC_REDIRECT_URL", "http://localhost:8080/api/auth/oidc/callback"),
+			Name:           getEnv("OIDC_PROVIDER_NAME", "SSO"),
+			AllowedDomains: domains,
+		})
+	}
+
+	// Send email over SMTP, or write it to MAIL_DIR (or the log) in development.
+	// Logged emails hold login and reset links, so production needs SMTP.
+	if smtpAddr == "" && production {
+		log.Fatalf("SMTP_ADDR must be set in production")
+	}
+	if smtpAddr != "" {
+		handlers.SetMailer(&mail.SMTPMailer{
+			Addr:     smtpAddr,
+			From:     mailFrom,
+			Username: getEnv("SMTP_USERNAME", ""),
+			Password: getEnv("SMTP_PASSWORD", ""),
+		})
+	} else {
+		handlers.SetMailer(&mail.LogMailer{Dir: getEnv("MAIL_DIR", "")})
+	}
 
 	// Initialize database connection
 	db, err := sql.Open("postgres", databaseURL)
@@ -50,16 +158,70 @@ func main() {
 		log.Fatalf("failed creating schema resources: %v", err)
 	}
 
+	// Share rate limits and lockouts between replicas through Postgres
+	if getEnv("RATE_LIMIT_STORE", "memory") == "postgres" {
+		store, err := ratelimit.NewPostgresStore(context.Background(), db)
+		if err != nil {
+			log.Fatalf("failed creating rate limit store: %v", err)
+		}
+		handlers.SetRateLimitStore(store)
+	}
+
 	// Initialize handlers
 	h := handlers.NewHandler(client)
 
+	// Give polls from before link keys existed one, so unlisted polls can be shared
+	if err := h.BackfillLinkKeys(context.Background()); err != nil {
+		log.Fatalf("failed backfilling poll link keys: %v", err)
+	}
+
+	// Relay live events between replicas through Postgres LISTEN/NOTIFY
+	h.StartEventRelay(context.Background(), databaseURL)
+
 	// Setup router
 	router := httprouter.New()
 
+	// Limits on endpoints open to guessing or sending email, per client
+	// address and, for logins, per account
+	signUpLimit := ratelimit.Limit{Events: 10, Per: time.Hour}
+	loginLimit := ratelimit.Limit{Events: 30, Per: 5 * time.Minute}
+	accountLimit := ratelimit.Limit{Events: 10, Per: 15 * time.Minute}
+	codeLimit := ratelimit.Limit{Events: 30, Per: 5 * time.Minute}
+	emailLimit := ratelimit.Limit{Events: 10, Per: 15 * time.Minute}
+
 	// Auth routes
-	router.POST("/api/auth/signup", h.SignUp)
-	router.POST("/api/auth/login", h.Login)
+	router.GET("/.well-known/jwks.json", h.JWKS)
+	router.POST("/api/auth/signup", h.LimitIP(signUpLimit, h.SignUp))
+	router.POST("/api/auth/login", h.LimitIP(loginLimit, h.LimitEmail(accountLimit, h.Login)))
+	router.POST("/api/auth/login/2fa", h.LimitIP(codeLimit, h.LoginTwoFactor))
+	router.POST("/api/auth/passkeys/login/begin", h.LimitIP(codeLimit, h.BeginPasskeyLogin))
+	router.POST("/api/auth/passkeys/login/finish", h.LimitIP(codeLimit, h.FinishPasskeyLogin))
+	router.GET("/api/auth/oidc/config", h.GetOIDCConfig)
+	router.GET("/api/auth/oidc/login", h.OIDCLogin)
+	router.GET("/api/auth/oidc/callback", h.OIDCCallback)
+	router.POST("/api/auth/oidc/exchange", h.LimitIP(codeLimit, h.OIDCExchange))
 	router.GET("/api/auth/me", h.AuthMiddleware(h.GetCurrentUser))
+	router.PATCH("/api/auth/me", h.LimitIP(codeLimit, h.AuthMiddleware(h.UpdateProfile)))
+	router.POST("/api/auth/me/password", h.LimitIP(codeLimit, h.AuthMiddleware(h.ChangePassword)))
+	router.POST("/api/auth/2fa/setup", h.LimitIP(codeLimit, h.AuthMiddleware(h.SetupTwoFactor)))
+	router.POST("/api/auth/2fa/enable", h.LimitIP(codeLimit, h.AuthMiddleware(h.EnableTwoFactor)))
+	router.POST("/api/auth/2fa/disable", h.LimitIP(codeLimit, h.AuthMiddleware(h.DisableTwoFactor)))
+	router.POST("/api/auth/2fa/recovery-codes", h.LimitIP(codeLimit, h.AuthMiddleware(h.RegenerateRecoveryCodes)))
+	router.GET("/api/auth/passkeys", h.AuthMiddleware(h.ListPasskeys))
+	router.POST("/api/auth/passkeys/register/begin", h.LimitIP(codeLimit, h.AuthMiddleware(h.BeginPasskeyRegistration)))
+	router.POST("/api/auth/passkeys/register/finish", h.LimitIP(codeLimit, h.AuthMiddleware(h.FinishPasskeyRegistration)))
+	router.DELETE("/api/auth/passkeys/:id", h.LimitIP(codeLimit, h.AuthMiddleware(h.DeletePasskey)))
+	router.POST("/api/auth/magic-link", h.LimitIP(emailLimit, h.RequestMagicLink))
+	router.POST("/api/auth/magic-link/login", h.LimitIP(codeLimit, h.MagicLinkLogin))
+	router.POST("/api/auth/forgot-password", h.LimitIP(emailLimit, h.ForgotPassword))
+	router.POST("/api/auth/reset-password", h.LimitIP(codeLimit, h.ResetPassword))
+	router.POST("/api/auth/verify-email", h.VerifyEmail)
+	router.POST("/api/auth/verify-email/resend", h.AuthMiddleware(h.ResendVerificationEmail))
+	router.POST("/api/auth/refresh", h.RefreshTokens)
+	router.POST("/api/auth/logout", h.Logout)
+	router.POST("/api/auth/logout-all", h.AuthMiddleware(h.LogoutAll))
+	router.GET("/api/auth/sessions", h.AuthMiddleware(h.ListSessions))
+	router.DELETE("/api/auth/sessions/:id", h.AuthMiddleware(h.RevokeSession))
 
 	// Poll routes
 	router.GET("/api/polls", h.AuthMiddleware(h.ListPolls))
@@ -67,22 +229,42 @@ func main() {
 	router.GET("/api/polls/:id", h.AuthMiddleware(h.GetPoll))
 	router.PUT("/api/polls/:id", h.AuthMiddleware(h.UpdatePoll))
 	router.DELETE("/api/polls/:id", h.AuthMiddleware(h.DeletePoll))
+	router.POST("/api/polls/:id/close", h.AuthMiddleware(h.ClosePoll))
+	router.POST("/api/polls/:id/reopen", h.AuthMiddleware(h.ReopenPoll))
+	router.GET("/api/polls/:id/stream", h.StreamPoll) // authenticates itself to accept ?token=
 
 	// Vote routes
 	router.POST("/api/polls/:id/vote", h.AuthMiddleware(h.Vote))
 	router.DELETE("/api/polls/:id/vote", h.AuthMiddleware(h.ClearVote))
 	router.GET("/api/options/:id/voters", h.AuthMiddleware(h.GetVoters))
 
+	// Results routes
+	router.GET("/api/polls/:id/results", h.AuthMiddleware(h.GetResults))
+
+	// Invite routes
+	router.GET("/api/polls/:id/invites", h.AuthMiddleware(h.ListInvites))
+	router.POST("/api/polls/:id/invites", h.AuthMiddleware(h.CreateInvite))
+	router.DELETE("/api/invites/:id", h.AuthMiddleware(h.RevokeInvite))
+	router.POST("/api/invites/redeem", h.AuthMiddleware(h.RedeemInvite))
+	router.POST("/api/polls/:id/join", h.AuthMiddleware(h.JoinPoll))
+
+	// Team routes
+	router.GET("/api/teams", h.AuthMiddleware(h.ListTeams))
+	router.POST("/api/teams", h.AuthMiddleware(h.CreateTeam))
+	router.POST("/api/teams/:id/members", h.AuthMiddleware(h.AddTeamMember))
+	router.DELETE("/api/teams/:id/members/:userId", h.AuthMiddleware(h.RemoveTeamMember))
+
 	// Notification routes
 	router.GET("/api/notifications", h.AuthMiddleware(h.GetNotifications))
 	router.GET("/api/notifications/unread-count", h.AuthMiddleware(h.GetUnreadCount))
 	router.POST("/api/notifications/mark-all-read", h.AuthMiddleware(h.MarkAllNotificationsRead))
 	router.PUT("/api/notifications/:id/read", h.AuthMiddleware(h.MarkNotificationRead))
+	router.GET("/api/notifications/ws", h.NotificationSocket) // authenticates itself to accept ?token=
 
 	// CORS middleware
 	c := cors.New(cors.Options{
-		AllowedOrigins:   []string{frontendURL, "http://localhost:3000", "http://localhost:5173"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedOrigins:   allowedOrigins,
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Authorization", "Content-Type"},
 		AllowCredentials: true,
 	})
@@ -0,0 +1,58 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+)
+
+// Lockout locks a key out after Threshold failures within Window. The first
+// lockout lasts Base and each further one twice as long, up to Max. The count
+// starts over after a success or a day without failures.
+type Lockout struct {
+	Threshold int
+	Window    time.Duration
+	Base      time.Duration
+	Max       time.Duration
+}
+
+// Locked returns how long key is still locked out, or 0
+func (l Lockout) Locked(ctx context.Context, s Store, key string) (time.Duration, error) {
+	e, found, err := s.Get(ctx, key)
+	if err != nil || !found {
+		return 0, err
+	}
+	return max(until(e.LockedUntil), 0), nil
+}
+
+// Fail records a failure for key. If it starts a lockout, Fail returns its
+// duration.
+func (l Lockout) Fail(ctx context.Context, s Store, key string) (time.Duration, error) {
+	var locked time.Duration
+	err := s.Update(ctx, key, func(e *Entry, found bool) {
+		now := clock()
+		if now.Sub(e.Updated) > l.Window {
+			e.Failures = 0
+		}
+		e.Failures++
+		e.Updated = now
+		if e.Failures < l.Threshold {
+			return
+		}
+
+		locked = l.Base << e.Strikes
+		if locked > l.Max || locked <= 0 {
+			locked = l.Max
+		}
+		e.Failures = 0
+		e.Strikes++
+		e.LockedUntil = now.Add(locked)
+	})
+	return locked, err
+}
+
+// Succeed clears the failures and lockouts of key
+func (l Lockout) Succeed(ctx context.Context, s Store, key string) error {
+	return s.Update(ctx, key, func(e *Entry, found bool) {
+		*e = Entry{Updated: clock()}
+	})
+}
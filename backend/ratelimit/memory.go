@@ -0,0 +1,53 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps entries in memory, so limits apply per instance
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]*Entry
+	swept   time.Time
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]*Entry), swept: clock()}
+}
+
+func (s *MemoryStore) Get(ctx context.Context, key string) (Entry, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	e, found := s.entries[key]
+	if !found || since(e.Updated) > Expiry {
+		return Entry{}, false, nil
+	}
+	return *e, true, nil
+}
+
+func (s *MemoryStore) Update(ctx context.Context, key string, fn func(e *Entry, found bool)) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := clock()
+	// Drop expired entries now and then
+	if now.Sub(s.swept) > time.Minute {
+		for k, e := range s.entries {
+			if now.Sub(e.Updated) > Expiry {
+				delete(s.entries, k)
+			}
+		}
+		s.swept = now
+	}
+
+	e, found := s.entries[key]
+	if !found || now.Sub(e.Updated) > Expiry {
+		e, found = &Entry{}, false
+		s.entries[key] = e
+	}
+	fn(e, found)
+	return nil
+}
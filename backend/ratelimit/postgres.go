@@ -0,0 +1,127 @@
+package ratelimit
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"log"
+	"sync"
+	"time"
+)
+
+// PostgresStore keeps entries in a Postgres table, so every instance using
+// the database shares the limits
+type PostgresStore struct {
+	db *sql.DB
+
+	mu    sync.Mutex
+	swept time.Time
+}
+
+// NewPostgresStore creates the rate_limits table if needed. The table is not
+// part of the ent schema: only this store uses it, through row locks and
+// ON CONFLICT upserts the generated client does not offer, and it keeps this
+// package free of ent so the in-memory store needs no database. Auto migration
+// never drops tables missing from the schema, so the two do not interfere.
+func NewPostgresStore(ctx context.Context, db *sql.DB) (*PostgresStore, error) {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS rate_limits (
+		key TEXT PRIMARY KEY,
+		tokens DOUBLE PRECISION NOT NULL DEFAULT 0,
+		failures INTEGER NOT NULL DEFAULT 0,
+		strikes INTEGER NOT NULL DEFAULT 0,
+		locked_until TIMESTAMPTZ,
+		updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+	)`)
+	if err != nil {
+		return nil, err
+	}
+	return &PostgresStore{db: db, swept: clock()}, nil
+}
+
+func (s *PostgresStore) Get(ctx context.Context, key string) (Entry, bool, error) {
+	var (
+		e           Entry
+		lockedUntil sql.NullTime
+	)
+	err := s.db.QueryRowContext(ctx,
+		`SELECT tokens, failures, strikes, locked_until, updated_at FROM rate_limits WHERE key = $1 AND updated_at > $2`,
+		key, clock().Add(-Expiry),
+	).Scan(&e.Tokens, &e.Failures, &e.Strikes, &lockedUntil, &e.Updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		return Entry{}, false, nil
+	}
+	if err != nil {
+		return Entry{}, false, err
+	}
+	e.LockedUntil = lockedUntil.Time
+	return e, true, nil
+}
+
+func (s *PostgresStore) Update(ctx context.Context, key string, fn func(e *Entry, found bool)) error {
+	s.sweep()
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// The row lock serializes updates of key across instances
+	var (
+		e           Entry
+		lockedUntil sql.NullTime
+		found       = true
+	)
+	err = tx.QueryRowContext(ctx,
+		`SELECT tokens, failures, strikes, locked_until, updated_at FROM rate_limits WHERE key = $1 FOR UPDATE`, key,
+	).Scan(&e.Tokens, &e.Failures, &e.Strikes, &lockedUntil, &e.Updated)
+	if errors.Is(err, sql.ErrNoRows) {
+		found = false
+	} else if err != nil {
+		return err
+	}
+	// Like MemoryStore, treat an expired row as missing
+	if found && since(e.Updated) > Expiry {
+		e, lockedUntil, found = Entry{}, sql.NullTime{}, false
+	}
+	e.LockedUntil = lockedUntil.Time
+
+	fn(&e, found)
+
+	lockedUntil = sql.NullTime{Time: e.LockedUntil, Valid: !e.LockedUntil.IsZero()}
+	// A concurrent first update of key waits on the primary key and then
+	// overwrites this one, which at worst lets one extra event through
+	_, err = tx.ExecContext(ctx,
+		`INSERT INTO rate_limits (key, tokens, failures, strikes, locked_until, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (key) DO UPDATE SET
+			tokens = EXCLUDED.tokens,
+			failures = EXCLUDED.failures,
+			strikes = EXCLUDED.strikes,
+			locked_until = EXCLUDED.locked_until,
+			updated_at = EXCLUDED.updated_at`,
+		key, e.Tokens, e.Failures, e.Strikes, lockedUntil, e.Updated)
+	if err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// sweep deletes expired rows in the background, at most once a minute
+func (s *PostgresStore) sweep() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if since(s.swept) < time.Minute {
+		return
+	}
+	s.swept = clock()
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+		defer cancel()
+		_, err := s.db.ExecContext(ctx, `DELETE FROM rate_limits WHERE updated_at < $1`, clock().Add(-Expiry))
+		if err != nil {
+			log.Printf("failed to delete expired rate limits: %v", err)
+		}
+	}()
+}
@@ -0,0 +1,73 @@
+// Package ratelimit limits how often clients may do something, with token
+// buckets and progressive lockouts kept in a Store.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// Expiry is how long a Store keeps an entry that is not updated
+const Expiry = 24 * time.Hour
+
+// clock tells the time to limits and stores, and is replaced in tests
+var clock = time.Now
+
+func since(t time.Time) time.Duration { return clock().Sub(t) }
+func until(t time.Time) time.Duration { return t.Sub(clock()) }
+
+// Entry is the state kept for a key
+type Entry struct {
+	// Tokens left in a token bucket
+	Tokens float64
+	// Failures since the last lockout, and Strikes, the lockouts so far
+	Failures int
+	Strikes  int
+	// LockedUntil ends the current lockout
+	LockedUntil time.Time
+	// Updated is when the entry last changed
+	Updated time.Time
+}
+
+// Store keeps entries. A shared store lets several instances enforce the
+// same limits.
+type Store interface {
+	// Get returns the entry of key, if there is one
+	Get(ctx context.Context, key string) (Entry, bool, error)
+	// Update calls fn with the entry of key, or a zero entry with found
+	// false, and saves the changes fn makes. Updates of a key are serialized.
+	Update(ctx context.Context, key string, fn func(e *Entry, found bool)) error
+}
+
+// Limit allows Events per Per on average, in bursts of up to Events
+type Limit struct {
+	Events int
+	Per    time.Duration
+}
+
+// Allow takes a token from the bucket of key. If none is left it returns
+// false and how long until one is.
+func (l Limit) Allow(ctx context.Context, s Store, key string) (bool, time.Duration, error) {
+	rate := float64(l.Events) / l.Per.Seconds()
+	var (
+		ok   bool
+		wait time.Duration
+	)
+	err := s.Update(ctx, key, func(e *Entry, found bool) {
+		now := clock()
+		if found {
+			e.Tokens = math.Min(float64(l.Events), e.Tokens+now.Sub(e.Updated).Seconds()*rate)
+		} else {
+			e.Tokens = float64(l.Events)
+		}
+		e.Updated = now
+		if e.Tokens >= 1 {
+			e.Tokens--
+			ok = true
+			return
+		}
+		wait = time.Duration((1 - e.Tokens) / rate * float64(time.Second))
+	})
+	return ok, wait, err
+}
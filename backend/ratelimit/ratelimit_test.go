@@ -0,0 +1,150 @@
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+// fakeClock replaces the package clock until the test ends
+type fakeClock struct{ t time.Time }
+
+func newFakeClock(t *testing.T) *fakeClock {
+	c := &fakeClock{t: time.Date(2026, 11, 1, 12, 0, 0, 0, time.UTC)}
+	clock = func() time.Time { return c.t }
+	t.Cleanup(func() { clock = time.Now })
+	return c
+}
+
+func (c *fakeClock) advance(d time.Duration) { c.t = c.t.Add(d) }
+
+func TestAllow(t *testing.T) {
+	// One token a second, in bursts of up to 3
+	limit := Limit{Events: 3, Per: 3 * time.Second}
+	steps := []struct {
+		name    string
+		advance time.Duration
+		ok      bool
+		wait    time.Duration
+	}{
+		{"first event", 0, true, 0},
+		{"burst", 0, true, 0},
+		{"last of the burst", 0, true, 0},
+		{"bucket empty", 0, false, time.Second},
+		{"half a token", 500 * time.Millisecond, false, 500 * time.Millisecond},
+		{"refilled one token", 500 * time.Millisecond, true, 0},
+		{"empty again", 0, false, time.Second},
+		{"refill stops at the burst size", time.Hour, true, 0},
+		{"second of the refilled burst", 0, true, 0},
+		{"third of the refilled burst", 0, true, 0},
+		{"no fourth token", 0, false, time.Second},
+	}
+
+	c := newFakeClock(t)
+	s := NewMemoryStore()
+	ctx := context.Background()
+	for _, step := range steps {
+		c.advance(step.advance)
+		ok, wait, err := limit.Allow(ctx, s, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok != step.ok || wait != step.wait {
+			t.Errorf("%s: Allow = %v, %v, want %v, %v", step.name, ok, wait, step.ok, step.wait)
+		}
+	}
+
+	// Keys have separate buckets
+	if ok, _, _ := limit.Allow(ctx, s, "other"); !ok {
+		t.Error("another key was limited")
+	}
+}
+
+func TestLockout(t *testing.T) {
+	l := Lockout{Threshold: 3, Window: time.Minute, Base: time.Minute, Max: 5 * time.Minute}
+	type op int
+	const (
+		fail op = iota
+		succeed
+	)
+	steps := []struct {
+		name    string
+		advance time.Duration
+		op      op
+		// the lockout Fail starts, and how long the key is locked after
+		started, locked time.Duration
+	}{
+		{"first failure", 0, fail, 0, 0},
+		{"second failure", 10 * time.Second, fail, 0, 0},
+		{"threshold", 10 * time.Second, fail, time.Minute, time.Minute},
+		{"lockout runs out", time.Minute, fail, 0, 0},
+		{"failures outside the window start over", 2 * time.Minute, fail, 0, 0},
+		{"second failure again", 0, fail, 0, 0},
+		{"second lockout doubles", 0, fail, 2 * time.Minute, 2 * time.Minute},
+		{"failures while locked count", time.Minute, fail, 0, time.Minute},
+		{"another failure while locked", 0, fail, 0, time.Minute},
+		{"third lockout doubles", 0, fail, 4 * time.Minute, 4 * time.Minute},
+		{"failure after the lockout", 4 * time.Minute, fail, 0, 0},
+		{"next failure", 0, fail, 0, 0},
+		{"fourth lockout is capped", 0, fail, 5 * time.Minute, 5 * time.Minute},
+		{"success clears the lockout", 0, succeed, 0, 0},
+		{"failure after success", 0, fail, 0, 0},
+		{"next failure after success", 0, fail, 0, 0},
+		{"lockouts start over after success", 0, fail, time.Minute, time.Minute},
+		{"failure after a quiet day", 25 * time.Hour, fail, 0, 0},
+		{"next failure after a quiet day", 0, fail, 0, 0},
+		{"lockouts start over after a day", 0, fail, time.Minute, time.Minute},
+	}
+
+	c := newFakeClock(t)
+	s := NewMemoryStore()
+	ctx := context.Background()
+	for _, step := range steps {
+		c.advance(step.advance)
+		var started time.Duration
+		var err error
+		if step.op == fail {
+			started, err = l.Fail(ctx, s, "key")
+		} else {
+			err = l.Succeed(ctx, s, "key")
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		locked, err := l.Locked(ctx, s, "key")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if started != step.started || locked != step.locked {
+			t.Errorf("%s: started %v, locked %v, want %v, %v", step.name, started, locked, step.started, step.locked)
+		}
+	}
+}
+
+func TestMemoryStoreExpiry(t *testing.T) {
+	c := newFakeClock(t)
+	s := NewMemoryStore()
+	ctx := context.Background()
+	if err := s.Update(ctx, "key", func(e *Entry, found bool) {
+		e.Failures = 1
+		e.Updated = clock()
+	}); err != nil {
+		t.Fatal(err)
+	}
+
+	c.advance(Expiry)
+	if e, found, _ := s.Get(ctx, "key"); !found || e.Failures != 1 {
+		t.Fatalf("Get = %+v, %v before the entry expired", e, found)
+	}
+	c.advance(time.Second)
+	if _, found, _ := s.Get(ctx, "key"); found {
+		t.Error("Get found an expired entry")
+	}
+	if err := s.Update(ctx, "key", func(e *Entry, found bool) {
+		if found || e.Failures != 0 {
+			t.Errorf("Update got %+v, %v for an expired entry", *e, found)
+		}
+	}); err != nil {
+		t.Fatal(err)
+	}
+}
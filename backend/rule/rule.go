@@ -0,0 +1,73 @@
+// Package rule holds the ent privacy rules shared by the schema.
+package rule
+
+import (
+	"context"
+
+	"poll_app/ent"
+	"poll_app/ent/poll"
+	"poll_app/ent/polloption"
+	"poll_app/ent/predicate"
+	"poll_app/ent/privacy"
+	"poll_app/ent/team"
+	"poll_app/ent/user"
+	"poll_app/ent/vote"
+	"poll_app/viewer"
+)
+
+// VisiblePolls matches the polls the viewer in ctx may open: public polls,
+// their own polls, polls they were invited to and team polls of teams they
+// belong to. Unlisted polls are only visible to their creator until a viewer
+// joins them with the poll's link key, which makes them an invitee, so they
+// cannot be found by trying poll IDs.
+func VisiblePolls(ctx context.Context) (predicate.Poll, error) {
+	v, ok := viewer.FromContext(ctx)
+	if !ok {
+		return nil, privacy.Denyf("rule: poll query without a viewer")
+	}
+	return poll.Or(
+		poll.VisibilityEQ(poll.VisibilityPublic),
+		poll.HasCreatorWith(user.ID(v.UserID)),
+		poll.HasInviteesWith(user.ID(v.UserID)),
+		poll.And(
+			poll.VisibilityEQ(poll.VisibilityTeam),
+			poll.HasTeamWith(team.HasMembersWith(user.ID(v.UserID))),
+		),
+	), nil
+}
+
+// FilterVisiblePolls limits poll queries to VisiblePolls.
+func FilterVisiblePolls() privacy.QueryRule {
+	return privacy.PollQueryRuleFunc(func(ctx context.Context, q *ent.PollQuery) error {
+		visible, err := VisiblePolls(ctx)
+		if err != nil {
+			return err
+		}
+		q.Where(visible)
+		return privacy.Skip
+	})
+}
+
+// FilterVisiblePollOptions limits option queries to options of VisiblePolls.
+func FilterVisiblePollOptions() privacy.QueryRule {
+	return privacy.PollOptionQueryRuleFunc(func(ctx context.Context, q *ent.PollOptionQuery) error {
+		visible, err := VisiblePolls(ctx)
+		if err != nil {
+			return err
+		}
+		q.Where(polloption.HasPollWith(visible))
+		return privacy.Skip
+	})
+}
+
+// FilterVisibleVotes limits vote queries to votes on VisiblePolls.
+func FilterVisibleVotes() privacy.QueryRule {
+	return privacy.VoteQueryRuleFunc(func(ctx context.Context, q *ent.VoteQuery) error {
+		visible, err := VisiblePolls(ctx)
+		if err != nil {
+			return err
+		}
+		q.Where(vote.HasOptionWith(polloption.HasPollWith(visible)))
+		return privacy.Skip
+	})
+}
@@ -0,0 +1,102 @@
+package rule_test
+
+import (
+	"context"
+	"testing"
+
+	"poll_app/ent"
+	"poll_app/ent/enttest"
+	"poll_app/ent/poll"
+	"poll_app/ent/polloption"
+	"poll_app/ent/privacy"
+	"poll_app/ent/vote"
+	"poll_app/viewer"
+
+	_ "github.com/mattn/go-sqlite3"
+)
+
+// TestVisiblePolls checks which viewers can load a poll of each visibility,
+// along with its options and votes
+func TestVisiblePolls(t *testing.T) {
+	client := enttest.Open(t, "sqlite3", "file:rule?mode=memory&cache=shared&_fk=1")
+	t.Cleanup(func() { client.Close() })
+	ctx := privacy.DecisionContext(context.Background(), privacy.Allow)
+
+	newUser := func(name string) *ent.User {
+		return client.User.Create().SetUsername(name).SetEmail(name + "@example.com").SetPassword("password").SaveX(ctx)
+	}
+	creator := newUser("creator")
+	invitee := newUser("invitee")
+	member := newUser("member")
+	stranger := newUser("stranger")
+	team := client.Team.Create().SetName("Team").SetOwner(creator).AddMembers(member).SaveX(ctx)
+
+	polls := make(map[poll.Visibility]*ent.Poll)
+	for _, v := range []poll.Visibility{poll.VisibilityPublic, poll.VisibilityUnlisted, poll.VisibilityPrivate, poll.VisibilityTeam} {
+		create := client.Poll.Create().
+			SetTitle(string(v)).
+			SetVisibility(v).
+			SetCreator(creator).
+			AddInvitees(invitee)
+		if v == poll.VisibilityTeam {
+			create.SetTeam(team)
+		}
+		p := create.SaveX(ctx)
+		opt := client.PollOption.Create().SetText("A").SetPoll(p).SaveX(ctx)
+		client.Vote.Create().SetOption(opt).SetUser(creator).SaveX(ctx)
+		polls[v] = p
+	}
+
+	viewers := map[string]*ent.User{"creator": creator, "invitee": invitee, "team member": member, "stranger": stranger}
+	tests := []struct {
+		visibility poll.Visibility
+		// visible lists the viewers who see the poll
+		visible []string
+	}{
+		{poll.VisibilityPublic, []string{"creator", "invitee", "team member", "stranger"}},
+		// Joining with the link key makes a viewer an invitee
+		{poll.VisibilityUnlisted, []string{"creator", "invitee"}},
+		{poll.VisibilityPrivate, []string{"creator", "invitee"}},
+		{poll.VisibilityTeam, []string{"creator", "invitee", "team member"}},
+	}
+	for _, tt := range tests {
+		visible := make(map[string]bool)
+		for _, name := range tt.visible {
+			visible[name] = true
+		}
+		for name, u := range viewers {
+			t.Run(string(tt.visibility)+"/"+name, func(t *testing.T) {
+				vctx := viewer.NewContext(context.Background(), viewer.Viewer{UserID: u.ID})
+				id := polls[tt.visibility].ID
+
+				_, err := client.Poll.Get(vctx, id)
+				if visible[name] && err != nil {
+					t.Fatalf("poll: %v", err)
+				}
+				if !visible[name] && !ent.IsNotFound(err) {
+					t.Fatalf("poll: got error %v, want not found", err)
+				}
+
+				want := 0
+				if visible[name] {
+					want = 1
+				}
+				options := client.PollOption.Query().Where(polloption.HasPollWith(poll.ID(id))).CountX(vctx)
+				if options != want {
+					t.Errorf("found %d options, want %d", options, want)
+				}
+				votes := client.Vote.Query().Where(vote.HasOptionWith(polloption.HasPollWith(poll.ID(id)))).CountX(vctx)
+				if votes != want {
+					t.Errorf("found %d votes, want %d", votes, want)
+				}
+			})
+		}
+	}
+
+	t.Run("no viewer", func(t *testing.T) {
+		_, err := client.Poll.Get(context.Background(), polls[poll.VisibilityPublic].ID)
+		if err == nil {
+			t.Fatal("loaded a poll without a viewer")
+		}
+	})
+}
@@ -0,0 +1,121 @@
+// Package tally computes poll results from ballots.
+package tally
+
+// Ballot is one voter's option IDs in order of preference, most preferred first.
+type Ballot []int
+
+// Round holds the state of a single instant-runoff round.
+type Round struct {
+	// Tallies maps each continuing option to its votes this round
+	Tallies map[int]int
+	// Eliminated lists the option knocked out at the end of the round, if any
+	Eliminated []int
+	// Exhausted counts ballots with no continuing option left
+	Exhausted int
+}
+
+// IRVResult is the outcome of an instant-runoff count.
+type IRVResult struct {
+	Rounds []Round
+	// Winner is 0 when there were no ballots to count
+	Winner int
+}
+
+// InstantRunoff counts ranked ballots by repeatedly eliminating the option with
+// the fewest votes until one holds a majority of the continuing ballots.
+//
+// Ties for last place are broken by looking back through earlier rounds for the
+// most recent one in which the tied options differed; if they never did, the
+// option listed last in options is eliminated. The result is therefore fully
+// determined by the input order of options.
+func InstantRunoff(options []int, ballots []Ballot) IRVResult {
+	var result IRVResult
+	if len(options) == 0 {
+		return result
+	}
+
+	continuing := make(map[int]bool, len(options))
+	for _, id := range options {
+		continuing[id] = true
+	}
+
+	for {
+		round := Round{Tallies: make(map[int]int, len(continuing))}
+		for _, id := range options {
+			if continuing[id] {
+				round.Tallies[id] = 0
+			}
+		}
+		for _, b := range ballots {
+			if top, ok := topChoice(b, continuing); ok {
+				round.Tallies[top]++
+			} else {
+				round.Exhausted++
+			}
+		}
+
+		active := len(ballots) - round.Exhausted
+		if active == 0 {
+			result.Rounds = append(result.Rounds, round)
+			return result
+		}
+
+		for _, id := range options {
+			if continuing[id] && (round.Tallies[id]*2 > active || len(continuing) == 1) {
+				result.Winner = id
+				result.Rounds = append(result.Rounds, round)
+				return result
+			}
+		}
+
+		loser := lastPlace(options, continuing, round, result.Rounds)
+		round.Eliminated = []int{loser}
+		delete(continuing, loser)
+		result.Rounds = append(result.Rounds, round)
+	}
+}
+
+func topChoice(b Ballot, continuing map[int]bool) (int, bool) {
+	for _, id := range b {
+		if continuing[id] {
+			return id, true
+		}
+	}
+	return 0, false
+}
+
+// lastPlace picks the continuing option to eliminate from the current round.
+func lastPlace(options []int, continuing map[int]bool, current Round, previous []Round) int {
+	fewest := -1
+	var tied []int
+	for _, id := range options {
+		if !continuing[id] {
+			continue
+		}
+		switch votes := current.Tallies[id]; {
+		case fewest == -1 || votes < fewest:
+			fewest = votes
+			tied = []int{id}
+		case votes == fewest:
+			tied = append(tied, id)
+		}
+	}
+
+	// Backwards tie-breaking: the option that did worst most recently loses
+	for i := len(previous) - 1; i >= 0 && len(tied) > 1; i-- {
+		fewest = -1
+		var narrowed []int
+		for _, id := range tied {
+			switch votes := previous[i].Tallies[id]; {
+			case fewest == -1 || votes < fewest:
+				fewest = votes
+				narrowed = []int{id}
+			case votes == fewest:
+				narrowed = append(narrowed, id)
+			}
+		}
+		tied = narrowed
+	}
+
+	return tied[len(tied)-1]
+}
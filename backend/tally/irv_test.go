@@ -0,0 +1,122 @@
+package tally
+
+import (
+	"reflect"
+	"testing"
+)
+
+// repeat returns n copies of b
+func repeat(n int, b Ballot) []Ballot {
+	ballots := make([]Ballot, n)
+	for i := range ballots {
+		ballots[i] = b
+	}
+	return ballots
+}
+
+func concat(groups ...[]Ballot) []Ballot {
+	var ballots []Ballot
+	for _, g := range groups {
+		ballots = append(ballots, g...)
+	}
+	return ballots
+}
+
+func TestInstantRunoff(t *testing.T) {
+	tests := []struct {
+		name    string
+		options []int
+		ballots []Ballot
+		winner  int
+		// eliminated lists each round's eliminated options
+		eliminated [][]int
+		// exhausted lists each round's exhausted ballots
+		exhausted []int
+	}{
+		{
+			name:       "majority in the first round",
+			options:    []int{1, 2, 3},
+			ballots:    concat(repeat(3, Ballot{1}), repeat(1, Ballot{2}), repeat(1, Ballot{3})),
+			winner:     1,
+			eliminated: [][]int{nil},
+			exhausted:  []int{0},
+		},
+		{
+			name:       "transfers decide the winner",
+			options:    []int{1, 2, 3},
+			ballots:    concat(repeat(4, Ballot{1}), repeat(3, Ballot{2}), repeat(2, Ballot{3, 2})),
+			winner:     2,
+			eliminated: [][]int{{3}, nil},
+			exhausted:  []int{0, 0},
+		},
+		{
+			// The ballot ranking only 3 drops out, so 3 of the 5 ballots
+			// left are a majority
+			name:       "exhausted ballots leave the count",
+			options:    []int{1, 2, 3},
+			ballots:    concat(repeat(3, Ballot{1, 2}), repeat(2, Ballot{2}), repeat(1, Ballot{3})),
+			winner:     1,
+			eliminated: [][]int{{3}, nil},
+			exhausted:  []int{0, 1},
+		},
+		{
+			// 2 and 3 tie for last in round 2; 3 had fewer votes in round 1
+			name:    "elimination tie broken by earlier rounds",
+			options: []int{1, 2, 3, 4},
+			ballots: concat(
+				repeat(5, Ballot{1}),
+				repeat(3, Ballot{2}),
+				repeat(2, Ballot{3, 1}),
+				repeat(1, Ballot{4, 3, 1}),
+			),
+			winner:     1,
+			eliminated: [][]int{{4}, {3}, nil},
+			exhausted:  []int{0, 0, 0},
+		},
+		{
+			// 2 and 3 never differ, so the one listed last goes
+			name:       "elimination tie broken by option order",
+			options:    []int{1, 2, 3},
+			ballots:    []Ballot{{1}, {1}, {2}, {3}},
+			winner:     1,
+			eliminated: [][]int{{3}, nil},
+			exhausted:  []int{0, 1},
+		},
+		{
+			name:       "full tie",
+			options:    []int{1, 2},
+			ballots:    []Ballot{{1}, {2}},
+			winner:     1,
+			eliminated: [][]int{{2}, nil},
+			exhausted:  []int{0, 1},
+		},
+		{
+			name:       "no ballots",
+			options:    []int{1, 2},
+			winner:     0,
+			eliminated: [][]int{nil},
+			exhausted:  []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := InstantRunoff(tt.options, tt.ballots)
+			if got.Winner != tt.winner {
+				t.Errorf("Winner = %d, want %d", got.Winner, tt.winner)
+			}
+			var eliminated [][]int
+			var exhausted []int
+			for _, r := range got.Rounds {
+				eliminated = append(eliminated, r.Eliminated)
+				exhausted = append(exhausted, r.Exhausted)
+			}
+			if !reflect.DeepEqual(eliminated, tt.eliminated) {
+				t.Errorf("eliminated = %v, want %v", eliminated, tt.eliminated)
+			}
+			if !reflect.DeepEqual(exhausted, tt.exhausted) {
+				t.Errorf("exhausted = %v, want %v", exhausted, tt.exhausted)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package tally
+
+// SchulzeResult is the outcome of a Schulze count. Matrices are indexed in
+// the order of Options.
+type SchulzeResult struct {
+	Options []int
+	// Preferences[i][j] is the number of voters who ranked Options[i] above Options[j]
+	Preferences [][]int
+	// Strengths[i][j] is the strength of the strongest path from Options[i] to Options[j]
+	Strengths [][]int
+	// Ranking groups options into tiers, best first; options sharing a tier are tied
+	Ranking [][]int
+	// CondorcetWinner beats every other option head to head, or is 0 if there is none
+	CondorcetWinner int
+}
+
+// Schulze counts ranked ballots with the Schulze method. Options a ballot
+// leaves unranked are treated as tied below every option it does rank.
+func Schulze(options []int, ballots []Ballot) SchulzeResult {
+	n := len(options)
+	d := PairwisePreferences(options, ballots)
+
+	// Strongest paths, using winning votes as the link strength
+	p := newMatrix(n)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i != j && d[i][j] > d[j][i] {
+				p[i][j] = d[i][j]
+			}
+		}
+	}
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			if i == j {
+				continue
+			}
+			for k := 0; k < n; k++ {
+				if k != i && k != j {
+					p[j][k] = max(p[j][k], min(p[j][i], p[i][k]))
+				}
+			}
+		}
+	}
+
+	result := SchulzeResult{
+		Options:     options,
+		Preferences: d,
+		Strengths:   p,
+	}
+
+	// The Schulze relation is transitive, so peel off unbeaten options tier by tier
+	remaining := make([]bool, n)
+	for i := range remaining {
+		remaining[i] = true
+	}
+	for left := n; left > 0; {
+		var tier []int
+		for i := 0; i < n; i++ {
+			if !remaining[i] {
+				continue
+			}
+			beaten := false
+			for j := 0; j < n; j++ {
+				if remaining[j] && j != i && p[j][i] > p[i][j] {
+					beaten = true
+					break
+				}
+			}
+			if !beaten {
+				tier = append(tier, i)
+			}
+		}
+		ids := make([]int, len(tier))
+		for t, i := range tier {
+			ids[t] = options[i]
+			remaining[i] = false
+		}
+		left -= len(tier)
+		result.Ranking = append(result.Ranking, ids)
+	}
+
+	for i := 0; i < n; i++ {
+		wins := 0
+		for j := 0; j < n; j++ {
+			if i != j && d[i][j] > d[j][i] {
+				wins++
+			}
+		}
+		if wins == n-1 {
+			result.CondorcetWinner = options[i]
+			break
+		}
+	}
+
+	return result
+}
+
+// PairwisePreferences builds the matrix d where d[i][j] counts the ballots
+// ranking options[i] above options[j].
+func PairwisePreferences(options []int, ballots []Ballot) [][]int {
+	n := len(options)
+	index := make(map[int]int, n)
+	for i, id := range options {
+		index[id] = i
+	}
+
+	d := newMatrix(n)
+	for _, b := range ballots {
+		// Unranked options share the position after the last ranked one
+		pos := make([]int, n)
+		for i := range pos {
+			pos[i] = len(b)
+		}
+		for rank, id := range b {
+			if i, ok := index[id]; ok && pos[i] == len(b) {
+				pos[i] = rank
+			}
+		}
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if pos[i] < pos[j] {
+					d[i][j]++
+				}
+			}
+		}
+	}
+	return d
+}
+
+func newMatrix(n int) [][]int {
+	m := make([][]int, n)
+	for i := range m {
+		m[i] = make([]int, n)
+	}
+	return m
+}
@@ -0,0 +1,82 @@
+package tally
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSchulze(t *testing.T) {
+	tests := []struct {
+		name            string
+		options         []int
+		ballots         []Ballot
+		ranking         [][]int
+		condorcetWinner int
+	}{
+		{
+			name:            "condorcet winner",
+			options:         []int{1, 2, 3},
+			ballots:         concat(repeat(3, Ballot{1, 2, 3}), repeat(2, Ballot{2, 1, 3}), repeat(2, Ballot{3, 1, 2})),
+			ranking:         [][]int{{1}, {2}, {3}},
+			condorcetWinner: 1,
+		},
+		{
+			// 1 beats 2 6-3, 2 beats 3 7-2 and 3 beats 1 5-4; the weakest
+			// link, 3 over 1, is overruled by the path 1 > 2 > 3
+			name:    "condorcet cycle",
+			options: []int{1, 2, 3},
+			ballots: concat(repeat(4, Ballot{1, 2, 3}), repeat(3, Ballot{2, 3, 1}), repeat(2, Ballot{3, 1, 2})),
+			ranking: [][]int{{1}, {2}, {3}},
+		},
+		{
+			name:    "symmetric cycle is a full tie",
+			options: []int{1, 2, 3},
+			ballots: []Ballot{{1, 2, 3}, {2, 3, 1}, {3, 1, 2}},
+			ranking: [][]int{{1, 2, 3}},
+		},
+		{
+			name:    "no ballots is a full tie",
+			options: []int{1, 2, 3},
+			ranking: [][]int{{1, 2, 3}},
+		},
+		{
+			name:            "unranked options tie below ranked ones",
+			options:         []int{1, 2, 3},
+			ballots:         []Ballot{{2}},
+			ranking:         [][]int{{2}, {1, 3}},
+			condorcetWinner: 2,
+		},
+		{
+			name:    "tie for second place",
+			options: []int{1, 2, 3},
+			ballots: []Ballot{{1, 2, 3}, {1, 3, 2}},
+			ranking: [][]int{{1}, {2, 3}},
+			// 1 beats both others 2-0
+			condorcetWinner: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Schulze(tt.options, tt.ballots)
+			if !reflect.DeepEqual(got.Ranking, tt.ranking) {
+				t.Errorf("Ranking = %v, want %v", got.Ranking, tt.ranking)
+			}
+			if got.CondorcetWinner != tt.condorcetWinner {
+				t.Errorf("CondorcetWinner = %d, want %d", got.CondorcetWinner, tt.condorcetWinner)
+			}
+		})
+	}
+}
+
+func TestPairwisePreferences(t *testing.T) {
+	got := PairwisePreferences([]int{1, 2, 3}, []Ballot{{1, 2}, {3}, {2, 1, 3}})
+	want := [][]int{
+		{0, 1, 2},
+		{1, 0, 2},
+		{1, 1, 0},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("PairwisePreferences = %v, want %v", got, want)
+	}
+}
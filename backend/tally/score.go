@@ -0,0 +1,45 @@
+package tally
+
+// ScoreSummary describes the ratings one option received on a score ballot.
+type ScoreSummary struct {
+	Count   int
+	Average float64
+	Median  float64
+	// Histogram[i] counts ratings equal to minScore+i
+	Histogram []int
+}
+
+// SummarizeScoreCounts computes the average, median and distribution of
+// ratings within [minScore, maxScore], given as counts by value as a GROUP BY
+// query returns them. Ratings outside the range are ignored.
+func SummarizeScoreCounts(counts map[int]int, minScore, maxScore int) ScoreSummary {
+	summary := ScoreSummary{Histogram: make([]int, maxScore-minScore+1)}
+
+	total := 0
+	for s := minScore; s <= maxScore; s++ {
+		summary.Histogram[s-minScore] = counts[s]
+		summary.Count += counts[s]
+		total += s * counts[s]
+	}
+	if summary.Count == 0 {
+		return summary
+	}
+
+	summary.Average = float64(total) / float64(summary.Count)
+	// The median is the mean of the ratings at positions lo and hi, 0-based in sorted order
+	lo, hi := (summary.Count-1)/2, summary.Count/2
+	seen := 0
+	loScore, hiScore := 0, 0
+	for i, n := range summary.Histogram {
+		if seen <= lo && lo < seen+n {
+			loScore = minScore + i
+		}
+		if seen <= hi && hi < seen+n {
+			hiScore = minScore + i
+			break
+		}
+		seen += n
+	}
+	summary.Median = float64(loScore+hiScore) / 2
+	return summary
+}
@@ -0,0 +1,75 @@
+package tally
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSummarizeScoreCounts(t *testing.T) {
+	tests := []struct {
+		name     string
+		scores   []int
+		min, max int
+		want     ScoreSummary
+	}{
+		{
+			name: "no ballots",
+			min:  0, max: 5,
+			want: ScoreSummary{Histogram: []int{0, 0, 0, 0, 0, 0}},
+		},
+		{
+			name:   "one ballot",
+			scores: []int{3},
+			min:    0, max: 5,
+			want: ScoreSummary{Count: 1, Average: 3, Median: 3, Histogram: []int{0, 0, 0, 1, 0, 0}},
+		},
+		{
+			name:   "odd count",
+			scores: []int{1, 5, 2, 2, 4},
+			min:    1, max: 5,
+			want: ScoreSummary{Count: 5, Average: 2.8, Median: 2, Histogram: []int{1, 2, 0, 1, 1}},
+		},
+		{
+			name:   "even count averages the middle ratings",
+			scores: []int{1, 4, 2, 5},
+			min:    1, max: 5,
+			want: ScoreSummary{Count: 4, Average: 3, Median: 3, Histogram: []int{1, 1, 0, 1, 1}},
+		},
+		{
+			name:   "even count with equal middle ratings",
+			scores: []int{2, 3, 3, 4},
+			min:    0, max: 5,
+			want: ScoreSummary{Count: 4, Average: 3, Median: 3, Histogram: []int{0, 0, 1, 2, 1, 0}},
+		},
+		{
+			name:   "ratings at the range bounds",
+			scores: []int{0, 0, 10},
+			min:    0, max: 10,
+			want: ScoreSummary{Count: 3, Average: 10.0 / 3, Median: 0, Histogram: []int{2, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1}},
+		},
+		{
+			name:   "even count split across the bounds",
+			scores: []int{1, 1, 3, 3},
+			min:    1, max: 3,
+			want: ScoreSummary{Count: 4, Average: 2, Median: 2, Histogram: []int{2, 0, 2}},
+		},
+		{
+			name:   "ratings outside the range are ignored",
+			scores: []int{0, 2, 6, 4},
+			min:    1, max: 5,
+			want: ScoreSummary{Count: 2, Average: 3, Median: 3, Histogram: []int{0, 1, 0, 1, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counts := make(map[int]int)
+			for _, s := range tt.scores {
+				counts[s]++
+			}
+			got := SummarizeScoreCounts(counts, tt.min, tt.max)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SummarizeScoreCounts(%v, %d, %d) = %+v, want %+v", counts, tt.min, tt.max, got, tt.want)
+			}
+		})
+	}
+}
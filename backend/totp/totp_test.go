@@ -0,0 +1,79 @@
+package totp
+
+import (
+	"testing"
+	"time"
+)
+
+// rfcSecret is the SHA-1 seed of RFC 6238 Appendix B, "12345678901234567890"
+const rfcSecret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestCode(t *testing.T) {
+	// The SHA-1 vectors of RFC 6238 Appendix B, which has eight digits; the
+	// six digit code is their last six
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+	for _, tt := range tests {
+		got, err := Code(rfcSecret, Step(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Code at %d = %s, want %s", tt.unix, got, tt.want)
+		}
+	}
+
+	// Secrets are accepted in lower case, as some apps show them
+	if got, err := Code("gezdgnbvgy3tqojqgezdgnbvgy3tqojq", 1); err != nil || got != "287082" {
+		t.Errorf("lower case secret: Code = %q, %v", got, err)
+	}
+	if _, err := Code("not base32!", 1); err == nil {
+		t.Error("accepted an invalid secret")
+	}
+}
+
+func TestValidate(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	step := Step(now)
+	code := func(step int64) string {
+		c, err := Code(rfcSecret, step)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		code   string
+		want   int64
+		wantOK bool
+	}{
+		{"current step", code(step), step, true},
+		{"previous step", code(step - 1), step - 1, true},
+		{"next step", code(step + 1), step + 1, true},
+		{"two steps ago", code(step - 2), 0, false},
+		{"two steps ahead", code(step + 2), 0, false},
+		{"spaces are ignored", code(step)[:3] + " " + code(step)[3:], step, true},
+		{"too short", code(step)[:5], 0, false},
+		{"too long", code(step) + "0", 0, false},
+		{"empty", "", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Validate(rfcSecret, tt.code, now)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Validate(%q) = %d, %v, want %d, %v", tt.code, got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
@@ -0,0 +1,23 @@
+// Package viewer carries the identity of the user making a request so that
+// ent privacy rules can decide what they may see.
+package viewer
+
+import "context"
+
+// Viewer describes the user a query runs on behalf of.
+type Viewer struct {
+	UserID int
+}
+
+type ctxKey struct{}
+
+// NewContext returns a copy of parent that carries v.
+func NewContext(parent context.Context, v Viewer) context.Context {
+	return context.WithValue(parent, ctxKey{}, v)
+}
+
+// FromContext returns the viewer stored in ctx, if any.
+func FromContext(ctx context.Context) (Viewer, bool) {
+	v, ok := ctx.Value(ctxKey{}).(Viewer)
+	return v, ok
+}
@@ -2,11 +2,19 @@ import { BrowserRouter, Routes, Route, Navigate } from 'react-router-dom';
 import { AuthProvider, useAuth } from './context/AuthContext';
 import Login from './pages/Login';
 import SignUp from './pages/SignUp';
+import ForgotPassword from './pages/ForgotPassword';
+import ResetPassword from './pages/ResetPassword';
 import Polls from './pages/Polls';
 import CreatePoll from './pages/CreatePoll';
 import EditPoll from './pages/EditPoll';
 import PollDetail from './pages/PollDetail';
+import Sessions from './pages/Sessions';
+import Profile from './pages/Profile';
+import VerifyEmail from './pages/VerifyEmail';
+import OidcCallback from './pages/OidcCallback';
+import MagicLink from './pages/MagicLink';
 import Navbar from './components/Navbar';
+import VerifyEmailBanner from './components/VerifyEmailBanner';
 
 function PrivateRoute({ children }: { children: React.ReactNode }) {
   const { user, loading } = useAuth();
@@ -33,10 +41,17 @@ function AppRoutes() {
     <Routes>
       <Route path="/login" element={<PublicRoute><Login /></PublicRoute>} />
       <Route path="/signup" element={<PublicRoute><SignUp /></PublicRoute>} />
+      <Route path="/forgot-password" element={<PublicRoute><ForgotPassword /></PublicRoute>} />
+      <Route path="/reset-password" element={<PublicRoute><ResetPassword /></PublicRoute>} />
+      <Route path="/verify-email" element={<VerifyEmail />} />
+      <Route path="/magic-link" element={<PublicRoute><MagicLink /></PublicRoute>} />
+      <Route path="/oidc/callback" element={<PublicRoute><OidcCallback /></PublicRoute>} />
       <Route path="/" element={<PrivateRoute><Polls /></PrivateRoute>} />
       <Route path="/polls/new" element={<PrivateRoute><CreatePoll /></PrivateRoute>} />
       <Route path="/polls/:id" element={<PrivateRoute><PollDetail /></PrivateRoute>} />
       <Route path="/polls/:id/edit" element={<PrivateRoute><EditPoll /></PrivateRoute>} />
+      <Route path="/sessions" element={<PrivateRoute><Sessions /></PrivateRoute>} />
+      <Route path="/profile" element={<PrivateRoute><Profile /></PrivateRoute>} />
     </Routes>
   );
 }
@@ -48,6 +63,7 @@ function App() {
         <div className="app">
           <Navbar />
           <main className="main-content">
+            <VerifyEmailBanner />
             <AppRoutes />
           </main>
         </div>
@@ -1,8 +1,8 @@
 import { useState, useEffect, useCallback } from 'react';
 import { Link } from 'react-router-dom';
 import { useAuth } from '../context/AuthContext';
-import { notificationAPI } from '../services/api';
-import { Notification } from '../types';
+import { freshToken, notificationAPI } from '../services/api';
+import { Notification, NotificationMessage } from '../types';
 
 function Navbar() {
   const { user, logout } = useAuth();
@@ -25,19 +25,57 @@ function Navbar() {
   }, [user]);
 
   useEffect(() => {
-    fetchNotifications();
-    // Poll for notifications every 10 seconds
-    const interval = setInterval(fetchNotifications, 10000);
-    return () => clearInterval(interval);
-  }, [fetchNotifications]);
+    if (!user) return;
+    let socket: WebSocket | null = null;
+    let retry: ReturnType<typeof setTimeout> | undefined;
+    let closed = false;
+
+    // Notifications and read state from other tabs are pushed over the socket.
+    // Each (re)connect starts with a sync message, after which the list is refetched.
+    const connect = async () => {
+      // The token in the URL must not have expired
+      await freshToken();
+      if (closed) return;
+      socket = new WebSocket(notificationAPI.socketURL());
+      socket.onmessage = (event) => {
+        const msg: NotificationMessage = JSON.parse(event.data);
+        setUnreadCount(msg.unread_count);
+        switch (msg.type) {
+          case 'sync':
+            fetchNotifications();
+            break;
+          case 'notification':
+            setNotifications(prev => [msg.notification!, ...prev]);
+            break;
+          case 'read':
+            setNotifications(prev => prev.map(n =>
+              msg.ids?.includes(n.id) ? { ...n, read: true } : n
+            ));
+            break;
+          case 'read_all':
+            setNotifications(prev => prev.map(n => ({ ...n, read: true })));
+            break;
+        }
+      };
+      socket.onclose = () => {
+        if (!closed) retry = setTimeout(connect, 5000);
+      };
+    };
+    connect();
+
+    return () => {
+      closed = true;
+      clearTimeout(retry);
+      socket?.close();
+    };
+  }, [user, fetchNotifications]);
 
   const handleMarkAsRead = async (id: number) => {
     try {
       await notificationAPI.markAsRead(id);
-      setNotifications(notifications.map(n => 
+      setNotifications(prev => prev.map(n =>
         n.id === id ? { ...n, read: true } : n
       ));
-      setUnreadCount(prev => Math.max(0, prev - 1));
     } catch {
       // Ignore errors
     }
@@ -46,7 +84,7 @@ function Navbar() {
   const handleMarkAllAsRead = async () => {
     try {
       await notificationAPI.markAllAsRead();
-      setNotifications(notifications.map(n => ({ ...n, read: true })));
+      setNotifications(prev => prev.map(n => ({ ...n, read: true })));
       setUnreadCount(0);
     } catch {
       // Ignore errors
@@ -126,6 +164,12 @@ function Navbar() {
             <button onClick={logout} className="btn btn-outline">
               Logout
             </button>
+            <Link to="/profile" className="btn btn-outline">
+              Profile
+            </Link>
+            <Link to="/sessions" className="btn btn-outline">
+              Sessions
+            </Link>
           </>
         ) : (
           <>
@@ -0,0 +1,123 @@
+import { useState, useEffect } from 'react';
+import { useAuth } from '../context/AuthContext';
+import { authAPI } from '../services/api';
+import { createPasskey, passkeysSupported } from '../services/webauthn';
+import { Passkey } from '../types';
+
+function PasskeySettings() {
+  const { user } = useAuth();
+  const [passkeys, setPasskeys] = useState<Passkey[]>([]);
+  const [name, setName] = useState('');
+  const [password, setPassword] = useState('');
+  const [error, setError] = useState('');
+  const [busy, setBusy] = useState(false);
+
+  useEffect(() => {
+    authAPI.passkeys()
+      .then((response) => setPasskeys(response.data))
+      .catch(() => setError('Failed to fetch passkeys'));
+  }, []);
+
+  // Accounts that only use SSO or passkeys confirm with a current code instead
+  const hasPassword = user?.has_password !== false;
+  const confirmWith = (secret: string) => (hasPassword ? { password: secret } : { code: secret });
+
+  const handleAdd = async () => {
+    setError('');
+    setBusy(true);
+    try {
+      const begin = await authAPI.beginPasskeyRegistration(confirmWith(password));
+      const credential = await createPasskey(begin.data.options);
+      // A code works once, so finishing takes the next one
+      const secret = hasPassword ? password : window.prompt('Enter the next code from your authenticator app');
+      if (secret === null) return;
+      const response = await authAPI.finishPasskeyRegistration({
+        ceremony_token: begin.data.ceremony_token,
+        name: name || 'Passkey',
+        credential,
+        ...confirmWith(secret),
+      });
+      setPasskeys([...passkeys, response.data]);
+      setName('');
+      setPassword('');
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } }; name?: string };
+      if (error.name !== 'NotAllowedError') {
+        setError(error.response?.data?.error || 'Failed to add passkey');
+      }
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const handleDelete = async (id: number) => {
+    if (!confirm('Remove this passkey? You will no longer be able to sign in with it.')) return;
+    try {
+      await authAPI.deletePasskey(id, confirmWith(password));
+      setPasskeys(passkeys.filter((p) => p.id !== id));
+      setPassword('');
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setError(error.response?.data?.error || 'Failed to remove passkey');
+    }
+  };
+
+  return (
+    <div className="form-container">
+      <h1 className="form-title">Passkeys</h1>
+      <p className="form-subtitle">Sign in with your fingerprint, face or device PIN instead of a password</p>
+      {error && <div className="alert alert-error">{error}</div>}
+
+      {passkeys.map((p) => (
+        <div key={p.id} className="passkey-item">
+          <div>
+            <strong>{p.name}</strong>
+            <div className="poll-card-meta">
+              Added {new Date(p.created_at).toLocaleDateString()}
+              {p.last_used_at && ` • Last used ${new Date(p.last_used_at).toLocaleString()}`}
+              {p.backed_up && ' • Synced'}
+            </div>
+          </div>
+          <button className="btn btn-outline" onClick={() => handleDelete(p.id)} disabled={!password}>
+            Remove
+          </button>
+        </div>
+      ))}
+
+      <div className="form-group">
+        <label htmlFor="passkeyPassword">{hasPassword ? 'Password' : 'Code from your authenticator app'}</label>
+        <input
+          type={hasPassword ? 'password' : 'text'}
+          id="passkeyPassword"
+          value={password}
+          onChange={(e) => setPassword(e.target.value)}
+          placeholder="Required to add or remove passkeys"
+          autoComplete={hasPassword ? 'current-password' : 'one-time-code'}
+          inputMode={hasPassword ? undefined : 'numeric'}
+        />
+      </div>
+
+      {passkeysSupported() ? (
+        <>
+          <div className="form-group">
+            <label htmlFor="passkeyName">Name</label>
+            <input
+              type="text"
+              id="passkeyName"
+              value={name}
+              onChange={(e) => setName(e.target.value)}
+              placeholder="e.g. Work laptop"
+            />
+          </div>
+          <button className="btn btn-primary" style={{ width: '100%' }} onClick={handleAdd} disabled={busy || !password}>
+            {busy ? 'Waiting for your device...' : 'Add a passkey'}
+          </button>
+        </>
+      ) : (
+        <p>This browser does not support passkeys.</p>
+      )}
+    </div>
+  );
+}
+
+export default PasskeySettings;
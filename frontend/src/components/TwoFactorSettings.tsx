@@ -0,0 +1,164 @@
+import { useState, FormEvent } from 'react';
+import { useAuth } from '../context/AuthContext';
+import { authAPI } from '../services/api';
+import { TwoFactorSetup } from '../types';
+
+function errorMessage(err: unknown, fallback: string) {
+  const error = err as { response?: { data?: { error?: string } } };
+  return error.response?.data?.error || fallback;
+}
+
+function TwoFactorSettings() {
+  const { user, updateUser } = useAuth();
+  const [setup, setSetup] = useState<TwoFactorSetup | null>(null);
+  const [code, setCode] = useState('');
+  const [password, setPassword] = useState('');
+  const [recoveryCodes, setRecoveryCodes] = useState<string[]>([]);
+  const [error, setError] = useState('');
+  const [busy, setBusy] = useState(false);
+
+  if (!user) return null;
+
+  // Accounts that only use SSO or passkeys confirm with a current code instead
+  const hasPassword = user.has_password !== false;
+  const confirm = hasPassword ? { password } : { code: password };
+
+  const run = async (action: () => Promise<void>, fallback: string) => {
+    setError('');
+    setBusy(true);
+    try {
+      await action();
+    } catch (err: unknown) {
+      setError(errorMessage(err, fallback));
+    } finally {
+      setBusy(false);
+    }
+  };
+
+  const handleSetup = () => run(async () => {
+    const response = await authAPI.setupTwoFactor(password);
+    setSetup(response.data);
+    setRecoveryCodes([]);
+  }, 'Failed to start setup');
+
+  const handleEnable = (e: FormEvent) => {
+    e.preventDefault();
+    run(async () => {
+      const response = await authAPI.enableTwoFactor(code, password);
+      setRecoveryCodes(response.data.recovery_codes);
+      setSetup(null);
+      setCode('');
+      setPassword('');
+      updateUser({ ...user, two_factor_enabled: true });
+    }, 'Failed to enable two-factor authentication');
+  };
+
+  const handleRegenerate = () => run(async () => {
+    const response = await authAPI.regenerateRecoveryCodes(confirm);
+    setRecoveryCodes(response.data.recovery_codes);
+    setPassword('');
+  }, 'Failed to generate recovery codes');
+
+  const handleDisable = () => run(async () => {
+    await authAPI.disableTwoFactor(confirm);
+    setPassword('');
+    setRecoveryCodes([]);
+    updateUser({ ...user, two_factor_enabled: false });
+  }, 'Failed to disable two-factor authentication');
+
+  return (
+    <div className="form-container">
+      <h1 className="form-title">Two-Factor Login</h1>
+      <p className="form-subtitle">
+        {user.two_factor_enabled
+          ? 'On: logging in asks for a code from your authenticator app'
+          : 'Off: add a code from an authenticator app to your password'}
+      </p>
+      {error && <div className="alert alert-error">{error}</div>}
+
+      {recoveryCodes.length > 0 && (
+        <div className="alert alert-warning recovery-codes">
+          <span>Save these recovery codes somewhere safe. Each works once if you lose your authenticator, and they will not be shown again.</span>
+          <ul>
+            {recoveryCodes.map((c) => <li key={c}>{c}</li>)}
+          </ul>
+        </div>
+      )}
+
+      {!user.two_factor_enabled && !setup && !hasPassword && (
+        <p>Set a password to turn on two-factor login.</p>
+      )}
+
+      {!user.two_factor_enabled && !setup && hasPassword && (
+        <>
+          <div className="form-group">
+            <label htmlFor="setupPassword">Password</label>
+            <input
+              type="password"
+              id="setupPassword"
+              value={password}
+              onChange={(e) => setPassword(e.target.value)}
+              placeholder="Required to set up two-factor login"
+              autoComplete="current-password"
+            />
+          </div>
+          <button className="btn btn-primary" style={{ width: '100%' }} onClick={handleSetup} disabled={busy || !password}>
+            Set up two-factor login
+          </button>
+        </>
+      )}
+
+      {!user.two_factor_enabled && setup && (
+        <form onSubmit={handleEnable}>
+          <p>
+            Add this key to your authenticator app, or open the <a href={setup.otpauth_uri}>setup link</a> on your phone:
+          </p>
+          <p className="totp-secret">{setup.secret}</p>
+          <div className="form-group">
+            <label htmlFor="totpCode">Code from the app</label>
+            <input
+              type="text"
+              id="totpCode"
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              required
+              autoComplete="one-time-code"
+              inputMode="numeric"
+              placeholder="123456"
+            />
+          </div>
+          <button type="submit" className="btn btn-primary" style={{ width: '100%' }} disabled={busy}>
+            {busy ? 'Verifying...' : 'Turn on'}
+          </button>
+        </form>
+      )}
+
+      {user.two_factor_enabled && (
+        <>
+          <div className="form-group">
+            <label htmlFor="twoFactorPassword">{hasPassword ? 'Password' : 'Code from the app'}</label>
+            <input
+              type={hasPassword ? 'password' : 'text'}
+              id="twoFactorPassword"
+              value={password}
+              onChange={(e) => setPassword(e.target.value)}
+              placeholder="Required to change two-factor settings"
+              autoComplete={hasPassword ? 'current-password' : 'one-time-code'}
+              inputMode={hasPassword ? undefined : 'numeric'}
+            />
+          </div>
+          <div className="form-actions">
+            <button className="btn btn-outline" onClick={handleRegenerate} disabled={busy || !password}>
+              New recovery codes
+            </button>
+            <button className="btn btn-danger" onClick={handleDisable} disabled={busy || !password}>
+              Turn off
+            </button>
+          </div>
+        </>
+      )}
+    </div>
+  );
+}
+
+export default TwoFactorSettings;
@@ -0,0 +1,37 @@
+import { useState } from 'react';
+import { useAuth } from '../context/AuthContext';
+import { authAPI } from '../services/api';
+
+function VerifyEmailBanner() {
+  const { user } = useAuth();
+  const [message, setMessage] = useState('');
+  const [sending, setSending] = useState(false);
+
+  if (!user || user.email_verified !== false) {
+    return null;
+  }
+
+  const handleResend = async () => {
+    setSending(true);
+    try {
+      await authAPI.resendVerification();
+      setMessage(`We sent a new link to ${user.email}.`);
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setMessage(error.response?.data?.error || 'Failed to send verification email');
+    } finally {
+      setSending(false);
+    }
+  };
+
+  return (
+    <div className="alert alert-warning verify-banner">
+      <span>{message || `Please confirm your email address (${user.email}) using the link we sent you.`}</span>
+      <button className="btn btn-outline" onClick={handleResend} disabled={sending}>
+        {sending ? 'Sending...' : 'Resend email'}
+      </button>
+    </div>
+  );
+}
+
+export default VerifyEmailBanner;
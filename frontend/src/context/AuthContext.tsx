@@ -1,13 +1,22 @@
 import { createContext, useContext, useState, useEffect, ReactNode } from 'react';
-import { User, AuthResponse } from '../types';
-import { authAPI } from '../services/api';
+import { User, AuthResponse, TwoFactorChallenge } from '../types';
+import { authAPI, clearSession, saveSession } from '../services/api';
+import { getPasskey } from '../services/webauthn';
 
 interface AuthContextType {
   user: User | null;
   loading: boolean;
-  login: (email: string, password: string) => Promise<void>;
+  // login resolves to a challenge token when a second factor is needed
+  login: (email: string, password: string) => Promise<string | null>;
+  loginTwoFactor: (challengeToken: string, factor: { code?: string; recovery_code?: string }) => Promise<void>;
+  loginWithPasskey: () => Promise<void>;
+  // loginWithOidc exchanges the code from single sign-on, like login
+  loginWithOidc: (code: string) => Promise<string | null>;
+  loginWithMagicLink: (token: string) => Promise<string | null>;
   signUp: (username: string, email: string, password: string) => Promise<void>;
   logout: () => void;
+  logoutAll: () => Promise<void>;
+  updateUser: (user: User) => void;
 }
 
 const AuthContext = createContext<AuthContextType | undefined>(undefined);
@@ -30,8 +39,7 @@ export function AuthProvider({ children }: { children: ReactNode }) {
           localStorage.setItem('user', JSON.stringify(response.data));
         })
         .catch(() => {
-          localStorage.removeItem('token');
-          localStorage.removeItem('user');
+          clearSession();
           setUser(null);
         })
         .finally(() => setLoading(false));
@@ -42,28 +50,83 @@ export function AuthProvider({ children }: { children: ReactNode }) {
 
   const login = async (email: string, password: string) => {
     const response = await authAPI.login({ email, password });
+    if ((response.data as TwoFactorChallenge).two_factor_required) {
+      return (response.data as TwoFactorChallenge).challenge_token;
+    }
     const data: AuthResponse = response.data;
-    localStorage.setItem('token', data.token);
-    localStorage.setItem('user', JSON.stringify(data.user));
+    saveSession(data);
     setUser(data.user);
+    return null;
+  };
+
+  const loginTwoFactor = async (challengeToken: string, factor: { code?: string; recovery_code?: string }) => {
+    const response = await authAPI.loginTwoFactor({ challenge_token: challengeToken, ...factor });
+    const data: AuthResponse = response.data;
+    saveSession(data);
+    setUser(data.user);
+  };
+
+  const loginWithPasskey = async () => {
+    const begin = await authAPI.beginPasskeyLogin();
+    const credential = await getPasskey(begin.data.options);
+    const response = await authAPI.finishPasskeyLogin({ ceremony_token: begin.data.ceremony_token, credential });
+    const data: AuthResponse = response.data;
+    saveSession(data);
+    setUser(data.user);
+  };
+
+  const loginWithOidc = async (code: string) => {
+    const response = await authAPI.oidcExchange(code);
+    if ((response.data as TwoFactorChallenge).two_factor_required) {
+      return (response.data as TwoFactorChallenge).challenge_token;
+    }
+    const data: AuthResponse = response.data;
+    saveSession(data);
+    setUser(data.user);
+    return null;
+  };
+
+  const loginWithMagicLink = async (token: string) => {
+    const response = await authAPI.magicLinkLogin(token);
+    if ((response.data as TwoFactorChallenge).two_factor_required) {
+      return (response.data as TwoFactorChallenge).challenge_token;
+    }
+    const data: AuthResponse = response.data;
+    saveSession(data);
+    setUser(data.user);
+    return null;
   };
 
   const signUp = async (username: string, email: string, password: string) => {
     const response = await authAPI.signUp({ username, email, password });
     const data: AuthResponse = response.data;
-    localStorage.setItem('token', data.token);
-    localStorage.setItem('user', JSON.stringify(data.user));
+    saveSession(data);
     setUser(data.user);
   };
 
   const logout = () => {
-    localStorage.removeItem('token');
-    localStorage.removeItem('user');
+    const refreshToken = localStorage.getItem('refreshToken');
+    if (refreshToken) {
+      // Revoke the refresh token; the local session is cleared regardless
+      authAPI.logout(refreshToken).catch(() => {});
+    }
+    clearSession();
+    setUser(null);
+  };
+
+  const logoutAll = async () => {
+    await authAPI.logoutAll();
+    clearSession();
     setUser(null);
   };
 
+  const updateUser = (updated: User) => {
+    setUser(updated);
+    localStorage.setItem('user', JSON.stringify(updated));
+  };
+
   return (
-    <AuthContext.Provider value={{ user, loading, login, signUp, logout }}>
+    <AuthContext.Provider value={{ user, loading, login, loginTwoFactor, loginWithPasskey, loginWithOidc, loginWithMagicLink, signUp, logout, logoutAll, updateUser }}>
       {children}
     </AuthContext.Provider>
   );
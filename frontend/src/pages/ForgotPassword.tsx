@@ -0,0 +1,59 @@
+import { useState, FormEvent } from 'react';
+import { Link } from 'react-router-dom';
+import { authAPI } from '../services/api';
+
+function ForgotPassword() {
+  const [email, setEmail] = useState('');
+  const [message, setMessage] = useState('');
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+
+  const handleSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    setError('');
+    setLoading(true);
+
+    try {
+      const response = await authAPI.forgotPassword(email);
+      setMessage(response.data.message);
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setError(error.response?.data?.error || 'Failed to send reset link');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  return (
+    <div className="form-container">
+      <h1 className="form-title">Forgot Password</h1>
+      <p className="form-subtitle">We'll email you a link to choose a new one</p>
+      {error && <div className="alert alert-error">{error}</div>}
+      {message ? (
+        <div className="alert alert-success">{message}</div>
+      ) : (
+        <form onSubmit={handleSubmit}>
+          <div className="form-group">
+            <label htmlFor="email">Email</label>
+            <input
+              type="email"
+              id="email"
+              value={email}
+              onChange={(e) => setEmail(e.target.value)}
+              required
+              placeholder="Enter your email"
+            />
+          </div>
+          <button type="submit" className="btn btn-primary" style={{ width: '100%' }} disabled={loading}>
+            {loading ? 'Sending...' : 'Send reset link'}
+          </button>
+        </form>
+      )}
+      <p className="form-link">
+        Remembered it? <Link to="/login">Login</Link>
+      </p>
+    </div>
+  );
+}
+
+export default ForgotPassword;
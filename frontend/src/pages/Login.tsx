@@ -1,13 +1,41 @@
-import { useState, FormEvent } from 'react';
-import { Link } from 'react-router-dom';
+import { useState, useEffect, FormEvent } from 'react';
+import { Link, useLocation, useSearchParams } from 'react-router-dom';
 import { useAuth } from '../context/AuthContext';
+import { authAPI } from '../services/api';
+import { passkeysSupported } from '../services/webauthn';
+import { OIDCConfig } from '../types';
+
+// Why single sign-on sent the user back, from the sso_error parameter
+const ssoErrors: Record<string, string> = {
+  denied: 'Single sign-on was cancelled',
+  expired: 'Single sign-on took too long, please try again',
+  not_allowed: 'Your account cannot sign in to PollApp',
+  unverified: 'Confirm the email address of your PollApp account before using single sign-on',
+  unavailable: 'Single sign-on is unavailable right now',
+};
 
 function Login() {
   const [email, setEmail] = useState('');
   const [password, setPassword] = useState('');
-  const [error, setError] = useState('');
+  const location = useLocation();
+  const [searchParams] = useSearchParams();
+  // Single sign-on hands over its two-factor challenge in the route state
+  const [challengeToken, setChallengeToken] = useState<string | null>(
+    (location.state as { challengeToken?: string } | null)?.challengeToken ?? null
+  );
+  const [code, setCode] = useState('');
+  const [useRecoveryCode, setUseRecoveryCode] = useState(false);
+  const ssoError = searchParams.get('sso_error');
+  const [error, setError] = useState(ssoError ? ssoErrors[ssoError] || 'Single sign-on failed' : '');
   const [loading, setLoading] = useState(false);
-  const { login } = useAuth();
+  const [sso, setSso] = useState<OIDCConfig | null>(null);
+  const { login, loginTwoFactor, loginWithPasskey } = useAuth();
+
+  useEffect(() => {
+    authAPI.oidcConfig()
+      .then((response) => setSso(response.data))
+      .catch(() => setSso(null));
+  }, []);
 
   const handleSubmit = async (e: FormEvent) => {
     e.preventDefault();
@@ -15,7 +43,7 @@ function Login() {
     setLoading(true);
 
     try {
-      await login(email, password);
+      setChallengeToken(await login(email, password));
     } catch (err: unknown) {
       const error = err as { response?: { data?: { error?: string } } };
       setError(error.response?.data?.error || 'Failed to login');
@@ -24,6 +52,88 @@ function Login() {
     }
   };
 
+  const handleCodeSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    if (!challengeToken) return;
+    setError('');
+    setLoading(true);
+
+    try {
+      await loginTwoFactor(challengeToken, useRecoveryCode ? { recovery_code: code } : { code });
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setError(error.response?.data?.error || 'Failed to login');
+      setCode('');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const handlePasskey = async () => {
+    setError('');
+    setLoading(true);
+
+    try {
+      await loginWithPasskey();
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } }; name?: string };
+      // Closing the browser's passkey prompt is not an error worth showing
+      if (error.name !== 'NotAllowedError') {
+        setError(error.response?.data?.error || 'Failed to login with a passkey');
+      }
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  const startOver = () => {
+    setChallengeToken(null);
+    setCode('');
+    setPassword('');
+    setError('');
+  };
+
+  if (challengeToken) {
+    return (
+      <div className="form-container">
+        <h1 className="form-title">Two-Factor Login</h1>
+        <p className="form-subtitle">
+          {useRecoveryCode
+            ? 'Enter one of your recovery codes'
+            : 'Enter the 6-digit code from your authenticator app'}
+        </p>
+        {error && <div className="alert alert-error">{error}</div>}
+        <form onSubmit={handleCodeSubmit}>
+          <div className="form-group">
+            <label htmlFor="code">{useRecoveryCode ? 'Recovery Code' : 'Code'}</label>
+            <input
+              type="text"
+              id="code"
+              value={code}
+              onChange={(e) => setCode(e.target.value)}
+              required
+              autoFocus
+              autoComplete="one-time-code"
+              inputMode={useRecoveryCode ? 'text' : 'numeric'}
+              placeholder={useRecoveryCode ? 'xxxxx-xxxxx' : '123456'}
+            />
+          </div>
+          <button type="submit" className="btn btn-primary" style={{ width: '100%' }} disabled={loading}>
+            {loading ? 'Verifying...' : 'Verify'}
+          </button>
+        </form>
+        <p className="form-link">
+          <a href="#" onClick={(e) => { e.preventDefault(); setUseRecoveryCode(!useRecoveryCode); setCode(''); }}>
+            {useRecoveryCode ? 'Use your authenticator app' : 'Use a recovery code'}
+          </a>
+        </p>
+        <p className="form-link">
+          <a href="#" onClick={(e) => { e.preventDefault(); startOver(); }}>Back to login</a>
+        </p>
+      </div>
+    );
+  }
+
   return (
     <div className="form-container">
       <h1 className="form-title">Welcome Back</h1>
@@ -56,6 +166,19 @@ function Login() {
           {loading ? 'Logging in...' : 'Login'}
         </button>
       </form>
+      {passkeysSupported() && (
+        <button className="btn btn-outline" style={{ width: '100%', marginTop: '0.75rem' }} onClick={handlePasskey} disabled={loading}>
+          Sign in with a passkey
+        </button>
+      )}
+      {sso?.enabled && (
+        <a href={authAPI.oidcLoginURL} className="btn btn-outline" style={{ width: '100%', marginTop: '0.75rem' }}>
+          Sign in with {sso.name || 'SSO'}
+        </a>
+      )}
+      <p className="form-link">
+        <Link to="/forgot-password">Forgot password?</Link> · <Link to="/magic-link">Email me a sign-in link</Link>
+      </p>
       <p className="form-link">
         Don't have an account? <Link to="/signup">Sign up</Link>
       </p>
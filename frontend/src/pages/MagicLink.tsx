@@ -0,0 +1,96 @@
+import { useState, useEffect, useRef, FormEvent } from 'react';
+import { Link, useNavigate, useSearchParams } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+import { authAPI } from '../services/api';
+
+// MagicLink asks for a sign-in link, or signs in with the one in the URL
+function MagicLink() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token') || '';
+  const { loginWithMagicLink } = useAuth();
+  const navigate = useNavigate();
+  const [email, setEmail] = useState('');
+  const [message, setMessage] = useState('');
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+  // Links work once, so guard against effects running twice in development
+  const submitted = useRef(false);
+
+  useEffect(() => {
+    if (!token || submitted.current) return;
+    submitted.current = true;
+
+    loginWithMagicLink(token)
+      .then((challengeToken) => {
+        if (challengeToken) {
+          navigate('/login', { replace: true, state: { challengeToken } });
+        } else {
+          navigate('/', { replace: true });
+        }
+      })
+      .catch((err) => {
+        setError(err.response?.data?.error || 'Failed to sign in');
+      });
+  }, [token, loginWithMagicLink, navigate]);
+
+  const handleSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    setError('');
+    setLoading(true);
+
+    try {
+      const response = await authAPI.requestMagicLink(email);
+      setMessage(response.data.message);
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setError(error.response?.data?.error || 'Failed to send sign-in link');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  if (token) {
+    return (
+      <div className="form-container">
+        <h1 className="form-title">Email Sign-In</h1>
+        {error ? <div className="alert alert-error">{error}</div> : <p className="form-subtitle">Signing you in...</p>}
+        <p className="form-link">
+          <Link to="/magic-link">Send a new link</Link>
+        </p>
+      </div>
+    );
+  }
+
+  return (
+    <div className="form-container">
+      <h1 className="form-title">Email Sign-In</h1>
+      <p className="form-subtitle">We'll email you a link that signs you in, no password needed</p>
+      {error && <div className="alert alert-error">{error}</div>}
+      {message ? (
+        <div className="alert alert-success">{message}</div>
+      ) : (
+        <form onSubmit={handleSubmit}>
+          <div className="form-group">
+            <label htmlFor="email">Email</label>
+            <input
+              type="email"
+              id="email"
+              value={email}
+              onChange={(e) => setEmail(e.target.value)}
+              required
+              placeholder="Enter your email"
+            />
+          </div>
+          <button type="submit" className="btn btn-primary" style={{ width: '100%' }} disabled={loading}>
+            {loading ? 'Sending...' : 'Send sign-in link'}
+          </button>
+        </form>
+      )}
+      <p className="form-link">
+        Have your password? <Link to="/login">Login</Link>
+      </p>
+    </div>
+  );
+}
+
+export default MagicLink;
@@ -0,0 +1,42 @@
+import { useEffect, useRef, useState } from 'react';
+import { Link, useNavigate, useSearchParams } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+
+function OidcCallback() {
+  const [searchParams] = useSearchParams();
+  const code = searchParams.get('code') || '';
+  const { loginWithOidc } = useAuth();
+  const navigate = useNavigate();
+  const [error, setError] = useState(code ? '' : 'This sign-in link is incomplete.');
+  // Codes work once, so guard against effects running twice in development
+  const submitted = useRef(false);
+
+  useEffect(() => {
+    if (!code || submitted.current) return;
+    submitted.current = true;
+
+    loginWithOidc(code)
+      .then((challengeToken) => {
+        if (challengeToken) {
+          navigate('/login', { replace: true, state: { challengeToken } });
+        } else {
+          navigate('/', { replace: true });
+        }
+      })
+      .catch((err) => {
+        setError(err.response?.data?.error || 'Failed to sign in');
+      });
+  }, [code, loginWithOidc, navigate]);
+
+  return (
+    <div className="form-container">
+      <h1 className="form-title">Single Sign-On</h1>
+      {error ? <div className="alert alert-error">{error}</div> : <p className="form-subtitle">Signing you in...</p>}
+      <p className="form-link">
+        <Link to="/login">Back to login</Link>
+      </p>
+    </div>
+  );
+}
+
+export default OidcCallback;
@@ -1,12 +1,9 @@
-import { useState, useEffect, useCallback } from 'react';
-import { useParams, Link } from 'react-router-dom';
+import { useState, useEffect, useCallback, useRef } from 'react';
+import { useParams, useSearchParams, Link } from 'react-router-dom';
 import { Poll, User } from '../types';
-import { pollAPI } from '../services/api';
+import { freshToken, pollAPI } from '../services/api';
 import { useAuth } from '../context/AuthContext';
 
-// Polling interval in milliseconds (3 seconds)
-const POLL_INTERVAL = 3000;
-
 // Mark poll update as seen in localStorage
 const markUpdateAsSeen = (pollId: number, updatedAt: string) => {
   try {
@@ -30,6 +27,8 @@ const hasSeenUpdate = (pollId: number, updatedAt: string): boolean => {
 
 function PollDetail() {
   const { id } = useParams<{ id: string }>();
+  const [searchParams] = useSearchParams();
+  const linkKey = searchParams.get('key');
   const [poll, setPoll] = useState<Poll | null>(null);
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState('');
@@ -45,6 +44,9 @@ function PollDetail() {
   const fetchPoll = useCallback(async (showLoading = false, updateSelection = true) => {
     if (showLoading) setLoading(true);
     try {
+      if (linkKey) {
+        await pollAPI.join(Number(id), linkKey);
+      }
       const response = await pollAPI.get(Number(id));
       setPoll(response.data);
       // Only update selection if not actively changing vote and updateSelection is true
@@ -57,22 +59,52 @@ function PollDetail() {
     } finally {
       if (showLoading) setLoading(false);
     }
-  }, [id]);
+  }, [id, linkKey]);
 
   // Initial fetch
   useEffect(() => {
     fetchPoll(true, true);
   }, [fetchPoll]);
 
-  // Auto-refresh every 3 seconds - don't update selection if user is changing vote
+  // Live updates over Server-Sent Events - don't update selection if user is changing vote
+  const isChangingVoteRef = useRef(isChangingVote);
+  isChangingVoteRef.current = isChangingVote;
   useEffect(() => {
-    const interval = setInterval(() => {
-      // Pass false for updateSelection when user is actively changing their vote
-      fetchPoll(false, !isChangingVote);
-    }, POLL_INTERVAL);
-
-    return () => clearInterval(interval);
-  }, [fetchPoll, isChangingVote]);
+    let source: EventSource | null = null;
+    let stopped = false;
+
+    const connect = async () => {
+      // EventSource cannot refresh its token, so reconnect with a fresh one
+      // whenever it gives up
+      await freshToken();
+      if (stopped) return;
+      const es = new EventSource(pollAPI.streamURL(Number(id)));
+      source = es;
+      es.addEventListener('poll', (event) => {
+        const updated: Poll = JSON.parse((event as MessageEvent).data);
+        setPoll(updated);
+        if (!isChangingVoteRef.current && updated.user_voted_option_id) {
+          setSelectedOption(updated.user_voted_option_id);
+        }
+      });
+      es.addEventListener('deleted', () => {
+        stopped = true;
+        es.close();
+        setError('This poll has been deleted');
+      });
+      es.onerror = () => {
+        if (es.readyState === EventSource.CLOSED && !stopped) {
+          setTimeout(connect, 5000);
+        }
+      };
+    };
+    connect();
+
+    return () => {
+      stopped = true;
+      source?.close();
+    };
+  }, [id]);
 
   // Mark update as seen when user views the poll
   useEffect(() => {
@@ -183,6 +215,12 @@ function PollDetail() {
         <p className="poll-detail-description">{poll.description}</p>
       )}
 
+      {poll.link_key && (
+        <p className="poll-detail-meta">
+          Unlisted — share this link: {`${window.location.origin}/polls/${poll.id}?key=${poll.link_key}`}
+        </p>
+      )}
+
       {error && <div className="alert alert-error">{error}</div>}
 
       {/* Poll Edited After Vote Notification - only show if not already seen */}
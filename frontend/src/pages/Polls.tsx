@@ -1,11 +1,12 @@
-import { useState, useEffect, useCallback } from 'react';
+import { useState, useEffect, useCallback, useRef } from 'react';
 import { Link } from 'react-router-dom';
-import { Poll } from '../types';
+import { Poll, PollPage } from '../types';
 import { pollAPI } from '../services/api';
 import { useAuth } from '../context/AuthContext';
 
 // Polling interval in milliseconds (3 seconds)
 const POLL_INTERVAL = 3000;
+const PAGE_SIZE = 20;
 
 // Helper to check if user has seen the poll update
 const getSeenUpdates = (): Record<number, string> => {
@@ -23,15 +24,20 @@ const hasSeenUpdate = (pollId: number, updatedAt: string): boolean => {
 
 function Polls() {
   const [polls, setPolls] = useState<Poll[]>([]);
+  const [nextCursor, setNextCursor] = useState<string | undefined>();
   const [loading, setLoading] = useState(true);
   const [error, setError] = useState('');
   const { user } = useAuth();
+  // Refreshes reload every page shown so far
+  const loadedCount = useRef(PAGE_SIZE);
 
   const fetchPolls = useCallback(async (showLoading = false) => {
     if (showLoading) setLoading(true);
     try {
-      const response = await pollAPI.list();
-      setPolls(response.data);
+      const response = await pollAPI.list({ limit: loadedCount.current });
+      const page: PollPage = response.data;
+      setPolls(page.polls);
+      setNextCursor(page.next_cursor);
       setError('');
     } catch {
       setError('Failed to fetch polls');
@@ -54,6 +60,19 @@ function Polls() {
     return () => clearInterval(interval);
   }, [fetchPolls]);
 
+  const loadMore = async () => {
+    if (!nextCursor) return;
+    try {
+      const response = await pollAPI.list({ limit: PAGE_SIZE, cursor: nextCursor });
+      const page: PollPage = response.data;
+      loadedCount.current += PAGE_SIZE;
+      setPolls((prev) => [...prev, ...page.polls]);
+      setNextCursor(page.next_cursor);
+    } catch {
+      setError('Failed to fetch polls');
+    }
+  };
+
   const handleDelete = async (id: number) => {
     if (!confirm('Are you sure you want to delete this poll?')) return;
 
@@ -149,6 +168,14 @@ function Polls() {
           ))}
         </div>
       )}
+
+      {nextCursor && (
+        <div style={{ display: 'flex', justifyContent: 'center', marginTop: '1.5rem' }}>
+          <button onClick={loadMore} className="btn btn-secondary">
+            Load more
+          </button>
+        </div>
+      )}
     </div>
   );
 }
@@ -0,0 +1,227 @@
+import { useState, FormEvent } from 'react';
+import { useAuth } from '../context/AuthContext';
+import { authAPI } from '../services/api';
+import TwoFactorSettings from '../components/TwoFactorSettings';
+import PasskeySettings from '../components/PasskeySettings';
+
+function Profile() {
+  const { user, updateUser } = useAuth();
+  const [username, setUsername] = useState(user?.username || '');
+  const [email, setEmail] = useState(user?.email || '');
+  // The password, or an authenticator code without one, confirms a new email
+  const [confirmation, setConfirmation] = useState('');
+  const [profileMessage, setProfileMessage] = useState('');
+  const [profileError, setProfileError] = useState('');
+  const [savingProfile, setSavingProfile] = useState(false);
+
+  const [currentPassword, setCurrentPassword] = useState('');
+  const [passwordCode, setPasswordCode] = useState('');
+  const [newPassword, setNewPassword] = useState('');
+  const [confirmPassword, setConfirmPassword] = useState('');
+  const [passwordMessage, setPasswordMessage] = useState('');
+  const [passwordError, setPasswordError] = useState('');
+  const [savingPassword, setSavingPassword] = useState(false);
+  // Accounts created through single sign-on start without a password
+  const hasPassword = user?.has_password !== false;
+  const emailChanging = email !== user?.email;
+  // Without a password or an authenticator, a first password is set from an
+  // emailed link
+  const passwordByEmail = !hasPassword && !user?.two_factor_enabled;
+
+  const handleProfileSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    setProfileMessage('');
+    setProfileError('');
+    setSavingProfile(true);
+
+    try {
+      const response = await authAPI.updateProfile({
+        username,
+        email,
+        ...(!emailChanging ? {} : hasPassword ? { password: confirmation } : { code: confirmation }),
+      });
+      const emailChanged = response.data.email !== user?.email;
+      updateUser(response.data);
+      setConfirmation('');
+      setProfileMessage(emailChanged
+        ? `Profile saved. We sent a link to ${response.data.email} to confirm it.`
+        : 'Profile saved.');
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setProfileError(error.response?.data?.error || 'Failed to update profile');
+    } finally {
+      setSavingProfile(false);
+    }
+  };
+
+  const handlePasswordSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    setPasswordMessage('');
+    setPasswordError('');
+
+    if (passwordByEmail) {
+      setSavingPassword(true);
+      try {
+        const response = await authAPI.changePassword({});
+        setPasswordMessage(response.data.message);
+      } catch (err: unknown) {
+        const error = err as { response?: { data?: { error?: string } } };
+        setPasswordError(error.response?.data?.error || 'Failed to send link');
+      } finally {
+        setSavingPassword(false);
+      }
+      return;
+    }
+
+    if (newPassword !== confirmPassword) {
+      setPasswordError('Passwords do not match');
+      return;
+    }
+
+    setSavingPassword(true);
+    try {
+      await authAPI.changePassword(hasPassword
+        ? { current_password: currentPassword, new_password: newPassword }
+        : { code: passwordCode, new_password: newPassword });
+      if (user && !hasPassword) {
+        updateUser({ ...user, has_password: true });
+      }
+      setCurrentPassword('');
+      setPasswordCode('');
+      setNewPassword('');
+      setConfirmPassword('');
+      setPasswordMessage('Password changed. Your other sessions have been signed out.');
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setPasswordError(error.response?.data?.error || 'Failed to change password');
+    } finally {
+      setSavingPassword(false);
+    }
+  };
+
+  return (
+    <div>
+      <div className="form-container">
+        <h1 className="form-title">Profile</h1>
+        <p className="form-subtitle">Changing your email means confirming the new address</p>
+        {profileError && <div className="alert alert-error">{profileError}</div>}
+        {profileMessage && <div className="alert alert-success">{profileMessage}</div>}
+        <form onSubmit={handleProfileSubmit}>
+          <div className="form-group">
+            <label htmlFor="username">Username</label>
+            <input
+              type="text"
+              id="username"
+              value={username}
+              onChange={(e) => setUsername(e.target.value)}
+              required
+            />
+          </div>
+          <div className="form-group">
+            <label htmlFor="email">Email</label>
+            <input
+              type="email"
+              id="email"
+              value={email}
+              onChange={(e) => setEmail(e.target.value)}
+              required
+            />
+          </div>
+          {emailChanging && (
+            <div className="form-group">
+              <label htmlFor="confirmation">
+                {hasPassword ? 'Current Password' : 'Code from your authenticator app'}
+              </label>
+              <input
+                type={hasPassword ? 'password' : 'text'}
+                id="confirmation"
+                inputMode={hasPassword ? undefined : 'numeric'}
+                autoComplete={hasPassword ? 'current-password' : 'one-time-code'}
+                value={confirmation}
+                onChange={(e) => setConfirmation(e.target.value)}
+                required
+              />
+            </div>
+          )}
+          <button type="submit" className="btn btn-primary" style={{ width: '100%' }} disabled={savingProfile}>
+            {savingProfile ? 'Saving...' : 'Save profile'}
+          </button>
+        </form>
+      </div>
+
+      <div className="form-container">
+        <h1 className="form-title">Password</h1>
+        <p className="form-subtitle">
+          {hasPassword
+            ? 'Other devices will be signed out'
+            : passwordByEmail
+              ? 'We will email you a link to set a password, so you can also sign in with your email'
+              : 'Set a password to also sign in with your email'}
+        </p>
+        {passwordError && <div className="alert alert-error">{passwordError}</div>}
+        {passwordMessage && <div className="alert alert-success">{passwordMessage}</div>}
+        <form onSubmit={handlePasswordSubmit}>
+          {hasPassword && (
+            <div className="form-group">
+              <label htmlFor="currentPassword">Current Password</label>
+              <input
+                type="password"
+                id="currentPassword"
+                value={currentPassword}
+                onChange={(e) => setCurrentPassword(e.target.value)}
+                required
+              />
+            </div>
+          )}
+          {!hasPassword && !passwordByEmail && (
+            <div className="form-group">
+              <label htmlFor="passwordCode">Code from your authenticator app</label>
+              <input
+                type="text"
+                id="passwordCode"
+                autoComplete="one-time-code"
+                inputMode="numeric"
+                value={passwordCode}
+                onChange={(e) => setPasswordCode(e.target.value)}
+                required
+              />
+            </div>
+          )}
+          {!passwordByEmail && (
+            <>
+              <div className="form-group">
+                <label htmlFor="newPassword">New Password</label>
+                <input
+                  type="password"
+                  id="newPassword"
+                  value={newPassword}
+                  onChange={(e) => setNewPassword(e.target.value)}
+                  required
+                />
+              </div>
+              <div className="form-group">
+                <label htmlFor="confirmPassword">Confirm New Password</label>
+                <input
+                  type="password"
+                  id="confirmPassword"
+                  value={confirmPassword}
+                  onChange={(e) => setConfirmPassword(e.target.value)}
+                  required
+                />
+              </div>
+            </>
+          )}
+          <button type="submit" className="btn btn-primary" style={{ width: '100%' }} disabled={savingPassword}>
+            {savingPassword ? 'Saving...' : passwordByEmail ? 'Email me a link' : 'Change password'}
+          </button>
+        </form>
+      </div>
+
+      <TwoFactorSettings />
+
+      <PasskeySettings />
+    </div>
+  );
+}
+
+export default Profile;
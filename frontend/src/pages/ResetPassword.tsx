@@ -0,0 +1,86 @@
+import { useState, FormEvent } from 'react';
+import { Link, useNavigate, useSearchParams } from 'react-router-dom';
+import { authAPI } from '../services/api';
+
+function ResetPassword() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token') || '';
+  const [password, setPassword] = useState('');
+  const [confirmPassword, setConfirmPassword] = useState('');
+  const [error, setError] = useState('');
+  const [loading, setLoading] = useState(false);
+  const navigate = useNavigate();
+
+  const handleSubmit = async (e: FormEvent) => {
+    e.preventDefault();
+    setError('');
+
+    if (password !== confirmPassword) {
+      setError('Passwords do not match');
+      return;
+    }
+
+    setLoading(true);
+    try {
+      await authAPI.resetPassword(token, password);
+      navigate('/login');
+    } catch (err: unknown) {
+      const error = err as { response?: { data?: { error?: string } } };
+      setError(error.response?.data?.error || 'Failed to reset password');
+    } finally {
+      setLoading(false);
+    }
+  };
+
+  if (!token) {
+    return (
+      <div className="form-container">
+        <h1 className="form-title">Reset Password</h1>
+        <div className="alert alert-error">This reset link is incomplete.</div>
+        <p className="form-link">
+          <Link to="/forgot-password">Request a new link</Link>
+        </p>
+      </div>
+    );
+  }
+
+  return (
+    <div className="form-container">
+      <h1 className="form-title">Reset Password</h1>
+      <p className="form-subtitle">Choose a new password. You'll be signed out everywhere else.</p>
+      {error && <div className="alert alert-error">{error}</div>}
+      <form onSubmit={handleSubmit}>
+        <div className="form-group">
+          <label htmlFor="password">New Password</label>
+          <input
+            type="password"
+            id="password"
+            value={password}
+            onChange={(e) => setPassword(e.target.value)}
+            required
+            placeholder="Enter a new password"
+          />
+        </div>
+        <div className="form-group">
+          <label htmlFor="confirmPassword">Confirm Password</label>
+          <input
+            type="password"
+            id="confirmPassword"
+            value={confirmPassword}
+            onChange={(e) => setConfirmPassword(e.target.value)}
+            required
+            placeholder="Repeat the new password"
+          />
+        </div>
+        <button type="submit" className="btn btn-primary" style={{ width: '100%' }} disabled={loading}>
+          {loading ? 'Saving...' : 'Reset password'}
+        </button>
+      </form>
+      <p className="form-link">
+        <Link to="/forgot-password">Request a new link</Link>
+      </p>
+    </div>
+  );
+}
+
+export default ResetPassword;
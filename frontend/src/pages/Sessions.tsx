@@ -0,0 +1,77 @@
+import { useState, useEffect } from 'react';
+import { Session } from '../types';
+import { authAPI } from '../services/api';
+import { useAuth } from '../context/AuthContext';
+
+function Sessions() {
+  const [sessions, setSessions] = useState<Session[]>([]);
+  const [loading, setLoading] = useState(true);
+  const [error, setError] = useState('');
+  const { logoutAll } = useAuth();
+
+  useEffect(() => {
+    authAPI.sessions()
+      .then((response) => setSessions(response.data))
+      .catch(() => setError('Failed to fetch sessions'))
+      .finally(() => setLoading(false));
+  }, []);
+
+  const handleRevoke = async (id: number) => {
+    try {
+      await authAPI.revokeSession(id);
+      setSessions(sessions.filter((s) => s.id !== id));
+    } catch {
+      setError('Failed to sign out session');
+    }
+  };
+
+  const handleLogoutAll = async () => {
+    if (!confirm('Sign out of every device, including this one?')) return;
+    try {
+      await logoutAll();
+    } catch {
+      setError('Failed to sign out');
+    }
+  };
+
+  if (loading) {
+    return <div className="loading">Loading sessions...</div>;
+  }
+
+  return (
+    <div>
+      <div className="polls-header">
+        <h1>Active <span>Sessions</span></h1>
+        <button onClick={handleLogoutAll} className="btn btn-danger">
+          Sign out all sessions
+        </button>
+      </div>
+
+      {error && <div className="alert alert-error">{error}</div>}
+
+      <div className="session-list">
+        {sessions.map((s) => (
+          <div key={s.id} className="poll-card">
+            <div className="poll-card-header">
+              <div>
+                <h3>{s.user_agent || 'Unknown device'}</h3>
+                <div className="poll-card-meta">
+                  {s.ip} • Signed in {new Date(s.created_at).toLocaleDateString()} • Last active {new Date(s.last_seen_at).toLocaleString()}
+                </div>
+              </div>
+              {s.current ? (
+                <span className="poll-card-badge">This device</span>
+              ) : (
+                <button onClick={() => handleRevoke(s.id)} className="btn btn-outline">
+                  Sign out
+                </button>
+              )}
+            </div>
+          </div>
+        ))}
+      </div>
+    </div>
+  );
+}
+
+export default Sessions;
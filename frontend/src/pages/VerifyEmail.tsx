@@ -0,0 +1,45 @@
+import { useEffect, useRef, useState } from 'react';
+import { Link, useSearchParams } from 'react-router-dom';
+import { useAuth } from '../context/AuthContext';
+import { authAPI } from '../services/api';
+
+function VerifyEmail() {
+  const [searchParams] = useSearchParams();
+  const token = searchParams.get('token') || '';
+  const { user, updateUser } = useAuth();
+  const [status, setStatus] = useState<'verifying' | 'verified' | 'failed'>(token ? 'verifying' : 'failed');
+  const [error, setError] = useState(token ? '' : 'This verification link is incomplete.');
+  // Tokens work once, so guard against effects running twice in development
+  const submitted = useRef(false);
+
+  useEffect(() => {
+    if (!token || submitted.current) return;
+    submitted.current = true;
+
+    authAPI.verifyEmail(token)
+      .then((response) => {
+        if (user && user.id === response.data.id) {
+          updateUser(response.data);
+        }
+        setStatus('verified');
+      })
+      .catch((err) => {
+        setError(err.response?.data?.error || 'Failed to verify email');
+        setStatus('failed');
+      });
+  }, [token, user, updateUser]);
+
+  return (
+    <div className="form-container">
+      <h1 className="form-title">Email Verification</h1>
+      {status === 'verifying' && <p className="form-subtitle">Verifying your email address...</p>}
+      {status === 'verified' && <div className="alert alert-success">Your email address is confirmed.</div>}
+      {status === 'failed' && <div className="alert alert-error">{error}</div>}
+      <p className="form-link">
+        {user ? <Link to="/">Go to polls</Link> : <Link to="/login">Login</Link>}
+      </p>
+    </div>
+  );
+}
+
+export default VerifyEmail;
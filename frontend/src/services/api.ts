@@ -1,4 +1,5 @@
-import axios from 'axios';
+import axios, { AxiosError, InternalAxiosRequestConfig } from 'axios';
+import { AuthResponse, PollListParams } from '../types';
 
 // Use environment variable for API URL
 // In production (Render), set VITE_API_URL to your backend URL
@@ -21,14 +22,71 @@ api.interceptors.request.use((config) => {
   return config;
 });
 
-// Handle auth errors
+export const clearSession = () => {
+  localStorage.removeItem('token');
+  localStorage.removeItem('refreshToken');
+  localStorage.removeItem('user');
+};
+
+export const saveSession = (data: AuthResponse) => {
+  localStorage.setItem('token', data.token);
+  localStorage.setItem('refreshToken', data.refresh_token);
+  localStorage.setItem('user', JSON.stringify(data.user));
+};
+
+// Concurrent requests that hit an expired token share a single refresh,
+// since each refresh token can only be used once
+let refreshing: Promise<string> | null = null;
+
+export const refreshAccessToken = (): Promise<string> => {
+  if (!refreshing) {
+    const refreshToken = localStorage.getItem('refreshToken');
+    refreshing = (refreshToken
+      ? axios.post<AuthResponse>(`${API_URL}/api/auth/refresh`, { refresh_token: refreshToken })
+          .then((response) => {
+            saveSession(response.data);
+            return response.data.token;
+          })
+      : Promise.reject(new Error('No refresh token'))
+    ).finally(() => {
+      refreshing = null;
+    });
+  }
+  return refreshing;
+};
+
+// freshToken returns an access token valid for at least another 30 seconds,
+// for connections such as EventSource that cannot retry through axios
+export const freshToken = async (): Promise<string | null> => {
+  const token = localStorage.getItem('token');
+  if (!token) return null;
+  try {
+    const { exp } = JSON.parse(atob(token.split('.')[1].replace(/-/g, '+').replace(/_/g, '/')));
+    if (exp * 1000 > Date.now() + 30000) return token;
+    return await refreshAccessToken();
+  } catch {
+    return token;
+  }
+};
+
+// Requests whose 401 means bad credentials rather than an expired access token
+const credentialPaths = ['/api/auth/login', '/api/auth/login/2fa', '/api/auth/passkeys/login/finish', '/api/auth/oidc/exchange', '/api/auth/magic-link/login', '/api/auth/signup', '/api/auth/refresh', '/api/auth/logout', '/api/auth/forgot-password', '/api/auth/reset-password', '/api/auth/verify-email'];
+
+// Refresh expired access tokens and retry; give up and log out if that fails
 api.interceptors.response.use(
   (response) => response,
-  (error) => {
-    if (error.response?.status === 401) {
-      localStorage.removeItem('token');
-      localStorage.removeItem('user');
-      window.location.href = '/login';
+  async (error: AxiosError) => {
+    const config = error.config as (InternalAxiosRequestConfig & { _retried?: boolean }) | undefined;
+    if (error.response?.status === 401 && config && !config._retried && !credentialPaths.includes(config.url ?? '')) {
+      config._retried = true;
+      try {
+        const token = await refreshAccessToken();
+        config.headers.Authorization = `Bearer ${token}`;
+        return api(config);
+      } catch {
+        clearSession();
+        window.location.href = '/login';
+      }
     }
     return Promise.reject(error);
   }
@@ -41,15 +99,92 @@ export const authAPI = {
   
   login: (data: { email: string; password: string }) =>
     api.post('/api/auth/login', data),
+
+  loginTwoFactor: (data: { challenge_token: string; code?: string; recovery_code?: string }) =>
+    api.post('/api/auth/login/2fa', data),
   
   me: () => api.get('/api/auth/me'),
+
+  updateProfile: (data: { username?: string; email?: string; password?: string; code?: string }) =>
+    api.patch('/api/auth/me', data),
+
+  changePassword: (data: { current_password?: string; new_password?: string; code?: string }) =>
+    api.post('/api/auth/me/password', data),
+
+  logout: (refreshToken: string) =>
+    api.post('/api/auth/logout', { refresh_token: refreshToken }),
+
+  logoutAll: () => api.post('/api/auth/logout-all'),
+
+  sessions: () => api.get('/api/auth/sessions'),
+
+  revokeSession: (id: number) => api.delete(`/api/auth/sessions/${id}`),
+
+  forgotPassword: (email: string) =>
+    api.post('/api/auth/forgot-password', { email }),
+
+  resetPassword: (token: string, password: string) =>
+    api.post('/api/auth/reset-password', { token, password }),
+
+  requestMagicLink: (email: string) =>
+    api.post('/api/auth/magic-link', { email }),
+
+  magicLinkLogin: (token: string) =>
+    api.post('/api/auth/magic-link/login', { token }),
+
+  verifyEmail: (token: string) =>
+    api.post('/api/auth/verify-email', { token }),
+
+  resendVerification: () => api.post('/api/auth/verify-email/resend'),
+
+  setupTwoFactor: (password: string) => api.post('/api/auth/2fa/setup', { password }),
+
+  enableTwoFactor: (code: string, password: string) => api.post('/api/auth/2fa/enable', { code, password }),
+
+  // Accounts without a password confirm with a code from the authenticator app
+  disableTwoFactor: (confirm: { password?: string; code?: string }) =>
+    api.post('/api/auth/2fa/disable', confirm),
+
+  regenerateRecoveryCodes: (confirm: { password?: string; code?: string }) =>
+    api.post('/api/auth/2fa/recovery-codes', confirm),
+
+  passkeys: () => api.get('/api/auth/passkeys'),
+
+  // Adding or removing a passkey is confirmed like two-factor changes
+  beginPasskeyRegistration: (confirm: { password?: string; code?: string }) =>
+    api.post('/api/auth/passkeys/register/begin', confirm),
+
+  finishPasskeyRegistration: (data: { ceremony_token: string; name: string; credential: object; password?: string; code?: string }) =>
+    api.post('/api/auth/passkeys/register/finish', data),
+
+  deletePasskey: (id: number, confirm: { password?: string; code?: string }) =>
+    api.delete(`/api/auth/passkeys/${id}`, { data: confirm }),
+
+  beginPasskeyLogin: () => api.post('/api/auth/passkeys/login/begin'),
+
+  finishPasskeyLogin: (data: { ceremony_token: string; credential: object }) =>
+    api.post('/api/auth/passkeys/login/finish', data),
+
+  oidcConfig: () => api.get('/api/auth/oidc/config'),
+
+  // The browser navigates here to sign in with the identity provider
+  oidcLoginURL: `${API_URL}/api/auth/oidc/login`,
+
+  oidcExchange: (code: string) => api.post('/api/auth/oidc/exchange', { code }),
 };
 
 // Poll APIs
 export const pollAPI = {
-  list: () => api.get('/api/polls'),
+  list: (params?: PollListParams) => api.get('/api/polls', { params }),
   
   get: (id: number) => api.get(`/api/polls/${id}`),
+
+  // Opening an unlisted poll's link (?key=...) grants access to it
+  join: (id: number, key: string) => api.post(`/api/polls/${id}/join`, { key }),
+
+  // EventSource cannot send headers, so the token goes in the query string
+  streamURL: (id: number) =>
+    `${API_URL}/api/polls/${id}/stream?token=${encodeURIComponent(localStorage.getItem('token') || '')}`,
   
   create: (data: { title: string; description: string; options: string[] }) =>
     api.post('/api/polls', data),
@@ -78,6 +213,10 @@ export const notificationAPI = {
   markAsRead: (id: number) => api.put(`/api/notifications/${id}/read`),
   
   markAllAsRead: () => api.post('/api/notifications/mark-all-read'),
+
+  // WebSockets cannot send headers, so the token goes in the query string
+  socketURL: () =>
+    `${API_URL.replace(/^http/, 'ws')}/api/notifications/ws?token=${encodeURIComponent(localStorage.getItem('token') || '')}`,
 };
 
 export default api;
@@ -0,0 +1,90 @@
+// The server sends WebAuthn options with binary fields base64url encoded and
+// expects credentials back in the same form; the browser API uses ArrayBuffers.
+
+const toBuffer = (value: string): ArrayBuffer => {
+  const base64 = value.replace(/-/g, '+').replace(/_/g, '/');
+  const padded = base64 + '='.repeat((4 - (base64.length % 4)) % 4);
+  return Uint8Array.from(atob(padded), (c) => c.charCodeAt(0)).buffer;
+};
+
+const toBase64url = (buffer: ArrayBuffer): string =>
+  btoa(String.fromCharCode(...new Uint8Array(buffer)))
+    .replace(/\+/g, '-')
+    .replace(/\//g, '_')
+    .replace(/=+$/, '');
+
+interface CredentialDescriptorJSON {
+  id: string;
+  type: PublicKeyCredentialType;
+  transports?: AuthenticatorTransport[];
+}
+
+const toDescriptors = (list?: CredentialDescriptorJSON[]) =>
+  list?.map((c) => ({ ...c, id: toBuffer(c.id) }));
+
+export interface CreationOptionsJSON {
+  publicKey: Omit<PublicKeyCredentialCreationOptions, 'challenge' | 'user' | 'excludeCredentials'> & {
+    challenge: string;
+    user: Omit<PublicKeyCredentialUserEntity, 'id'> & { id: string };
+    excludeCredentials?: CredentialDescriptorJSON[];
+  };
+}
+
+export interface RequestOptionsJSON {
+  publicKey: Omit<PublicKeyCredentialRequestOptions, 'challenge' | 'allowCredentials'> & {
+    challenge: string;
+    allowCredentials?: CredentialDescriptorJSON[];
+  };
+}
+
+export const createPasskey = async (options: CreationOptionsJSON) => {
+  const publicKey = options.publicKey;
+  const credential = (await navigator.credentials.create({
+    publicKey: {
+      ...publicKey,
+      challenge: toBuffer(publicKey.challenge),
+      user: { ...publicKey.user, id: toBuffer(publicKey.user.id) },
+      excludeCredentials: toDescriptors(publicKey.excludeCredentials),
+    },
+  })) as PublicKeyCredential | null;
+  if (!credential) throw new Error('No passkey was created');
+
+  const response = credential.response as AuthenticatorAttestationResponse;
+  return {
+    id: credential.id,
+    rawId: toBase64url(credential.rawId),
+    type: credential.type,
+    response: {
+      clientDataJSON: toBase64url(response.clientDataJSON),
+      attestationObject: toBase64url(response.attestationObject),
+      transports: response.getTransports?.() ?? [],
+    },
+  };
+};
+
+export const getPasskey = async (options: RequestOptionsJSON) => {
+  const publicKey = options.publicKey;
+  const credential = (await navigator.credentials.get({
+    publicKey: {
+      ...publicKey,
+      challenge: toBuffer(publicKey.challenge),
+      allowCredentials: toDescriptors(publicKey.allowCredentials),
+    },
+  })) as PublicKeyCredential | null;
+  if (!credential) throw new Error('No passkey was chosen');
+
+  const response = credential.response as AuthenticatorAssertionResponse;
+  return {
+    id: credential.id,
+    rawId: toBase64url(credential.rawId),
+    type: credential.type,
+    response: {
+      clientDataJSON: toBase64url(response.clientDataJSON),
+      authenticatorData: toBase64url(response.authenticatorData),
+      signature: toBase64url(response.signature),
+      userHandle: response.userHandle ? toBase64url(response.userHandle) : undefined,
+    },
+  };
+};
+
+export const passkeysSupported = () => typeof window !== 'undefined' && !!window.PublicKeyCredential;
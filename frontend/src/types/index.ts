@@ -2,12 +2,24 @@ export interface User {
   id: number;
   username: string;
   email: string;
+  // Only set on the signed-in user
+  email_verified?: boolean;
+  two_factor_enabled?: boolean;
+  has_password?: boolean;
+}
+
+export interface ScoreBucket {
+  score: number;
+  count: number;
 }
 
 export interface Option {
   id: number;
   text: string;
   vote_count: number;
+  average_score?: number;
+  median_score?: number;
+  score_histogram?: ScoreBucket[];
 }
 
 export interface Poll {
@@ -18,15 +30,90 @@ export interface Poll {
   options: Option[];
   created_at: string;
   updated_at: string;
+  opens_at?: string;
+  closes_at?: string;
+  status: 'scheduled' | 'open' | 'closed';
+  ballot_type: 'single' | 'multiple' | 'ranked' | 'score';
+  min_choices: number;
+  max_choices: number;
+  score_min?: number;
+  score_max?: number;
+  anonymous: boolean;
+  visibility: 'public' | 'unlisted' | 'private' | 'team';
+  team_id?: number;
+  invitees?: User[];
+  link_key?: string; // creator of an unlisted poll only
+  results_visibility: 'always' | 'after_vote' | 'after_close' | 'creator_only';
+  results_hidden: boolean;
   user_voted_option_id?: number;
+  user_voted_option_ids?: number[];
+  user_scores?: Record<number, number>;
   poll_edited_after_vote?: boolean;
 }
 
+export interface Team {
+  id: number;
+  name: string;
+  owner: User;
+  members: User[];
+  created_at: string;
+}
+
+export interface PollPage {
+  polls: Poll[];
+  next_cursor?: string;
+}
+
+export interface PollListParams {
+  limit?: number;
+  cursor?: string;
+  sort?: 'newest' | 'most_votes' | 'updated';
+  creator?: number | 'me';
+  voted?: boolean;
+  status?: 'scheduled' | 'open' | 'closed';
+  q?: string;
+}
+
+export interface Invite {
+  id: number;
+  poll_id: number;
+  token?: string;
+  expires_at?: string;
+  max_uses?: number;
+  uses: number;
+  created_at: string;
+}
+
 export interface AuthResponse {
   token: string;
+  refresh_token: string;
   user: User;
 }
 
+// Login returns this instead of tokens when two-factor authentication is on
+export interface TwoFactorChallenge {
+  two_factor_required: true;
+  challenge_token: string;
+}
+
+export interface OIDCConfig {
+  enabled: boolean;
+  name?: string;
+}
+
+export interface Passkey {
+  id: number;
+  name: string;
+  backed_up: boolean;
+  created_at: string;
+  last_used_at?: string;
+}
+
+export interface TwoFactorSetup {
+  secret: string;
+  otpauth_uri: string;
+}
+
 export interface Notification {
   id: number;
   message: string;
@@ -35,3 +122,19 @@ export interface Notification {
   read: boolean;
   created_at: string;
 }
+
+export interface Session {
+  id: number;
+  user_agent: string;
+  ip: string;
+  created_at: string;
+  last_seen_at: string;
+  current: boolean;
+}
+
+export interface NotificationMessage {
+  type: 'sync' | 'notification' | 'read' | 'read_all';
+  notification?: Notification;
+  ids?: number[];
+  unread_count: number;
+}